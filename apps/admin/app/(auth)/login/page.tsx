@@ -1,9 +1,10 @@
 "use client";
 
-import { useState } from "react";
+import { Suspense, useState } from "react";
 import Link from "next/link";
+import { useSearchParams } from "next/navigation";
 import { Eye, EyeOff } from "@/lib/icons";
-import { useLogin } from "@/hooks/use-auth";
+import { useLogin, useLoginTwoFactor } from "@/hooks/use-auth";
 import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { LoginSchema, type LoginInput } from "@repo/shared/schemas";
@@ -25,8 +26,94 @@ function getErrorMessage(error: unknown): string {
   return "An unexpected error occurred. Please try again.";
 }
 
-export default function LoginPage() {
+function ErrorAlert({ error }: { error: unknown }) {
+  return (
+    <div className="rounded-xl bg-[var(--danger)]/[0.08] border border-[var(--danger)]/20 px-4 py-3.5 text-sm text-[var(--danger)] flex items-start gap-2.5">
+      <svg
+        className="h-4 w-4 shrink-0 mt-0.5"
+        fill="none"
+        viewBox="0 0 24 24"
+        stroke="currentColor"
+        strokeWidth={2}
+      >
+        <path
+          strokeLinecap="round"
+          strokeLinejoin="round"
+          d="M12 9v3.75m9-.75a9 9 0 11-18 0 9 9 0 0118 0zm-9 3.75h.008v.008H12v-.008z"
+        />
+      </svg>
+      <span>{getErrorMessage(error)}</span>
+    </div>
+  );
+}
+
+function TwoFactorForm({
+  challengeToken,
+  onCancel,
+}: {
+  challengeToken: string;
+  onCancel: () => void;
+}) {
+  const [code, setCode] = useState("");
+  const { mutate: verify, isPending, error } = useLoginTwoFactor();
+
+  const onSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!code.trim()) return;
+    verify({ challenge_token: challengeToken, code: code.trim() });
+  };
+
+  return (
+    <form onSubmit={onSubmit} className="space-y-5">
+      {error && <ErrorAlert error={error} />}
+
+      <div className="space-y-2">
+        <label
+          htmlFor="code"
+          className="block text-sm font-medium text-[var(--text-secondary)]"
+        >
+          Authentication code
+        </label>
+        <input
+          id="code"
+          value={code}
+          onChange={(e) => setCode(e.target.value)}
+          className={inputClass + " tracking-widest"}
+          placeholder="123456"
+          autoComplete="one-time-code"
+          autoFocus
+        />
+        <p className="text-xs text-[var(--text-muted)]">
+          Enter the 6-digit code from your authenticator app, or one of your
+          recovery codes.
+        </p>
+      </div>
+
+      <button
+        type="submit"
+        disabled={isPending || !code.trim()}
+        className="relative w-full rounded-xl bg-[var(--accent)] py-3.5 font-semibold text-white hover:bg-[var(--accent-hover)] disabled:opacity-50 transition-all duration-200 shadow-lg shadow-[var(--accent)]/20 hover:shadow-xl hover:shadow-[var(--accent)]/30 active:scale-[0.98]"
+      >
+        {isPending ? "Verifying..." : "Verify"}
+      </button>
+
+      <button
+        type="button"
+        onClick={onCancel}
+        className="w-full text-center text-sm text-[var(--text-muted)] hover:text-[var(--text-secondary)] transition-colors"
+      >
+        Back to sign in
+      </button>
+    </form>
+  );
+}
+
+function LoginForm() {
+  const searchParams = useSearchParams();
   const [showPassword, setShowPassword] = useState(false);
+  const [challengeToken, setChallengeToken] = useState(
+    searchParams.get("challenge_token") || ""
+  );
   const { mutate: login, isPending, error: serverError } = useLogin();
 
   const {
@@ -38,7 +125,13 @@ export default function LoginPage() {
   });
 
   const onSubmit = (data: LoginInput) => {
-    login(data);
+    login(data, {
+      onSuccess: (res) => {
+        if ("two_factor_required" in res.data) {
+          setChallengeToken(res.data.challenge_token);
+        }
+      },
+    });
   };
 
   return (
@@ -125,132 +218,124 @@ export default function LoginPage() {
 
           <div className="space-y-2">
             <h2 className="text-2xl font-bold text-[var(--text-primary)] tracking-tight">
-              Sign in
+              {challengeToken ? "Two-factor authentication" : "Sign in"}
             </h2>
             <p className="text-[var(--text-secondary)] text-[15px]">
-              Enter your credentials to access your account
+              {challengeToken
+                ? "One more step to confirm it's you"
+                : "Enter your credentials to access your account"}
             </p>
           </div>
 
-          <form onSubmit={handleSubmit(onSubmit)} className="space-y-5">
-            {serverError && (
-              <div className="rounded-xl bg-[var(--danger)]/[0.08] border border-[var(--danger)]/20 px-4 py-3.5 text-sm text-[var(--danger)] flex items-start gap-2.5">
-                <svg
-                  className="h-4 w-4 shrink-0 mt-0.5"
-                  fill="none"
-                  viewBox="0 0 24 24"
-                  stroke="currentColor"
-                  strokeWidth={2}
-                >
-                  <path
-                    strokeLinecap="round"
-                    strokeLinejoin="round"
-                    d="M12 9v3.75m9-.75a9 9 0 11-18 0 9 9 0 0118 0zm-9 3.75h.008v.008H12v-.008z"
-                  />
-                </svg>
-                <span>{getErrorMessage(serverError)}</span>
-              </div>
-            )}
-
-            <div className="space-y-2">
-              <label
-                htmlFor="email"
-                className="block text-sm font-medium text-[var(--text-secondary)]"
-              >
-                Email address
-              </label>
-              <input
-                id="email"
-                type="email"
-                {...register("email")}
-                className={errors.email ? errorInputClass : inputClass}
-                placeholder="you@example.com"
-                autoFocus
-              />
-              {errors.email && (
-                <p className="text-xs text-[var(--danger)] mt-1.5">
-                  {errors.email.message}
-                </p>
-              )}
-            </div>
+          {challengeToken ? (
+            <TwoFactorForm
+              challengeToken={challengeToken}
+              onCancel={() => setChallengeToken("")}
+            />
+          ) : (
+            <form onSubmit={handleSubmit(onSubmit)} className="space-y-5">
+              {serverError && <ErrorAlert error={serverError} />}
 
-            <div className="space-y-2">
-              <div className="flex items-center justify-between">
+              <div className="space-y-2">
                 <label
-                  htmlFor="password"
+                  htmlFor="email"
                   className="block text-sm font-medium text-[var(--text-secondary)]"
                 >
-                  Password
+                  Email address
                 </label>
-                <Link
-                  href="/forgot-password"
-                  className="text-xs text-[var(--accent)] hover:text-[var(--accent-hover)] transition-colors"
-                >
-                  Forgot password?
-                </Link>
-              </div>
-              <div className="relative">
                 <input
-                  id="password"
-                  type={showPassword ? "text" : "password"}
-                  {...register("password")}
-                  className={
-                    (errors.password ? errorInputClass : inputClass) + " pr-12"
-                  }
-                  placeholder="Enter your password"
+                  id="email"
+                  type="email"
+                  {...register("email")}
+                  className={errors.email ? errorInputClass : inputClass}
+                  placeholder="you@example.com"
+                  autoFocus
                 />
-                <button
-                  type="button"
-                  onClick={() => setShowPassword(!showPassword)}
-                  className="absolute right-3.5 top-1/2 -translate-y-1/2 text-[var(--text-muted)] hover:text-[var(--text-secondary)] transition-colors"
-                >
-                  {showPassword ? (
-                    <EyeOff className="h-[18px] w-[18px]" />
-                  ) : (
-                    <Eye className="h-[18px] w-[18px]" />
-                  )}
-                </button>
+                {errors.email && (
+                  <p className="text-xs text-[var(--danger)] mt-1.5">
+                    {errors.email.message}
+                  </p>
+                )}
               </div>
-              {errors.password && (
-                <p className="text-xs text-[var(--danger)] mt-1.5">
-                  {errors.password.message}
-                </p>
-              )}
-            </div>
 
-            <button
-              type="submit"
-              disabled={isPending}
-              className="relative w-full rounded-xl bg-[var(--accent)] py-3.5 font-semibold text-white hover:bg-[var(--accent-hover)] disabled:opacity-50 transition-all duration-200 shadow-lg shadow-[var(--accent)]/20 hover:shadow-xl hover:shadow-[var(--accent)]/30 active:scale-[0.98]"
-            >
-              {isPending ? (
-                <span className="flex items-center justify-center gap-2">
-                  <svg
-                    className="h-4 w-4 animate-spin"
-                    fill="none"
-                    viewBox="0 0 24 24"
+              <div className="space-y-2">
+                <div className="flex items-center justify-between">
+                  <label
+                    htmlFor="password"
+                    className="block text-sm font-medium text-[var(--text-secondary)]"
+                  >
+                    Password
+                  </label>
+                  <Link
+                    href="/forgot-password"
+                    className="text-xs text-[var(--accent)] hover:text-[var(--accent-hover)] transition-colors"
                   >
-                    <circle
-                      className="opacity-25"
-                      cx="12"
-                      cy="12"
-                      r="10"
-                      stroke="currentColor"
-                      strokeWidth="4"
-                    />
-                    <path
-                      className="opacity-75"
-                      fill="currentColor"
-                      d="M4 12a8 8 0 018-8V0C5.373 0 0 5.373 0 12h4zm2 5.291A7.962 7.962 0 014 12H0c0 3.042 1.135 5.824 3 7.938l3-2.647z"
-                    />
-                  </svg>
-                  Signing in...
-                </span>
-              ) : (
-                "Sign in"
-              )}
-            </button>
-          </form>
+                    Forgot password?
+                  </Link>
+                </div>
+                <div className="relative">
+                  <input
+                    id="password"
+                    type={showPassword ? "text" : "password"}
+                    {...register("password")}
+                    className={
+                      (errors.password ? errorInputClass : inputClass) + " pr-12"
+                    }
+                    placeholder="Enter your password"
+                  />
+                  <button
+                    type="button"
+                    onClick={() => setShowPassword(!showPassword)}
+                    className="absolute right-3.5 top-1/2 -translate-y-1/2 text-[var(--text-muted)] hover:text-[var(--text-secondary)] transition-colors"
+                  >
+                    {showPassword ? (
+                      <EyeOff className="h-[18px] w-[18px]" />
+                    ) : (
+                      <Eye className="h-[18px] w-[18px]" />
+                    )}
+                  </button>
+                </div>
+                {errors.password && (
+                  <p className="text-xs text-[var(--danger)] mt-1.5">
+                    {errors.password.message}
+                  </p>
+                )}
+              </div>
+
+              <button
+                type="submit"
+                disabled={isPending}
+                className="relative w-full rounded-xl bg-[var(--accent)] py-3.5 font-semibold text-white hover:bg-[var(--accent-hover)] disabled:opacity-50 transition-all duration-200 shadow-lg shadow-[var(--accent)]/20 hover:shadow-xl hover:shadow-[var(--accent)]/30 active:scale-[0.98]"
+              >
+                {isPending ? (
+                  <span className="flex items-center justify-center gap-2">
+                    <svg
+                      className="h-4 w-4 animate-spin"
+                      fill="none"
+                      viewBox="0 0 24 24"
+                    >
+                      <circle
+                        className="opacity-25"
+                        cx="12"
+                        cy="12"
+                        r="10"
+                        stroke="currentColor"
+                        strokeWidth="4"
+                      />
+                      <path
+                        className="opacity-75"
+                        fill="currentColor"
+                        d="M4 12a8 8 0 018-8V0C5.373 0 0 5.373 0 12h4zm2 5.291A7.962 7.962 0 014 12H0c0 3.042 1.135 5.824 3 7.938l3-2.647z"
+                      />
+                    </svg>
+                    Signing in...
+                  </span>
+                ) : (
+                  "Sign in"
+                )}
+              </button>
+            </form>
+          )}
 
           <p className="text-center text-sm text-[var(--text-muted)]">
             Don&apos;t have an account?{" "}
@@ -266,3 +351,11 @@ export default function LoginPage() {
     </div>
   );
 }
+
+export default function LoginPage() {
+  return (
+    <Suspense>
+      <LoginForm />
+    </Suspense>
+  );
+}
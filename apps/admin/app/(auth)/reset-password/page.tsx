@@ -0,0 +1,207 @@
+"use client";
+
+import { Suspense, useState } from "react";
+import Link from "next/link";
+import { useSearchParams } from "next/navigation";
+import { apiClient } from "@/lib/api-client";
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import {
+  ResetPasswordSchema,
+  type ResetPasswordInput,
+} from "@repo/shared/schemas";
+
+const inputClass =
+  "w-full rounded-xl border border-white/[0.08] bg-white/[0.04] px-4 py-3.5 text-[var(--text-primary)] placeholder:text-[var(--text-muted)] focus:border-[var(--accent)]/50 focus:outline-none focus:ring-2 focus:ring-[var(--accent)]/20 focus:bg-white/[0.06] transition-all duration-200 text-[15px]";
+const errorInputClass =
+  "w-full rounded-xl border border-[var(--danger)]/40 bg-[var(--danger)]/[0.04] px-4 py-3.5 text-[var(--text-primary)] placeholder:text-[var(--text-muted)] focus:border-[var(--danger)]/60 focus:outline-none focus:ring-2 focus:ring-[var(--danger)]/20 transition-all duration-200 text-[15px]";
+
+function getErrorMessage(error: unknown): string {
+  const axiosErr = error as { response?: { data?: { error?: { code?: string; message?: string } } } };
+  return axiosErr?.response?.data?.error?.message || "Unable to reset your password. Please try again.";
+}
+
+function ResetPasswordForm() {
+  const searchParams = useSearchParams();
+  const token = searchParams.get("token") || "";
+  const [done, setDone] = useState(false);
+  const [loading, setLoading] = useState(false);
+  const [serverError, setServerError] = useState("");
+  const [confirm, setConfirm] = useState("");
+  const [mismatch, setMismatch] = useState(false);
+
+  const {
+    register,
+    handleSubmit,
+    formState: { errors },
+  } = useForm<ResetPasswordInput>({
+    resolver: zodResolver(ResetPasswordSchema),
+    defaultValues: { token },
+  });
+
+  const onSubmit = async (data: ResetPasswordInput) => {
+    if (data.password !== confirm) {
+      setMismatch(true);
+      return;
+    }
+    setMismatch(false);
+    setServerError("");
+    setLoading(true);
+    try {
+      await apiClient.post("/api/auth/reset-password", data);
+      setDone(true);
+    } catch (err) {
+      setServerError(getErrorMessage(err));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  if (!token) {
+    return (
+      <div className="rounded-2xl border border-white/[0.06] bg-white/[0.02] p-8 text-center space-y-4">
+        <h3 className="text-lg font-semibold text-[var(--text-primary)]">
+          Invalid reset link
+        </h3>
+        <p className="text-[var(--text-secondary)] text-sm leading-relaxed">
+          This link is missing its reset token. Request a new link to continue.
+        </p>
+        <Link
+          href="/forgot-password"
+          className="inline-flex text-[var(--accent)] hover:text-[var(--accent-hover)] font-medium text-sm transition-colors"
+        >
+          Request a new link
+        </Link>
+      </div>
+    );
+  }
+
+  if (done) {
+    return (
+      <div className="rounded-2xl border border-white/[0.06] bg-white/[0.02] p-8 text-center space-y-4">
+        <h3 className="text-lg font-semibold text-[var(--text-primary)]">
+          Password updated
+        </h3>
+        <p className="text-[var(--text-secondary)] text-sm leading-relaxed">
+          Your password has been reset and you&apos;ve been signed out on every
+          device. Sign in with your new password.
+        </p>
+        <Link
+          href="/login"
+          className="inline-flex text-[var(--accent)] hover:text-[var(--accent-hover)] font-medium text-sm transition-colors"
+        >
+          Go to login
+        </Link>
+      </div>
+    );
+  }
+
+  return (
+    <form onSubmit={handleSubmit(onSubmit)} className="space-y-5">
+      {serverError && (
+        <div className="rounded-xl bg-[var(--danger)]/[0.08] border border-[var(--danger)]/20 px-4 py-3.5 text-sm text-[var(--danger)]">
+          {serverError}{" "}
+          <Link href="/forgot-password" className="underline">
+            Request a new link
+          </Link>
+        </div>
+      )}
+
+      <input type="hidden" {...register("token")} />
+
+      <div className="space-y-2">
+        <label
+          htmlFor="password"
+          className="block text-sm font-medium text-[var(--text-secondary)]"
+        >
+          New password
+        </label>
+        <input
+          id="password"
+          type="password"
+          autoComplete="new-password"
+          {...register("password")}
+          className={errors.password ? errorInputClass : inputClass}
+          placeholder="At least 8 characters"
+          autoFocus
+        />
+        {errors.password && (
+          <p className="text-xs text-[var(--danger)] mt-1.5">
+            {errors.password.message}
+          </p>
+        )}
+      </div>
+
+      <div className="space-y-2">
+        <label
+          htmlFor="confirm"
+          className="block text-sm font-medium text-[var(--text-secondary)]"
+        >
+          Confirm password
+        </label>
+        <input
+          id="confirm"
+          type="password"
+          autoComplete="new-password"
+          value={confirm}
+          onChange={(e) => setConfirm(e.target.value)}
+          className={mismatch ? errorInputClass : inputClass}
+          placeholder="Repeat your new password"
+        />
+        {mismatch && (
+          <p className="text-xs text-[var(--danger)] mt-1.5">
+            Passwords don&apos;t match
+          </p>
+        )}
+      </div>
+
+      <button
+        type="submit"
+        disabled={loading}
+        className="relative w-full rounded-xl bg-[var(--accent)] py-3.5 font-semibold text-white hover:bg-[var(--accent-hover)] disabled:opacity-50 transition-all duration-200 shadow-lg shadow-[var(--accent)]/20 hover:shadow-xl hover:shadow-[var(--accent)]/30 active:scale-[0.98]"
+      >
+        {loading ? "Resetting..." : "Reset password"}
+      </button>
+    </form>
+  );
+}
+
+export default function ResetPasswordPage() {
+  return (
+    <div className="flex min-h-screen items-center justify-center px-6 py-12 bg-[var(--bg-primary)]">
+      <div className="w-full max-w-[420px] space-y-8">
+        <div className="flex items-center justify-center gap-3">
+          <div className="h-10 w-10 rounded-xl bg-[var(--accent)] flex items-center justify-center shadow-lg shadow-[var(--accent)]/25">
+            <span className="text-lg font-bold text-white">G</span>
+          </div>
+          <span className="text-xl font-semibold text-white tracking-tight">
+            GritCMS
+          </span>
+        </div>
+
+        <div className="space-y-2">
+          <h2 className="text-2xl font-bold text-[var(--text-primary)] tracking-tight">
+            Choose a new password
+          </h2>
+          <p className="text-[var(--text-secondary)] text-[15px]">
+            Reset links work once and expire after an hour
+          </p>
+        </div>
+
+        <Suspense fallback={null}>
+          <ResetPasswordForm />
+        </Suspense>
+
+        <p className="text-center text-sm text-[var(--text-muted)]">
+          Remember your password?{" "}
+          <Link
+            href="/login"
+            className="text-[var(--accent)] hover:text-[var(--accent-hover)] font-medium transition-colors"
+          >
+            Sign in
+          </Link>
+        </p>
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,88 @@
+"use client";
+
+import { Suspense, useEffect, useRef, useState } from "react";
+import Link from "next/link";
+import { useSearchParams } from "next/navigation";
+import { useQueryClient } from "@tanstack/react-query";
+import { apiClient } from "@/lib/api-client";
+
+type Status = "verifying" | "verified" | "failed";
+
+function VerifyEmailHandler() {
+  const searchParams = useSearchParams();
+  const queryClient = useQueryClient();
+  const processed = useRef(false);
+  const [status, setStatus] = useState<Status>("verifying");
+  const [message, setMessage] = useState("");
+
+  useEffect(() => {
+    if (processed.current) return;
+    processed.current = true;
+
+    const token = searchParams.get("token");
+    if (!token) {
+      setStatus("failed");
+      setMessage("This verification link is missing its token.");
+      return;
+    }
+
+    apiClient
+      .post("/api/auth/verify-email", { token })
+      .then(() => {
+        setStatus("verified");
+        queryClient.invalidateQueries({ queryKey: ["me"] });
+      })
+      .catch((err) => {
+        setStatus("failed");
+        setMessage(
+          err.response?.data?.error?.message ||
+            "This verification link is invalid or has expired."
+        );
+      });
+  }, [searchParams, queryClient]);
+
+  if (status === "verifying") {
+    return (
+      <div className="text-center">
+        <div className="inline-flex h-10 w-10 animate-spin items-center justify-center rounded-full border-2 border-accent border-t-transparent" />
+        <p className="mt-4 text-sm text-text-secondary">Verifying your email...</p>
+      </div>
+    );
+  }
+
+  return (
+    <div className="w-full max-w-[420px] rounded-2xl border border-white/[0.06] bg-white/[0.02] p-8 text-center space-y-4">
+      <h3 className="text-lg font-semibold text-[var(--text-primary)]">
+        {status === "verified" ? "Email verified" : "Verification failed"}
+      </h3>
+      <p className="text-[var(--text-secondary)] text-sm leading-relaxed">
+        {status === "verified"
+          ? "Thanks for confirming your email address. You're all set."
+          : `${message} You can request a new link from your profile.`}
+      </p>
+      <Link
+        href={status === "verified" ? "/profile" : "/login"}
+        className="inline-flex text-[var(--accent)] hover:text-[var(--accent-hover)] font-medium text-sm transition-colors"
+      >
+        {status === "verified" ? "Go to your profile" : "Back to login"}
+      </Link>
+    </div>
+  );
+}
+
+export default function VerifyEmailPage() {
+  return (
+    <div className="flex min-h-screen items-center justify-center px-6 bg-bg-primary">
+      <Suspense
+        fallback={
+          <div className="text-center">
+            <div className="inline-flex h-10 w-10 animate-spin items-center justify-center rounded-full border-2 border-accent border-t-transparent" />
+            <p className="mt-4 text-sm text-text-secondary">Loading...</p>
+          </div>
+        }
+      >
+        <VerifyEmailHandler />
+      </Suspense>
+    </div>
+  );
+}
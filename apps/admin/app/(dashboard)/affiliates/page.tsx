@@ -70,6 +70,7 @@ const COMMISSION_STATUS_FILTERS = [
   { label: "Approved", value: "approved" },
   { label: "Paid", value: "paid" },
   { label: "Rejected", value: "rejected" },
+  { label: "Reversed", value: "reversed" },
 ] as const;
 
 const PAYOUT_STATUS_FILTERS = [
@@ -90,6 +91,7 @@ const commissionStatusBadge: Record<string, string> = {
   approved: "bg-blue-500/10 text-blue-400",
   paid: "bg-green-500/10 text-green-400",
   rejected: "bg-red-500/10 text-red-400",
+  reversed: "bg-orange-500/10 text-orange-400",
 };
 
 const payoutStatusBadge: Record<string, string> = {
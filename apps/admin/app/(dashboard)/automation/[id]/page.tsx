@@ -346,8 +346,17 @@ export default function WorkflowEditorPage() {
     if (!cfg || Object.keys(cfg).length === 0) return "No configuration";
     if (workflow.trigger_type === "event")
       return (cfg.event_name as string) || JSON.stringify(cfg);
-    if (workflow.trigger_type === "schedule")
-      return (cfg.cron as string) || JSON.stringify(cfg);
+    if (workflow.trigger_type === "schedule") {
+      if (!cfg.cron) return JSON.stringify(cfg);
+      const audience = cfg.tag
+        ? `tag "${cfg.tag}"`
+        : cfg.segment_id
+          ? `segment #${cfg.segment_id}`
+          : cfg.tag_id
+            ? `tag #${cfg.tag_id}`
+            : "no audience";
+      return `${cfg.cron as string} · ${audience}`;
+    }
     return JSON.stringify(cfg);
   })();
 
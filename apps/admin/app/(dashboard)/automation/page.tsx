@@ -110,6 +110,7 @@ export default function AutomationPage() {
   const [newDesc, setNewDesc] = useState("");
   const [newTriggerType, setNewTriggerType] = useState<TriggerType>("event");
   const [newTriggerConfig, setNewTriggerConfig] = useState("");
+  const [newScheduleTag, setNewScheduleTag] = useState("");
 
   // Data
   const { data: wfData, isLoading: wfLoading } = useWorkflows(
@@ -139,7 +140,7 @@ export default function AutomationPage() {
     if (newTriggerType === "event") {
       triggerConfig = { event_name: newTriggerConfig };
     } else if (newTriggerType === "schedule") {
-      triggerConfig = { cron: newTriggerConfig };
+      triggerConfig = { cron: newTriggerConfig, tag: newScheduleTag };
     } else {
       // For manual, try parsing JSON, otherwise empty
       try {
@@ -164,6 +165,7 @@ export default function AutomationPage() {
           setNewDesc("");
           setNewTriggerType("event");
           setNewTriggerConfig("");
+          setNewScheduleTag("");
         },
       }
     );
@@ -659,6 +661,16 @@ export default function AutomationPage() {
                     onChange={(e) => setNewTriggerConfig(e.target.value)}
                     className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground font-mono focus:border-accent focus:outline-none"
                   />
+                  <label className="block text-sm font-medium text-text-secondary mt-3 mb-1">
+                    Audience Tag
+                  </label>
+                  <input
+                    type="text"
+                    placeholder="e.g. customer (runs for every contact with this tag)"
+                    value={newScheduleTag}
+                    onChange={(e) => setNewScheduleTag(e.target.value)}
+                    className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
                 </div>
               )}
 
@@ -20,15 +20,22 @@ import {
   Check,
 } from "@/lib/icons";
 import {
+  useCalendars,
   useCalendar,
   useUpdateCalendar,
   useCreateEventType,
   useUpdateEventType,
   useDeleteEventType,
   useSetAvailability,
+  useCreateOverride,
+  useDeleteOverride,
 } from "@/hooks/use-booking";
 import { useConfirm } from "@/hooks/use-confirm";
-import type { BookingEventType, Availability } from "@repo/shared/types";
+import type {
+  BookingEventType,
+  Availability,
+  AssignmentStrategy,
+} from "@repo/shared/types";
 
 // ---------------------------------------------------------------------------
 // Types & Constants
@@ -55,6 +62,16 @@ interface EventTypeForm {
   max_per_day: number;
   price: number;
   color: string;
+  reminder_offsets: string;
+  follow_up_enabled: boolean;
+  follow_up_delay_minutes: number;
+  follow_up_subject: string;
+  follow_up_message: string;
+  refund_window_hours: number;
+  min_notice_minutes: number;
+  max_advance_days: number;
+  assignment_strategy: AssignmentStrategy;
+  host_calendar_ids: number[];
 }
 
 const emptyEventTypeForm: EventTypeForm = {
@@ -66,8 +83,42 @@ const emptyEventTypeForm: EventTypeForm = {
   max_per_day: 10,
   price: 0,
   color: "#6366f1",
+  reminder_offsets: "1440, 60",
+  follow_up_enabled: false,
+  follow_up_delay_minutes: 60,
+  follow_up_subject: "",
+  follow_up_message: "",
+  refund_window_hours: 24,
+  min_notice_minutes: 0,
+  max_advance_days: 0,
+  assignment_strategy: "round_robin",
+  host_calendar_ids: [],
 };
 
+interface OverrideForm {
+  date: string;
+  blocked: boolean;
+  start_time: string;
+  end_time: string;
+  reason: string;
+}
+
+const emptyOverrideForm: OverrideForm = {
+  date: "",
+  blocked: true,
+  start_time: "09:00",
+  end_time: "17:00",
+  reason: "",
+};
+
+// "1440, 60" -> [1440, 60], dropping anything that isn't a positive number.
+function parseReminderOffsets(value: string): number[] {
+  return value
+    .split(",")
+    .map((v) => parseInt(v.trim()))
+    .filter((n) => n > 0);
+}
+
 interface DaySlot {
   enabled: boolean;
   start_time: string;
@@ -218,6 +269,7 @@ export default function CalendarDetailPage() {
 
   // Data
   const { data: calendar, isLoading } = useCalendar(calendarId);
+  const { data: allCalendars } = useCalendars();
 
   // Mutations
   const { mutate: updateCalendar, isPending: savingCalendar } =
@@ -227,6 +279,9 @@ export default function CalendarDetailPage() {
   const { mutate: deleteEventType } = useDeleteEventType();
   const { mutate: setAvailability, isPending: savingAvailability } =
     useSetAvailability();
+  const { mutate: createOverride, isPending: savingOverride } =
+    useCreateOverride();
+  const { mutate: deleteOverride } = useDeleteOverride();
 
   // Tab state
   const [activeTab, setActiveTab] = useState<Tab>("event-types");
@@ -246,11 +301,14 @@ export default function CalendarDetailPage() {
   // ---- Availability state ----
   const [daySlots, setDaySlots] = useState<DaySlot[]>(DEFAULT_SLOTS);
   const [availabilityInitialized, setAvailabilityInitialized] = useState(false);
+  const [overrideForm, setOverrideForm] =
+    useState<OverrideForm>(emptyOverrideForm);
 
   // ---- Settings state ----
   const [settingsName, setSettingsName] = useState("");
   const [settingsDescription, setSettingsDescription] = useState("");
   const [settingsTimezone, setSettingsTimezone] = useState("");
+  const [settingsGoogleCalendarId, setSettingsGoogleCalendarId] = useState("");
   const [settingsStatus, setSettingsStatus] = useState<"active" | "inactive">(
     "active"
   );
@@ -261,6 +319,7 @@ export default function CalendarDetailPage() {
     setSettingsName(calendar.name ?? "");
     setSettingsDescription(calendar.description ?? "");
     setSettingsTimezone(calendar.timezone ?? "UTC");
+    setSettingsGoogleCalendarId(calendar.google_calendar_id ?? "");
     setSettingsStatus(calendar.status ?? "active");
     setSettingsInitialized(true);
   }
@@ -311,13 +370,27 @@ export default function CalendarDetailPage() {
       max_per_day: et.max_per_day,
       price: et.price / 100,
       color: et.color ?? "#6366f1",
+      reminder_offsets: (et.reminder_offsets ?? []).join(", "),
+      follow_up_enabled: et.follow_up_enabled ?? false,
+      follow_up_delay_minutes: et.follow_up_delay_minutes ?? 60,
+      follow_up_subject: et.follow_up_subject ?? "",
+      follow_up_message: et.follow_up_message ?? "",
+      refund_window_hours: et.refund_window_hours ?? 24,
+      min_notice_minutes: et.min_notice_minutes ?? 0,
+      max_advance_days: et.max_advance_days ?? 0,
+      assignment_strategy: et.assignment_strategy ?? "round_robin",
+      host_calendar_ids: (et.hosts ?? []).map((h) => h.id),
     });
     setEditingEventTypeId(et.id);
     setShowEventTypeModal(true);
   };
 
   const handleEventTypeSubmit = () => {
-    const submitData = { ...eventTypeForm, price: Math.round(eventTypeForm.price * 100) };
+    const submitData = {
+      ...eventTypeForm,
+      price: Math.round(eventTypeForm.price * 100),
+      reminder_offsets: parseReminderOffsets(eventTypeForm.reminder_offsets),
+    };
     if (editingEventTypeId) {
       updateEventType(
         { id: editingEventTypeId, ...submitData },
@@ -390,6 +463,40 @@ export default function CalendarDetailPage() {
     setAvailability({ calendarId, slots });
   };
 
+  const handleAddOverride = () => {
+    createOverride(
+      {
+        calendarId,
+        date: overrideForm.date,
+        start_time: overrideForm.blocked ? "" : overrideForm.start_time,
+        end_time: overrideForm.blocked ? "" : overrideForm.end_time,
+        reason: overrideForm.reason,
+      },
+      { onSuccess: () => setOverrideForm(emptyOverrideForm) }
+    );
+  };
+
+  const handleDeleteOverride = async (id: number) => {
+    const ok = await confirm({
+      title: "Remove Date Override",
+      description: "Remove this date override? The weekly hours will apply again.",
+      confirmLabel: "Remove",
+      variant: "danger",
+    });
+    if (ok) {
+      deleteOverride(id);
+    }
+  };
+
+  const toggleHost = (id: number) => {
+    setEventTypeForm((prev) => ({
+      ...prev,
+      host_calendar_ids: prev.host_calendar_ids.includes(id)
+        ? prev.host_calendar_ids.filter((h) => h !== id)
+        : [...prev.host_calendar_ids, id],
+    }));
+  };
+
   // -----------------------------------------------------------------------
   // Settings Handler
   // -----------------------------------------------------------------------
@@ -400,6 +507,7 @@ export default function CalendarDetailPage() {
       name: settingsName,
       description: settingsDescription,
       timezone: settingsTimezone,
+      google_calendar_id: settingsGoogleCalendarId.trim(),
       status: settingsStatus,
     });
   };
@@ -428,6 +536,8 @@ export default function CalendarDetailPage() {
   }
 
   const eventTypes = calendar.event_types ?? [];
+  const overrides = calendar.overrides ?? [];
+  const otherCalendars = (allCalendars ?? []).filter((c) => c.id !== calendarId);
 
   // -----------------------------------------------------------------------
   // Render
@@ -700,6 +810,127 @@ export default function CalendarDetailPage() {
               ))}
             </div>
           </div>
+
+          <div className="pt-4">
+            <h2 className="text-lg font-semibold text-foreground">
+              Date Overrides
+            </h2>
+            <p className="text-sm text-text-muted mt-0.5">
+              Block out holidays, or set different hours on specific dates.
+            </p>
+          </div>
+
+          <div className="rounded-xl border border-border bg-bg-secondary p-5">
+            <div className="flex flex-wrap items-end gap-3">
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">
+                  Date
+                </label>
+                <input
+                  type="date"
+                  value={overrideForm.date}
+                  onChange={(e) =>
+                    setOverrideForm({ ...overrideForm, date: e.target.value })
+                  }
+                  className="rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+                />
+              </div>
+              <label className="flex items-center gap-2 pb-2 text-sm text-text-secondary">
+                <input
+                  type="checkbox"
+                  checked={overrideForm.blocked}
+                  onChange={(e) =>
+                    setOverrideForm({
+                      ...overrideForm,
+                      blocked: e.target.checked,
+                    })
+                  }
+                  className="rounded border-border"
+                />
+                Unavailable all day
+              </label>
+              {!overrideForm.blocked && (
+                <div className="flex items-center gap-2">
+                  <input
+                    type="time"
+                    value={overrideForm.start_time}
+                    onChange={(e) =>
+                      setOverrideForm({
+                        ...overrideForm,
+                        start_time: e.target.value,
+                      })
+                    }
+                    className="rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                  <span className="text-text-muted text-sm">to</span>
+                  <input
+                    type="time"
+                    value={overrideForm.end_time}
+                    onChange={(e) =>
+                      setOverrideForm({
+                        ...overrideForm,
+                        end_time: e.target.value,
+                      })
+                    }
+                    className="rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                </div>
+              )}
+              <div className="flex-1 min-w-[160px]">
+                <label className="block text-sm font-medium text-text-secondary mb-1">
+                  Reason
+                </label>
+                <input
+                  type="text"
+                  value={overrideForm.reason}
+                  onChange={(e) =>
+                    setOverrideForm({ ...overrideForm, reason: e.target.value })
+                  }
+                  placeholder="e.g. Public holiday"
+                  className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+                />
+              </div>
+              <button
+                onClick={handleAddOverride}
+                disabled={savingOverride || !overrideForm.date}
+                className="flex items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 disabled:opacity-50 transition-colors"
+              >
+                <Plus className="h-4 w-4" />
+                Add
+              </button>
+            </div>
+            <p className="mt-2 text-xs text-text-muted">
+              Add several overrides for the same date to offer more than one block of hours.
+            </p>
+          </div>
+
+          {overrides.length > 0 && (
+            <div className="rounded-xl border border-border bg-bg-secondary overflow-hidden">
+              <div className="divide-y divide-border">
+                {overrides.map((o) => (
+                  <div key={o.id} className="flex items-center gap-4 px-5 py-3">
+                    <span className="w-28 text-sm font-medium text-foreground">
+                      {o.date}
+                    </span>
+                    <span className="text-sm text-text-secondary">
+                      {o.start_time && o.end_time
+                        ? `${o.start_time} – ${o.end_time}`
+                        : "Unavailable"}
+                    </span>
+                    <span className="flex-1 truncate text-sm text-text-muted">
+                      {o.reason}
+                    </span>
+                    <button
+                      onClick={() => handleDeleteOverride(o.id)}
+                      className="rounded-lg p-1.5 text-text-muted hover:bg-red-500/10 hover:text-red-400 transition-colors"
+                    >
+                      <Trash2 className="h-4 w-4" />
+                    </button>
+                  </div>
+                ))}
+              </div>
+            </div>
+          )}
         </div>
       )}
 
@@ -753,6 +984,22 @@ export default function CalendarDetailPage() {
                 />
               </div>
 
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">
+                  Google Calendar ID
+                </label>
+                <input
+                  type="text"
+                  value={settingsGoogleCalendarId}
+                  onChange={(e) => setSettingsGoogleCalendarId(e.target.value)}
+                  placeholder="primary"
+                  className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                />
+                <p className="mt-1 text-xs text-text-muted">
+                  Busy times on this Google calendar are hidden from booking.
+                </p>
+              </div>
+
               <div>
                 <label className="block text-sm font-medium text-text-secondary mb-1">
                   Status
@@ -948,6 +1195,93 @@ export default function CalendarDetailPage() {
                 </div>
               </div>
 
+              <div className="grid grid-cols-2 gap-4">
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">
+                    Minimum Notice (min)
+                  </label>
+                  <input
+                    type="number"
+                    min={0}
+                    step={15}
+                    value={eventTypeForm.min_notice_minutes}
+                    onChange={(e) =>
+                      setEventTypeForm({
+                        ...eventTypeForm,
+                        min_notice_minutes: parseInt(e.target.value) || 0,
+                      })
+                    }
+                    className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                </div>
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">
+                    Book Up To (days ahead)
+                  </label>
+                  <input
+                    type="number"
+                    min={0}
+                    value={eventTypeForm.max_advance_days}
+                    onChange={(e) =>
+                      setEventTypeForm({
+                        ...eventTypeForm,
+                        max_advance_days: parseInt(e.target.value) || 0,
+                      })
+                    }
+                    className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                  <p className="mt-1 text-xs text-text-muted">0 = no limit</p>
+                </div>
+              </div>
+
+              {otherCalendars.length > 0 && (
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">
+                    Additional Hosts
+                  </label>
+                  <div className="space-y-1.5 rounded-lg border border-border bg-bg-secondary px-3 py-2">
+                    {otherCalendars.map((cal) => (
+                      <label
+                        key={cal.id}
+                        className="flex items-center gap-2 text-sm text-text-secondary"
+                      >
+                        <input
+                          type="checkbox"
+                          checked={eventTypeForm.host_calendar_ids.includes(cal.id)}
+                          onChange={() => toggleHost(cal.id)}
+                          className="rounded border-border"
+                        />
+                        {cal.name}
+                      </label>
+                    ))}
+                  </div>
+                  <p className="mt-1 text-xs text-text-muted">
+                    Each booking goes to one free host, this calendar included.
+                  </p>
+                </div>
+              )}
+
+              {eventTypeForm.host_calendar_ids.length > 0 && (
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">
+                    Assign Bookings By
+                  </label>
+                  <select
+                    value={eventTypeForm.assignment_strategy}
+                    onChange={(e) =>
+                      setEventTypeForm({
+                        ...eventTypeForm,
+                        assignment_strategy: e.target.value as AssignmentStrategy,
+                      })
+                    }
+                    className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  >
+                    <option value="round_robin">Round robin</option>
+                    <option value="least_booked">Fewest upcoming bookings</option>
+                  </select>
+                </div>
+              )}
+
               <div>
                 <label className="block text-sm font-medium text-text-secondary mb-1">
                   Price (0 = free)
@@ -968,6 +1302,29 @@ export default function CalendarDetailPage() {
                 />
               </div>
 
+              {eventTypeForm.price > 0 && (
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">
+                    Refund Window (hours)
+                  </label>
+                  <input
+                    type="number"
+                    min={0}
+                    value={eventTypeForm.refund_window_hours}
+                    onChange={(e) =>
+                      setEventTypeForm({
+                        ...eventTypeForm,
+                        refund_window_hours: parseInt(e.target.value) || 0,
+                      })
+                    }
+                    className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                  <p className="mt-1 text-xs text-text-muted">
+                    Cancellations at least this long before the start are refunded.
+                  </p>
+                </div>
+              )}
+
               <div>
                 <label className="block text-sm font-medium text-text-secondary mb-1">
                   Color
@@ -1002,6 +1359,100 @@ export default function CalendarDetailPage() {
                   />
                 </div>
               </div>
+
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">
+                  Reminders (minutes before)
+                </label>
+                <input
+                  type="text"
+                  value={eventTypeForm.reminder_offsets}
+                  onChange={(e) =>
+                    setEventTypeForm({
+                      ...eventTypeForm,
+                      reminder_offsets: e.target.value,
+                    })
+                  }
+                  placeholder="e.g. 1440, 60"
+                  className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                />
+                <p className="mt-1 text-xs text-text-muted">
+                  Comma-separated. 1440 is a day before, 60 an hour before. Leave empty for no reminders.
+                </p>
+              </div>
+
+              <div className="space-y-3">
+                <label className="flex items-center gap-2 text-sm text-text-secondary">
+                  <input
+                    type="checkbox"
+                    checked={eventTypeForm.follow_up_enabled}
+                    onChange={(e) =>
+                      setEventTypeForm({
+                        ...eventTypeForm,
+                        follow_up_enabled: e.target.checked,
+                      })
+                    }
+                    className="rounded border-border"
+                  />
+                  Send a follow-up email after the appointment is completed
+                </label>
+                {eventTypeForm.follow_up_enabled && (
+                  <>
+                    <div>
+                      <label className="block text-sm font-medium text-text-secondary mb-1">
+                        Send After (min)
+                      </label>
+                      <input
+                        type="number"
+                        min={0}
+                        step={5}
+                        value={eventTypeForm.follow_up_delay_minutes}
+                        onChange={(e) =>
+                          setEventTypeForm({
+                            ...eventTypeForm,
+                            follow_up_delay_minutes: parseInt(e.target.value) || 0,
+                          })
+                        }
+                        className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                      />
+                    </div>
+                    <div>
+                      <label className="block text-sm font-medium text-text-secondary mb-1">
+                        Subject
+                      </label>
+                      <input
+                        type="text"
+                        value={eventTypeForm.follow_up_subject}
+                        onChange={(e) =>
+                          setEventTypeForm({
+                            ...eventTypeForm,
+                            follow_up_subject: e.target.value,
+                          })
+                        }
+                        placeholder="Thanks for your session"
+                        className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                      />
+                    </div>
+                    <div>
+                      <label className="block text-sm font-medium text-text-secondary mb-1">
+                        Message
+                      </label>
+                      <textarea
+                        value={eventTypeForm.follow_up_message}
+                        onChange={(e) =>
+                          setEventTypeForm({
+                            ...eventTypeForm,
+                            follow_up_message: e.target.value,
+                          })
+                        }
+                        placeholder="Leave empty for a short thank-you note"
+                        rows={3}
+                        className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none resize-y"
+                      />
+                    </div>
+                  </>
+                )}
+              </div>
             </div>
 
             <div className="flex justify-end gap-2 mt-6">
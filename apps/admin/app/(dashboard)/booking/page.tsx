@@ -37,6 +37,7 @@ type MainTab = "calendars" | "appointments";
 
 const STATUS_OPTIONS = [
   { label: "All", value: "" },
+  { label: "Awaiting payment", value: "pending" },
   { label: "Confirmed", value: "confirmed" },
   { label: "Cancelled", value: "cancelled" },
   { label: "Rescheduled", value: "rescheduled" },
@@ -46,6 +47,7 @@ const STATUS_OPTIONS = [
 const statusBadge: Record<string, string> = {
   active: "bg-green-500/10 text-green-400",
   inactive: "bg-zinc-500/10 text-zinc-400",
+  pending: "bg-orange-500/10 text-orange-400",
   confirmed: "bg-accent/10 text-accent",
   cancelled: "bg-red-500/10 text-red-400",
   rescheduled: "bg-yellow-500/10 text-yellow-400",
@@ -390,6 +392,11 @@ export default function BookingPage() {
                       {/* Event Type */}
                       <td className="px-4 py-3 text-text-secondary">
                         {appt.event_type?.name ?? `Type #${appt.event_type_id}`}
+                        {appt.calendar && (
+                          <p className="text-xs text-text-muted truncate">
+                            with {appt.calendar.name}
+                          </p>
+                        )}
                       </td>
 
                       {/* Contact */}
@@ -17,6 +17,8 @@ import {
   Heart,
   Filter,
   MessageSquare,
+  Clock,
+  Ban,
 } from "@/lib/icons";
 import {
   useSpace,
@@ -24,6 +26,7 @@ import {
   useThreads,
   useAddMember,
   useRemoveMember,
+  useSanctionMember,
   useCreateThread,
   useDeleteThread,
   usePinThread,
@@ -174,6 +177,7 @@ export default function CommunitySpaceDetailPage() {
   // Mutations
   const { mutate: addMember, isPending: addingMember } = useAddMember();
   const { mutate: removeMember } = useRemoveMember();
+  const { mutate: sanctionMember } = useSanctionMember();
   const { mutate: createThread, isPending: creatingThread } = useCreateThread();
   const { mutate: deleteThread } = useDeleteThread();
   const { mutate: pinThread } = usePinThread();
@@ -255,6 +259,33 @@ export default function CommunitySpaceDetailPage() {
     }
   };
 
+  const isMuted = (member: CommunityMember) =>
+    !!member.muted_until && new Date(member.muted_until) > new Date();
+
+  const handleToggleMute = (member: CommunityMember) => {
+    if (isMuted(member)) {
+      sanctionMember({ memberId: member.id, action: "unmute" });
+    } else {
+      sanctionMember({ memberId: member.id, action: "mute", minutes: 24 * 60 });
+    }
+  };
+
+  const handleToggleBan = async (member: CommunityMember) => {
+    if (member.banned_at) {
+      sanctionMember({ memberId: member.id, action: "unban" });
+      return;
+    }
+    const ok = await confirm({
+      title: "Ban Member",
+      description: "Banned members can't post, react, or rejoin this space.",
+      confirmLabel: "Ban",
+      variant: "danger",
+    });
+    if (ok) {
+      sanctionMember({ memberId: member.id, action: "ban" });
+    }
+  };
+
   // -------------------------------------------------------------------------
   // Loading state
   // -------------------------------------------------------------------------
@@ -683,11 +714,41 @@ export default function CommunitySpaceDetailPage() {
                       {member.role}
                     </span>
 
+                    {/* Sanction badges */}
+                    {member.banned_at ? (
+                      <span className="inline-flex rounded-full px-2.5 py-0.5 text-[10px] font-medium shrink-0 bg-danger/10 text-danger">
+                        Banned
+                      </span>
+                    ) : isMuted(member) ? (
+                      <span
+                        className="inline-flex rounded-full px-2.5 py-0.5 text-[10px] font-medium shrink-0 bg-warning/10 text-warning"
+                        title={`Muted until ${new Date(member.muted_until!).toLocaleString()}`}
+                      >
+                        Muted
+                      </span>
+                    ) : null}
+
                     {/* Joined date */}
                     <span className="text-xs text-text-muted shrink-0 hidden sm:block">
                       Joined {formatDate(member.joined_at)}
                     </span>
 
+                    {/* Mute / ban buttons */}
+                    <button
+                      onClick={() => handleToggleMute(member)}
+                      className="rounded-lg p-1.5 text-text-muted hover:bg-bg-elevated hover:text-foreground transition-colors opacity-0 group-hover:opacity-100"
+                      title={isMuted(member) ? "Unmute member" : "Mute for 24 hours"}
+                    >
+                      <Clock className="h-4 w-4" />
+                    </button>
+                    <button
+                      onClick={() => handleToggleBan(member)}
+                      className="rounded-lg p-1.5 text-text-muted hover:bg-danger/10 hover:text-danger transition-colors opacity-0 group-hover:opacity-100"
+                      title={member.banned_at ? "Unban member" : "Ban member"}
+                    >
+                      <Ban className="h-4 w-4" />
+                    </button>
+
                     {/* Remove button */}
                     <button
                       onClick={() => handleRemoveMember(member.id)}
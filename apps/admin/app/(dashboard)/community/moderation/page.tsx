@@ -0,0 +1,404 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import {
+  ArrowLeft,
+  Shield,
+  Check,
+  EyeOff,
+  Trash2,
+  X,
+  Loader2,
+  AlertTriangle,
+  Clock,
+} from "@/lib/icons";
+import {
+  useSpaces,
+  useModerationQueue,
+  useModerationLogs,
+  useModerateContent,
+  useDismissReport,
+} from "@/hooks/use-community";
+import { useConfirm } from "@/hooks/use-confirm";
+import type { ModerationQueueItem, ModerationLog } from "@repo/shared/types";
+
+// ---------------------------------------------------------------------------
+// Types
+// ---------------------------------------------------------------------------
+
+type Tab = "queue" | "log";
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+function statusBadge(status: ModerationQueueItem["moderation_status"]) {
+  const map: Record<string, string> = {
+    pending: "bg-warning/10 text-warning",
+    hidden: "bg-bg-elevated text-text-muted",
+    approved: "bg-success/10 text-success",
+  };
+  return map[status] ?? "bg-bg-elevated text-text-muted";
+}
+
+function actionBadge(action: ModerationLog["action"]) {
+  const map: Record<string, string> = {
+    hold: "bg-warning/10 text-warning",
+    approve: "bg-success/10 text-success",
+    hide: "bg-bg-elevated text-text-muted",
+    delete: "bg-danger/10 text-danger",
+    dismiss: "bg-bg-elevated text-text-muted",
+    mute: "bg-warning/10 text-warning",
+    unmute: "bg-accent/10 text-accent",
+    ban: "bg-danger/10 text-danger",
+    unban: "bg-accent/10 text-accent",
+  };
+  return map[action] ?? "bg-bg-elevated text-text-muted";
+}
+
+function excerpt(content: unknown): string {
+  const text = typeof content === "string" ? content : JSON.stringify(content ?? "");
+  return text.length > 240 ? `${text.slice(0, 240)}…` : text;
+}
+
+function formatDateTime(dateStr: string): string {
+  return new Date(dateStr).toLocaleString("en-US", {
+    month: "short",
+    day: "numeric",
+    hour: "numeric",
+    minute: "2-digit",
+  });
+}
+
+// ---------------------------------------------------------------------------
+// Page
+// ---------------------------------------------------------------------------
+
+export default function CommunityModerationPage() {
+  const confirm = useConfirm();
+  const [activeTab, setActiveTab] = useState<Tab>("queue");
+  const [spaceId, setSpaceId] = useState<number>(0);
+  const [actionFilter, setActionFilter] = useState("");
+  const [logPage, setLogPage] = useState(1);
+
+  const { data: spaces } = useSpaces();
+  const { data: queue, isLoading: queueLoading } = useModerationQueue(spaceId || undefined);
+  const { data: logsResult, isLoading: logsLoading } = useModerationLogs({
+    spaceId: spaceId || undefined,
+    action: actionFilter || undefined,
+    page: logPage,
+  });
+  const { mutate: moderate, isPending: moderating } = useModerateContent();
+  const { mutate: dismissReport } = useDismissReport();
+
+  const items = queue ?? [];
+  const logs = logsResult?.data ?? [];
+  const logMeta = logsResult?.meta;
+  const spaceName = (id: number) => spaces?.find((s) => s.id === id)?.name ?? `Space #${id}`;
+
+  const handleDelete = async (item: ModerationQueueItem) => {
+    const ok = await confirm({
+      title: item.type === "thread" ? "Delete Thread" : "Delete Reply",
+      description: "This permanently removes the post and resolves its reports.",
+      confirmLabel: "Delete",
+      variant: "danger",
+    });
+    if (ok) {
+      moderate({ type: item.type, id: item.id, action: "delete" });
+    }
+  };
+
+  const handleDismissAll = (item: ModerationQueueItem) => {
+    item.reports
+      .filter((r) => r.status === "open")
+      .forEach((r) => dismissReport({ id: r.id }));
+  };
+
+  return (
+    <div className="space-y-6">
+      {/* Header */}
+      <div className="flex items-center gap-4">
+        <Link
+          href="/community"
+          className="rounded-lg p-1.5 hover:bg-bg-hover text-text-muted transition-colors"
+        >
+          <ArrowLeft className="h-5 w-5" />
+        </Link>
+        <div className="flex-1 min-w-0">
+          <h1 className="text-2xl font-bold text-foreground">Moderation</h1>
+          <p className="text-text-secondary mt-1">
+            Review held and reported posts, and the history of moderation actions.
+          </p>
+        </div>
+        <select
+          value={spaceId}
+          onChange={(e) => {
+            setSpaceId(Number(e.target.value));
+            setLogPage(1);
+          }}
+          className="rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+        >
+          <option value={0}>All spaces</option>
+          {spaces?.map((s) => (
+            <option key={s.id} value={s.id}>
+              {s.name}
+            </option>
+          ))}
+        </select>
+      </div>
+
+      {/* Tabs */}
+      <div className="border-b border-border">
+        <nav className="flex gap-6">
+          {(["queue", "log"] as Tab[]).map((tab) => (
+            <button
+              key={tab}
+              onClick={() => setActiveTab(tab)}
+              className={`relative pb-3 text-sm font-medium capitalize transition-colors ${
+                activeTab === tab ? "text-accent" : "text-text-muted hover:text-foreground"
+              }`}
+            >
+              <span className="flex items-center gap-2">
+                {tab === "queue" ? <Shield className="h-4 w-4" /> : <Clock className="h-4 w-4" />}
+                {tab === "queue" ? `Queue${items.length ? ` (${items.length})` : ""}` : "Log"}
+              </span>
+              {activeTab === tab && (
+                <span className="absolute bottom-0 left-0 right-0 h-0.5 rounded-full bg-accent" />
+              )}
+            </button>
+          ))}
+        </nav>
+      </div>
+
+      {/* Queue */}
+      {activeTab === "queue" &&
+        (queueLoading ? (
+          <div className="flex justify-center py-12">
+            <Loader2 className="h-6 w-6 animate-spin text-accent" />
+          </div>
+        ) : items.length === 0 ? (
+          <div className="rounded-xl border border-dashed border-border bg-bg-secondary p-12 text-center">
+            <div className="flex justify-center mb-4">
+              <div className="flex h-14 w-14 items-center justify-center rounded-2xl bg-accent/10">
+                <Shield className="h-7 w-7 text-accent" />
+              </div>
+            </div>
+            <h3 className="text-lg font-semibold text-foreground mb-1">All clear</h3>
+            <p className="text-sm text-text-muted">No posts are waiting for review.</p>
+          </div>
+        ) : (
+          <div className="space-y-3">
+            {items.map((item) => {
+              const openReports = item.reports.filter((r) => r.status === "open");
+              return (
+                <div
+                  key={`${item.type}-${item.id}`}
+                  className="rounded-xl border border-border bg-bg-secondary p-4"
+                >
+                  <div className="flex items-start gap-4">
+                    <div className="flex-1 min-w-0">
+                      <div className="flex items-center gap-2 flex-wrap">
+                        <span className="inline-flex rounded-full px-2 py-0.5 text-[10px] font-medium capitalize bg-accent/10 text-accent">
+                          {item.type}
+                        </span>
+                        <span
+                          className={`inline-flex rounded-full px-2 py-0.5 text-[10px] font-medium capitalize ${statusBadge(item.moderation_status)}`}
+                        >
+                          {item.moderation_status}
+                        </span>
+                        <h3 className="font-semibold text-foreground truncate">
+                          {item.type === "reply" ? `Re: ${item.title}` : item.title}
+                        </h3>
+                      </div>
+                      <p className="text-xs text-text-muted mt-1">
+                        {item.author
+                          ? `${item.author.first_name} ${item.author.last_name}`
+                          : "Unknown author"}{" "}
+                        in {spaceName(item.space_id)} · {formatDateTime(item.created_at)}
+                      </p>
+                      <p className="text-sm text-text-secondary mt-2 whitespace-pre-wrap break-words">
+                        {excerpt(item.content)}
+                      </p>
+
+                      {openReports.length > 0 && (
+                        <div className="mt-3 space-y-1">
+                          {openReports.map((report) => (
+                            <div
+                              key={report.id}
+                              className="flex items-center gap-2 text-xs text-text-muted"
+                            >
+                              <AlertTriangle className="h-3.5 w-3.5 text-warning shrink-0" />
+                              <span className="capitalize text-text-secondary">
+                                {report.reason.replace("_", " ")}
+                              </span>
+                              {report.reporter && (
+                                <span>
+                                  by {report.reporter.first_name} {report.reporter.last_name}
+                                </span>
+                              )}
+                              {report.details && (
+                                <span className="truncate">: {report.details}</span>
+                              )}
+                              <button
+                                onClick={() => dismissReport({ id: report.id })}
+                                className="ml-auto rounded p-0.5 hover:bg-bg-hover"
+                                title="Dismiss report"
+                              >
+                                <X className="h-3.5 w-3.5" />
+                              </button>
+                            </div>
+                          ))}
+                        </div>
+                      )}
+                    </div>
+
+                    {/* Actions */}
+                    <div className="flex items-center gap-1 shrink-0">
+                      <button
+                        onClick={() => moderate({ type: item.type, id: item.id, action: "approve" })}
+                        disabled={moderating}
+                        className="rounded-lg p-1.5 text-text-muted hover:bg-success/10 hover:text-success transition-colors disabled:opacity-50"
+                        title="Approve"
+                      >
+                        <Check className="h-4 w-4" />
+                      </button>
+                      {item.moderation_status !== "hidden" && (
+                        <button
+                          onClick={() => moderate({ type: item.type, id: item.id, action: "hide" })}
+                          disabled={moderating}
+                          className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover hover:text-foreground transition-colors disabled:opacity-50"
+                          title="Hide"
+                        >
+                          <EyeOff className="h-4 w-4" />
+                        </button>
+                      )}
+                      {openReports.length > 0 && item.moderation_status === "approved" && (
+                        <button
+                          onClick={() => handleDismissAll(item)}
+                          className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover hover:text-foreground transition-colors"
+                          title="Dismiss all reports"
+                        >
+                          <X className="h-4 w-4" />
+                        </button>
+                      )}
+                      <button
+                        onClick={() => handleDelete(item)}
+                        disabled={moderating}
+                        className="rounded-lg p-1.5 text-text-muted hover:bg-danger/10 hover:text-danger transition-colors disabled:opacity-50"
+                        title="Delete"
+                      >
+                        <Trash2 className="h-4 w-4" />
+                      </button>
+                    </div>
+                  </div>
+                </div>
+              );
+            })}
+          </div>
+        ))}
+
+      {/* Log */}
+      {activeTab === "log" && (
+        <div className="space-y-4">
+          <div className="flex justify-end">
+            <select
+              value={actionFilter}
+              onChange={(e) => {
+                setActionFilter(e.target.value);
+                setLogPage(1);
+              }}
+              className="rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+            >
+              <option value="">All actions</option>
+              {["hold", "approve", "hide", "delete", "dismiss", "mute", "unmute", "ban", "unban"].map((a) => (
+                <option key={a} value={a} className="capitalize">
+                  {a}
+                </option>
+              ))}
+            </select>
+          </div>
+
+          {logsLoading ? (
+            <div className="flex justify-center py-12">
+              <Loader2 className="h-6 w-6 animate-spin text-accent" />
+            </div>
+          ) : logs.length === 0 ? (
+            <div className="rounded-xl border border-dashed border-border bg-bg-secondary p-12 text-center">
+              <p className="text-sm text-text-muted">No moderation actions yet.</p>
+            </div>
+          ) : (
+            <div className="rounded-xl border border-border bg-bg-secondary overflow-hidden">
+              <table className="w-full text-sm">
+                <thead>
+                  <tr className="border-b border-border bg-bg-elevated">
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">When</th>
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">Action</th>
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">Target</th>
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">Space</th>
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">By</th>
+                    <th className="px-4 py-3 text-left font-medium text-text-muted">Reason</th>
+                  </tr>
+                </thead>
+                <tbody className="divide-y divide-border">
+                  {logs.map((log) => (
+                    <tr key={log.id} className="hover:bg-bg-hover transition-colors">
+                      <td className="px-4 py-3 text-text-muted whitespace-nowrap">
+                        {formatDateTime(log.created_at)}
+                      </td>
+                      <td className="px-4 py-3">
+                        <span
+                          className={`inline-flex rounded-full px-2.5 py-0.5 text-[10px] font-medium capitalize ${actionBadge(log.action)}`}
+                        >
+                          {log.action}
+                        </span>
+                      </td>
+                      <td className="px-4 py-3 text-text-secondary capitalize">
+                        {log.target_type} #{log.target_id}
+                      </td>
+                      <td className="px-4 py-3 text-text-secondary">{spaceName(log.space_id)}</td>
+                      <td className="px-4 py-3 text-text-secondary">
+                        {log.actor_user
+                          ? `${log.actor_user.first_name} ${log.actor_user.last_name}`
+                          : log.actor_contact_id
+                            ? `Contact #${log.actor_contact_id}`
+                            : "Automatic"}
+                      </td>
+                      <td className="px-4 py-3 text-text-muted truncate max-w-xs">
+                        {log.reason || "—"}
+                      </td>
+                    </tr>
+                  ))}
+                </tbody>
+              </table>
+            </div>
+          )}
+
+          {logMeta && logMeta.pages > 1 && (
+            <div className="flex items-center justify-between pt-2">
+              <p className="text-sm text-text-muted">
+                Page {logMeta.page} of {logMeta.pages}
+              </p>
+              <div className="flex items-center gap-2">
+                <button
+                  onClick={() => setLogPage((p) => Math.max(1, p - 1))}
+                  disabled={logMeta.page <= 1}
+                  className="rounded-lg border border-border px-3 py-1.5 text-sm font-medium text-text-secondary hover:bg-bg-hover disabled:opacity-50 transition-colors"
+                >
+                  Previous
+                </button>
+                <button
+                  onClick={() => setLogPage((p) => Math.min(logMeta.pages, p + 1))}
+                  disabled={logMeta.page >= logMeta.pages}
+                  className="rounded-lg border border-border px-3 py-1.5 text-sm font-medium text-text-secondary hover:bg-bg-hover disabled:opacity-50 transition-colors"
+                >
+                  Next
+                </button>
+              </div>
+            </div>
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
@@ -25,6 +25,7 @@ import {
   CreditCard,
   ExternalLink,
   Clock,
+  Shield,
 } from "@/lib/icons";
 import { getIcon } from "@/lib/icons";
 import { useConfirm } from "@/hooks/use-confirm";
@@ -92,6 +93,8 @@ export default function CommunityPage() {
   const [spaceType, setSpaceType] = useState<"public" | "private" | "paid">("public");
   const [spaceIcon, setSpaceIcon] = useState("MessageCircle");
   const [spaceColor, setSpaceColor] = useState("#6366f1");
+  const [spaceBlockedKeywords, setSpaceBlockedKeywords] = useState("");
+  const [spaceFirstPostApproval, setSpaceFirstPostApproval] = useState(false);
 
   // --- Events state ---
   const { data: events, isLoading: eventsLoading } = useCommunityEvents();
@@ -119,6 +122,8 @@ export default function CommunityPage() {
     setSpaceType("public");
     setSpaceIcon("MessageCircle");
     setSpaceColor("#6366f1");
+    setSpaceBlockedKeywords("");
+    setSpaceFirstPostApproval(false);
     setSpaceModal({ open: true, editing: null });
   }
 
@@ -128,6 +133,8 @@ export default function CommunityPage() {
     setSpaceType(space.type);
     setSpaceIcon(space.icon || "MessageCircle");
     setSpaceColor(space.color || "#6366f1");
+    setSpaceBlockedKeywords((space.blocked_keywords ?? []).join(", "));
+    setSpaceFirstPostApproval(space.require_first_post_approval);
     setSpaceModal({ open: true, editing: space });
   }
 
@@ -138,6 +145,11 @@ export default function CommunityPage() {
       type: spaceType,
       icon: spaceIcon,
       color: spaceColor,
+      blocked_keywords: spaceBlockedKeywords
+        .split(",")
+        .map((k) => k.trim())
+        .filter(Boolean),
+      require_first_post_approval: spaceFirstPostApproval,
     };
 
     if (spaceModal.editing) {
@@ -189,11 +201,20 @@ export default function CommunityPage() {
   return (
     <div className="space-y-6">
       {/* Header */}
-      <div>
-        <h1 className="text-2xl font-bold text-foreground">Community</h1>
-        <p className="text-text-secondary mt-1">
-          Manage spaces, discussions, and community events.
-        </p>
+      <div className="flex items-start justify-between">
+        <div>
+          <h1 className="text-2xl font-bold text-foreground">Community</h1>
+          <p className="text-text-secondary mt-1">
+            Manage spaces, discussions, and community events.
+          </p>
+        </div>
+        <Link
+          href="/community/moderation"
+          className="flex items-center gap-2 rounded-lg border border-border px-4 py-2 text-sm font-medium text-text-secondary hover:bg-bg-hover hover:text-foreground transition-colors"
+        >
+          <Shield className="h-4 w-4" />
+          Moderation
+        </Link>
       </div>
 
       {/* Two-column layout */}
@@ -521,6 +542,32 @@ export default function CommunityPage() {
               </div>
             </div>
 
+            {/* Moderation */}
+            <div>
+              <label className="block text-sm font-medium text-text-secondary mb-1">
+                Blocked Keywords
+              </label>
+              <input
+                type="text"
+                placeholder="Comma-separated, e.g. casino, crypto giveaway"
+                value={spaceBlockedKeywords}
+                onChange={(e) => setSpaceBlockedKeywords(e.target.value)}
+                className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+              />
+              <p className="text-xs text-text-muted mt-1">
+                Posts containing these words are held for review.
+              </p>
+            </div>
+            <label className="flex items-center gap-2 text-sm text-text-secondary cursor-pointer">
+              <input
+                type="checkbox"
+                checked={spaceFirstPostApproval}
+                onChange={(e) => setSpaceFirstPostApproval(e.target.checked)}
+                className="rounded border-border"
+              />
+              Require approval for a member&apos;s first post
+            </label>
+
             {/* Actions */}
             <div className="flex justify-end gap-2 pt-2">
               <button
@@ -74,15 +74,18 @@ function getInitials(firstName: string, lastName: string): string {
 function statusBadge(status: string): string {
   switch (status) {
     case "active":
+    case "trialing":
     case "subscribed":
     case "completed":
     case "paid":
       return "bg-success/10 text-success";
     case "unsubscribed":
     case "cancelled":
+    case "past_due":
     case "refunded":
       return "bg-danger/10 text-danger";
     case "pending":
+    case "incomplete":
     case "in_progress":
     case "enrolled":
       return "bg-warning/10 text-warning";
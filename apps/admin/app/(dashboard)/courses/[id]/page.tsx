@@ -41,7 +41,7 @@ import {
   useUnenrollContact,
 } from "@/hooks/use-courses";
 import { useContacts } from "@/hooks/use-contacts";
-import type { CourseModule, Lesson } from "@repo/shared/types";
+import type { CourseModule, DripMode, Lesson } from "@repo/shared/types";
 
 // ---------------------------------------------------------------------------
 // Types for local form state
@@ -60,6 +60,8 @@ interface LessonForm {
   video_url: string;
   duration_minutes: number;
   is_free_preview: boolean;
+  drip_delay_days: number;
+  drip_date: string;
 }
 
 const emptyModuleForm: ModuleForm = { title: "", description: "" };
@@ -69,6 +71,8 @@ const emptyLessonForm: LessonForm = {
   video_url: "",
   duration_minutes: 0,
   is_free_preview: false,
+  drip_delay_days: 0,
+  drip_date: "",
 };
 
 // ---------------------------------------------------------------------------
@@ -151,6 +155,7 @@ export default function CourseEditorPage() {
   const [accessType, setAccessType] = useState<"free" | "paid" | "membership">("free");
   const [price, setPrice] = useState(0);
   const [currency, setCurrency] = useState("USD");
+  const [dripMode, setDripMode] = useState<DripMode>("enrollment");
   const [initialized, setInitialized] = useState(false);
 
   // Curriculum state
@@ -173,6 +178,7 @@ export default function CourseEditorPage() {
     setAccessType(course.access_type ?? "free");
     setPrice(course.price ?? 0);
     setCurrency(course.currency ?? "USD");
+    setDripMode(course.drip_mode ?? "enrollment");
     setInitialized(true);
   }
 
@@ -191,6 +197,7 @@ export default function CourseEditorPage() {
       access_type: accessType,
       price,
       currency,
+      drip_mode: dripMode,
     });
   };
 
@@ -261,6 +268,8 @@ export default function CourseEditorPage() {
       video_url: lesson.video_url ?? "",
       duration_minutes: lesson.duration_minutes ?? 0,
       is_free_preview: lesson.is_free_preview ?? false,
+      drip_delay_days: lesson.drip_delay_days ?? 0,
+      drip_date: lesson.drip_date ? lesson.drip_date.slice(0, 10) : "",
     });
     setEditingLessonId(lesson.id);
     setLessonParentModuleId(modId);
@@ -279,6 +288,8 @@ export default function CourseEditorPage() {
           video_url: lessonForm.video_url,
           duration_minutes: lessonForm.duration_minutes,
           is_free_preview: lessonForm.is_free_preview,
+          drip_delay_days: lessonForm.drip_delay_days,
+          drip_date: lessonForm.drip_date ? new Date(lessonForm.drip_date).toISOString() : null,
         },
         { onSuccess: () => { setShowLessonModal(false); setEditingLessonId(null); setLessonForm(emptyLessonForm); } }
       );
@@ -550,6 +561,19 @@ export default function CourseEditorPage() {
                 </select>
               </div>
 
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">Lesson Unlocking</label>
+                <select
+                  value={dripMode}
+                  onChange={(e) => setDripMode(e.target.value as DripMode)}
+                  className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                >
+                  <option value="enrollment">Days after enrollment</option>
+                  <option value="sequential">After the previous lesson is completed</option>
+                  <option value="date">On fixed dates (cohort)</option>
+                </select>
+              </div>
+
               {accessType === "paid" && (
                 <>
                   <div>
@@ -935,6 +959,29 @@ export default function CourseEditorPage() {
                 </button>
                 <label className="text-sm font-medium text-text-secondary">Free Preview</label>
               </div>
+
+              {course?.drip_mode === "date" ? (
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">Unlock Date</label>
+                  <input
+                    type="date"
+                    value={lessonForm.drip_date}
+                    onChange={(e) => setLessonForm({ ...lessonForm, drip_date: e.target.value })}
+                    className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                </div>
+              ) : (
+                <div>
+                  <label className="block text-sm font-medium text-text-secondary mb-1">Unlock After (days)</label>
+                  <input
+                    type="number"
+                    min={0}
+                    value={lessonForm.drip_delay_days}
+                    onChange={(e) => setLessonForm({ ...lessonForm, drip_delay_days: parseInt(e.target.value) || 0 })}
+                    className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  />
+                </div>
+              )}
             </div>
 
             <div className="flex justify-end gap-2 mt-6">
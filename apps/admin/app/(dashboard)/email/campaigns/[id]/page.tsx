@@ -15,11 +15,13 @@ import {
 } from "@/hooks/use-email";
 import { ChevronLeft, Save, Loader2, Play } from "@/lib/icons";
 import { useConfirm } from "@/hooks/use-confirm";
+import type { CampaignVariant, WinnerMetric } from "@repo/shared/types";
 
 const statusBadge: Record<string, string> = {
   draft: "bg-bg-elevated text-text-muted",
   scheduled: "bg-accent/10 text-accent",
   sending: "bg-warning/10 text-warning",
+  testing: "bg-warning/10 text-warning",
   sent: "bg-success/10 text-success",
   cancelled: "bg-danger/10 text-danger",
 };
@@ -54,6 +56,10 @@ export default function CampaignEditorPage() {
   const [selectedListIds, setSelectedListIds] = useState<number[]>([]);
   const [selectedSegmentIds, setSelectedSegmentIds] = useState<number[]>([]);
   const [scheduledAt, setScheduledAt] = useState("");
+  const [variants, setVariants] = useState<CampaignVariant[]>([]);
+  const [testPercent, setTestPercent] = useState(20);
+  const [testWaitHours, setTestWaitHours] = useState(4);
+  const [winnerMetric, setWinnerMetric] = useState<WinnerMetric>("open_rate");
   const [initialized, setInitialized] = useState(false);
 
   // Populate form from fetched campaign
@@ -68,12 +74,16 @@ export default function CampaignEditorPage() {
     setSelectedListIds(campaign.list_ids ?? []);
     setSelectedSegmentIds(campaign.segment_ids ?? []);
     if (campaign.scheduled_at) setScheduledAt(campaign.scheduled_at.slice(0, 16));
+    setVariants(campaign.variants ?? []);
+    setTestPercent(campaign.test_percent || 20);
+    setTestWaitHours(campaign.test_wait_hours || 4);
+    setWinnerMetric(campaign.winner_metric || "open_rate");
     setInitialized(true);
   }
 
   const isSaving = isUpdating || isCreating;
   const isSent = campaign?.status === "sent";
-  const isSending = campaign?.status === "sending";
+  const isSending = campaign?.status === "sending" || campaign?.status === "testing";
   const isReadOnly = isSent || isSending;
 
   // --- Handlers ---
@@ -89,6 +99,10 @@ export default function CampaignEditorPage() {
       template_id: templateId,
       list_ids: selectedListIds.length > 0 ? selectedListIds : null,
       segment_ids: selectedSegmentIds.length > 0 ? selectedSegmentIds : null,
+      variants: variants.length > 0 ? variants : null,
+      test_percent: testPercent,
+      test_wait_hours: testWaitHours,
+      winner_metric: winnerMetric,
     };
   }
 
@@ -117,6 +131,25 @@ export default function CampaignEditorPage() {
     scheduleCampaign({ id, scheduledAt: new Date(scheduledAt).toISOString() });
   }
 
+  function updateVariant(index: number, patch: Partial<CampaignVariant>) {
+    setVariants((prev) => prev.map((v, i) => (i === index ? { ...v, ...patch } : v)));
+  }
+
+  function addVariant() {
+    setVariants((prev) => {
+      // Starting a test seeds variant A from the current subject and content.
+      const base = prev.length > 0 ? prev : [{ key: "A", subject, template_id: null, html_content: "" }];
+      return [...base, { key: String.fromCharCode(65 + base.length), subject: "", template_id: null, html_content: "" }];
+    });
+  }
+
+  function removeVariant(index: number) {
+    setVariants((prev) => {
+      const next = prev.filter((_, i) => i !== index).map((v, i) => ({ ...v, key: String.fromCharCode(65 + i) }));
+      return next.length < 2 ? [] : next;
+    });
+  }
+
   function toggleListId(listId: number) {
     setSelectedListIds((prev) =>
       prev.includes(listId) ? prev.filter((x) => x !== listId) : [...prev, listId]
@@ -264,6 +297,111 @@ export default function CampaignEditorPage() {
             </div>
           </div>
 
+          {/* A/B test */}
+          <div className="rounded-xl border border-border bg-bg-secondary p-6 space-y-4">
+            <div className="flex items-center justify-between">
+              <h2 className="text-lg font-semibold text-foreground">A/B Test</h2>
+              {!isReadOnly && variants.length < 4 && (
+                <button
+                  onClick={addVariant}
+                  className="rounded-lg border border-accent px-3 py-1.5 text-xs font-medium text-accent hover:bg-accent/10"
+                >
+                  Add Variant
+                </button>
+              )}
+            </div>
+            {variants.length === 0 ? (
+              <p className="text-sm text-text-muted">
+                Add variants to test up to four subject lines or versions of the content on part of the audience before sending the winner to everyone else.
+              </p>
+            ) : (
+              <>
+                {variants.map((variant, i) => (
+                  <div key={variant.key} className="rounded-lg border border-border bg-bg-elevated p-4 space-y-3">
+                    <div className="flex items-center justify-between">
+                      <span className="text-sm font-semibold text-foreground">
+                        Variant {variant.key}
+                        {campaign?.winning_variant === variant.key && (
+                          <span className="ml-2 rounded-full bg-success/10 px-2 py-0.5 text-xs font-medium text-success">Winner</span>
+                        )}
+                      </span>
+                      {!isReadOnly && (
+                        <button onClick={() => removeVariant(i)} className="text-xs text-danger hover:underline">
+                          Remove
+                        </button>
+                      )}
+                    </div>
+                    <input
+                      type="text"
+                      value={variant.subject}
+                      onChange={(e) => updateVariant(i, { subject: e.target.value })}
+                      disabled={isReadOnly}
+                      placeholder="Subject line (defaults to the campaign subject)"
+                      className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none disabled:opacity-60"
+                    />
+                    <textarea
+                      value={variant.html_content}
+                      onChange={(e) => updateVariant(i, { html_content: e.target.value })}
+                      disabled={isReadOnly}
+                      rows={4}
+                      placeholder="HTML content (defaults to the campaign content)"
+                      className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground font-mono focus:border-accent focus:outline-none disabled:opacity-60"
+                    />
+                    {campaign?.variant_stats?.[variant.key] && (
+                      <p className="text-xs text-text-muted">
+                        {campaign.variant_stats[variant.key].recipients.toLocaleString()} recipients &middot;{" "}
+                        {(campaign.variant_stats[variant.key].open_rate * 100).toFixed(1)}% opened &middot;{" "}
+                        {(campaign.variant_stats[variant.key].click_rate * 100).toFixed(1)}% clicked
+                      </p>
+                    )}
+                  </div>
+                ))}
+                <div className="grid gap-4 sm:grid-cols-3">
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Test audience (%)</label>
+                    <input
+                      type="number"
+                      min={1}
+                      max={100}
+                      value={testPercent}
+                      onChange={(e) => setTestPercent(Number(e.target.value))}
+                      disabled={isReadOnly}
+                      className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none disabled:opacity-60"
+                    />
+                  </div>
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Wait (hours)</label>
+                    <input
+                      type="number"
+                      min={1}
+                      value={testWaitHours}
+                      onChange={(e) => setTestWaitHours(Number(e.target.value))}
+                      disabled={isReadOnly}
+                      className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none disabled:opacity-60"
+                    />
+                  </div>
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Pick winner by</label>
+                    <select
+                      value={winnerMetric}
+                      onChange={(e) => setWinnerMetric(e.target.value as WinnerMetric)}
+                      disabled={isReadOnly}
+                      className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none disabled:opacity-60"
+                    >
+                      <option value="open_rate">Open rate</option>
+                      <option value="click_rate">Click rate</option>
+                    </select>
+                  </div>
+                </div>
+                {campaign?.status === "testing" && campaign.test_ends_at && (
+                  <p className="text-xs text-text-muted">
+                    Winner will be picked {new Date(campaign.test_ends_at).toLocaleString()}.
+                  </p>
+                )}
+              </>
+            )}
+          </div>
+
           {/* Stats (only for sent campaigns) */}
           {isSent && stats && (
             <div className="rounded-xl border border-border bg-bg-secondary p-6 space-y-4">
@@ -287,6 +425,36 @@ export default function CampaignEditorPage() {
                   </div>
                 ))}
               </div>
+              {stats.links.length > 0 && (
+                <div className="space-y-2">
+                  <h3 className="text-sm font-semibold text-foreground">
+                    Link Clicks
+                    <span className="ml-2 text-xs font-normal text-text-muted">
+                      {stats.unique_clickers.toLocaleString()} unique clickers
+                    </span>
+                  </h3>
+                  <table className="w-full text-sm">
+                    <thead>
+                      <tr className="border-b border-border text-left text-xs text-text-muted">
+                        <th className="py-2 font-medium">URL</th>
+                        <th className="py-2 font-medium text-right">Clicks</th>
+                        <th className="py-2 font-medium text-right">Unique</th>
+                      </tr>
+                    </thead>
+                    <tbody>
+                      {stats.links.map((link) => (
+                        <tr key={link.url} className="border-b border-border last:border-0">
+                          <td className="py-2 pr-4 max-w-xs truncate text-text-secondary" title={link.url}>
+                            {link.url}
+                          </td>
+                          <td className="py-2 text-right text-foreground">{link.clicks.toLocaleString()}</td>
+                          <td className="py-2 text-right text-foreground">{link.unique_clickers.toLocaleString()}</td>
+                        </tr>
+                      ))}
+                    </tbody>
+                  </table>
+                </div>
+              )}
             </div>
           )}
         </div>
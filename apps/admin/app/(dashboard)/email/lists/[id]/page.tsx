@@ -127,6 +127,7 @@ export default function EmailListDetailPage() {
 
   const [name, setName] = useState("");
   const [description, setDescription] = useState("");
+  const [topic, setTopic] = useState("");
   const [doubleOptin, setDoubleOptin] = useState(false);
   const [initialized, setInitialized] = useState(false);
   const [showShare, setShowShare] = useState(false);
@@ -141,12 +142,13 @@ export default function EmailListDetailPage() {
   if (list && !initialized) {
     setName(list.name);
     setDescription(list.description || "");
+    setTopic(list.topic || "");
     setDoubleOptin(list.double_optin);
     setInitialized(true);
   }
 
   const handleSave = () => {
-    updateList({ id, name, description, double_optin: doubleOptin });
+    updateList({ id, name, description, topic, double_optin: doubleOptin });
   };
 
   const handleAddSubscriber = () => {
@@ -231,6 +233,16 @@ export default function EmailListDetailPage() {
               className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
             />
           </div>
+          <div>
+            <label className="block text-sm font-medium text-text-secondary mb-1">Topic</label>
+            <input
+              type="text"
+              value={topic}
+              onChange={(e) => setTopic(e.target.value)}
+              placeholder="Groups lists on the email preferences page"
+              className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+            />
+          </div>
         </div>
         <label className="flex items-center gap-2 text-sm text-text-secondary">
           <input
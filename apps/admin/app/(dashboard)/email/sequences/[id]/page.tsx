@@ -50,6 +50,7 @@ export default function SequenceEditorPage() {
   const [description, setDescription] = useState("");
   const [trigger, setTrigger] = useState<"manual" | "event">("manual");
   const [triggerEvent, setTriggerEvent] = useState("");
+  const [exitEvent, setExitEvent] = useState("");
   const [status, setStatus] = useState<"draft" | "active" | "paused">("draft");
   const [initialized, setInitialized] = useState(false);
 
@@ -66,6 +67,7 @@ export default function SequenceEditorPage() {
     setDescription(sequence.description || "");
     setTrigger(sequence.trigger);
     setTriggerEvent(sequence.trigger_event || "");
+    setExitEvent(sequence.exit_event || "");
     setStatus(sequence.status);
     setInitialized(true);
   }
@@ -77,6 +79,7 @@ export default function SequenceEditorPage() {
       description,
       trigger,
       trigger_event: trigger === "event" ? triggerEvent : "",
+      exit_event: exitEvent,
       status,
     });
   };
@@ -283,6 +286,18 @@ export default function SequenceEditorPage() {
               />
             </div>
           )}
+          <div>
+            <label className="block text-sm font-medium text-text-secondary mb-1">
+              Exit Event
+            </label>
+            <input
+              type="text"
+              value={exitEvent}
+              onChange={(e) => setExitEvent(e.target.value)}
+              placeholder="e.g. purchase.completed (optional)"
+              className="w-full rounded-lg border border-border bg-bg-elevated px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+            />
+          </div>
         </div>
       </div>
 
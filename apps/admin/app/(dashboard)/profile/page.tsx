@@ -5,9 +5,11 @@ import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { z } from "zod";
 import { useMe } from "@/hooks/use-auth";
-import { useUpdateProfile, useChangePassword } from "@/hooks/use-profile";
-import { User, Briefcase, Lock, Trash2, Save, Loader2, Upload } from "@/lib/icons";
+import { useUpdateProfile, useChangePassword, useResendVerification } from "@/hooks/use-profile";
+import { User, Briefcase, Lock, Trash2, Save, Loader2, Upload, Mail } from "@/lib/icons";
 import { DeleteAccountDialog } from "@/components/profile/delete-account-dialog";
+import { ActiveSessions } from "@/components/profile/active-sessions";
+import { TwoFactorSettings } from "@/components/profile/two-factor-settings";
 import { apiClient, uploadFile } from "@/lib/api-client";
 
 const PersonalInfoSchema = z.object({
@@ -43,6 +45,7 @@ export default function ProfilePage() {
   const { data: user } = useMe();
   const updateProfile = useUpdateProfile();
   const changePassword = useChangePassword();
+  const resendVerification = useResendVerification();
   const [showDeleteDialog, setShowDeleteDialog] = useState(false);
   const [avatarUploading, setAvatarUploading] = useState(false);
   const avatarInputRef = useRef<HTMLInputElement>(null);
@@ -183,6 +186,25 @@ export default function ProfilePage() {
         </div>
       </div>
 
+      {!user.email_verified_at && (
+        <div className="flex items-center justify-between rounded-xl border border-warning/30 bg-warning/10 px-6 py-4">
+          <div className="flex items-center gap-3">
+            <Mail className="h-4 w-4 text-warning" />
+            <p className="text-sm text-foreground">
+              Your email address isn&apos;t verified yet. Check your inbox for the verification link.
+            </p>
+          </div>
+          <button
+            onClick={() => resendVerification.mutate()}
+            disabled={resendVerification.isPending}
+            className="flex items-center gap-2 rounded-lg border border-border px-3 py-1.5 text-xs font-medium text-text-secondary hover:bg-bg-hover hover:text-foreground disabled:opacity-50 transition-colors"
+          >
+            {resendVerification.isPending && <Loader2 className="h-3.5 w-3.5 animate-spin" />}
+            Resend email
+          </button>
+        </div>
+      )}
+
       <div className="grid gap-6 lg:grid-cols-2">
         {/* Personal Information */}
         <div className="rounded-xl border border-border bg-bg-secondary">
@@ -349,6 +371,12 @@ export default function ProfilePage() {
           </form>
         </div>
 
+        {/* Two-Factor Authentication */}
+        <TwoFactorSettings />
+
+        {/* Active Sessions */}
+        <ActiveSessions />
+
         {/* Danger Zone */}
         <div className="rounded-xl border border-danger/30 bg-bg-secondary">
           <div className="border-b border-danger/20 px-6 py-4">
@@ -0,0 +1,344 @@
+"use client";
+
+import { useState } from "react";
+import { KeyRound, Plus, Trash2, X, Copy, Check, Loader2 } from "@/lib/icons";
+import {
+  useApiKeyScopes,
+  useApiKeys,
+  useCreateApiKey,
+  useRevokeApiKey,
+} from "@/hooks/use-api-keys";
+import { useMe } from "@/hooks/use-auth";
+import { useConfirm } from "@/hooks/use-confirm";
+import type { ApiKey } from "@repo/shared/types";
+
+interface KeyForm {
+  name: string;
+  scopes: string[];
+  runAsMe: boolean;
+  expiresAt: string;
+}
+
+const emptyForm: KeyForm = {
+  name: "",
+  scopes: [],
+  runAsMe: false,
+  expiresAt: "",
+};
+
+function keyStatus(key: ApiKey) {
+  if (key.revoked_at) return { label: "Revoked", className: "bg-red-500/10 text-red-400" };
+  if (key.expires_at && new Date(key.expires_at) < new Date()) {
+    return { label: "Expired", className: "bg-zinc-500/10 text-zinc-400" };
+  }
+  return { label: "Active", className: "bg-green-500/10 text-green-400" };
+}
+
+// groupScopes turns ["contacts:read", "contacts:write", "email:send"] into
+// { contacts: ["read", "write"], email: ["send"] }.
+function groupScopes(scopes: string[]) {
+  const groups: Record<string, string[]> = {};
+  for (const scope of scopes) {
+    const [resource, action] = scope.split(":");
+    (groups[resource] ??= []).push(action);
+  }
+  return groups;
+}
+
+export default function ApiKeysPage() {
+  const confirm = useConfirm();
+  const { data: me } = useMe();
+  const { data: scopeNames } = useApiKeyScopes();
+  const { data: keys, isLoading } = useApiKeys();
+  const createKey = useCreateApiKey();
+  const revokeKey = useRevokeApiKey();
+
+  const [showModal, setShowModal] = useState(false);
+  const [form, setForm] = useState<KeyForm>(emptyForm);
+  const [newKey, setNewKey] = useState("");
+  const [copied, setCopied] = useState(false);
+
+  const closeModal = () => {
+    setShowModal(false);
+    setForm(emptyForm);
+    setNewKey("");
+    setCopied(false);
+  };
+
+  const toggleScope = (scope: string) => {
+    setForm((prev) => ({
+      ...prev,
+      scopes: prev.scopes.includes(scope)
+        ? prev.scopes.filter((s) => s !== scope)
+        : [...prev.scopes, scope],
+    }));
+  };
+
+  const handleSubmit = () => {
+    createKey.mutate(
+      {
+        name: form.name.trim(),
+        scopes: form.scopes,
+        user_id: form.runAsMe ? me?.id : undefined,
+        expires_at: form.expiresAt ? new Date(form.expiresAt).toISOString() : undefined,
+      },
+      { onSuccess: (data) => setNewKey(data.key) }
+    );
+  };
+
+  const handleRevoke = async (key: ApiKey) => {
+    const ok = await confirm({
+      title: "Revoke API Key",
+      description: `Requests using "${key.name}" will be rejected immediately. This can't be undone.`,
+      confirmLabel: "Revoke",
+      variant: "danger",
+    });
+    if (ok) revokeKey.mutate(key.id);
+  };
+
+  const handleCopy = () => {
+    navigator.clipboard.writeText(newKey);
+    setCopied(true);
+    setTimeout(() => setCopied(false), 2000);
+  };
+
+  const scopeGroups = groupScopes(scopeNames ?? []);
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center justify-between">
+        <div>
+          <h1 className="text-2xl font-bold text-foreground">API Keys</h1>
+          <p className="text-sm text-text-secondary mt-1">
+            Scoped keys for server-to-server access. Send them as{" "}
+            <code className="font-mono text-xs">Authorization: Bearer gcms_…</code>
+          </p>
+        </div>
+        <button
+          onClick={() => setShowModal(true)}
+          className="flex items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 transition-colors"
+        >
+          <Plus className="h-4 w-4" />
+          New Key
+        </button>
+      </div>
+
+      {isLoading ? (
+        <div className="flex justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin text-accent" />
+        </div>
+      ) : !keys || keys.length === 0 ? (
+        <div className="rounded-xl border border-border bg-bg-secondary p-12 text-center">
+          <KeyRound className="h-10 w-10 text-text-muted mx-auto mb-3" />
+          <p className="text-sm text-text-secondary">No API keys yet.</p>
+        </div>
+      ) : (
+        <div className="rounded-xl border border-border bg-bg-secondary overflow-hidden">
+          <table className="w-full">
+            <thead>
+              <tr className="border-b border-border">
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Name</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Runs as</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Scopes</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Status</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Last used</th>
+                <th className="px-4 py-3" />
+              </tr>
+            </thead>
+            <tbody>
+              {keys.map((key) => {
+                const status = keyStatus(key);
+                return (
+                  <tr key={key.id} className="border-b border-border/50">
+                    <td className="px-4 py-3">
+                      <p className="text-sm font-medium text-foreground">{key.name}</p>
+                      <p className="text-xs text-text-muted font-mono">{key.prefix}…</p>
+                    </td>
+                    <td className="px-4 py-3 text-sm text-text-secondary">
+                      {key.user ? `${key.user.first_name} ${key.user.last_name}` : "Tenant"}
+                    </td>
+                    <td className="px-4 py-3">
+                      <div className="flex flex-wrap gap-1 max-w-xs">
+                        {(key.scopes ?? []).map((scope) => (
+                          <span
+                            key={scope}
+                            className="rounded bg-bg-tertiary px-1.5 py-0.5 text-xs font-mono text-text-secondary"
+                          >
+                            {scope}
+                          </span>
+                        ))}
+                      </div>
+                    </td>
+                    <td className="px-4 py-3">
+                      <span className={`rounded-full px-2 py-0.5 text-xs font-medium ${status.className}`}>
+                        {status.label}
+                      </span>
+                      {key.expires_at && !key.revoked_at && (
+                        <p className="text-xs text-text-muted mt-1">
+                          Expires {new Date(key.expires_at).toLocaleDateString()}
+                        </p>
+                      )}
+                    </td>
+                    <td className="px-4 py-3 text-xs text-text-muted">
+                      {key.last_used_at ? (
+                        <>
+                          {new Date(key.last_used_at).toLocaleString()}
+                          <br />
+                          {key.last_used_ip}
+                        </>
+                      ) : (
+                        "Never"
+                      )}
+                    </td>
+                    <td className="px-4 py-3 text-right">
+                      {!key.revoked_at && (
+                        <button
+                          onClick={() => handleRevoke(key)}
+                          className="rounded-lg p-1.5 text-text-muted hover:bg-red-500/10 hover:text-red-400 transition-colors"
+                          title="Revoke"
+                        >
+                          <Trash2 className="h-4 w-4" />
+                        </button>
+                      )}
+                    </td>
+                  </tr>
+                );
+              })}
+            </tbody>
+          </table>
+        </div>
+      )}
+
+      {/* Create modal */}
+      {showModal && (
+        <div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+          <div className="w-full max-w-lg rounded-xl border border-border bg-bg-elevated p-6 shadow-2xl mx-4 max-h-[90vh] overflow-y-auto">
+            <div className="flex items-center justify-between mb-5">
+              <h2 className="text-lg font-semibold text-foreground">
+                {newKey ? "API Key Created" : "New API Key"}
+              </h2>
+              <button
+                onClick={closeModal}
+                className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover transition-colors"
+              >
+                <X className="h-5 w-5" />
+              </button>
+            </div>
+
+            {newKey ? (
+              <div className="space-y-4">
+                <p className="text-sm text-text-secondary">
+                  Copy this key now. For your security it won&apos;t be shown again.
+                </p>
+                <div className="flex items-center gap-2">
+                  <code className="flex-1 break-all rounded-lg bg-bg-tertiary px-3 py-2 text-xs text-foreground font-mono">
+                    {newKey}
+                  </code>
+                  <button
+                    onClick={handleCopy}
+                    className="rounded-lg border border-border p-2 text-text-muted hover:bg-bg-hover transition-colors"
+                    title="Copy"
+                  >
+                    {copied ? <Check className="h-4 w-4 text-green-400" /> : <Copy className="h-4 w-4" />}
+                  </button>
+                </div>
+                <div className="flex justify-end">
+                  <button
+                    onClick={closeModal}
+                    className="rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 transition-colors"
+                  >
+                    Done
+                  </button>
+                </div>
+              </div>
+            ) : (
+              <>
+                <div className="space-y-4">
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Name</label>
+                    <input
+                      type="text"
+                      value={form.name}
+                      onChange={(e) => setForm({ ...form, name: e.target.value })}
+                      placeholder="Billing service"
+                      className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                      autoFocus
+                    />
+                  </div>
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Scopes</label>
+                    <div className="max-h-64 overflow-y-auto space-y-1.5 rounded-lg border border-border bg-bg-secondary px-3 py-2">
+                      {Object.entries(scopeGroups).map(([resource, actions]) => (
+                        <div key={resource} className="flex items-center justify-between gap-4">
+                          <span className="text-sm text-text-secondary capitalize">{resource}</span>
+                          <div className="flex items-center gap-3">
+                            {actions.map((action) => {
+                              const scope = `${resource}:${action}`;
+                              return (
+                                <label key={scope} className="flex items-center gap-1.5 text-xs text-text-secondary">
+                                  <input
+                                    type="checkbox"
+                                    checked={form.scopes.includes(scope)}
+                                    onChange={() => toggleScope(scope)}
+                                    className="rounded border-border"
+                                  />
+                                  {action}
+                                </label>
+                              );
+                            })}
+                          </div>
+                        </div>
+                      ))}
+                    </div>
+                    <p className="text-xs text-text-muted mt-1">Write includes read.</p>
+                  </div>
+                  <div>
+                    <label className="block text-sm font-medium text-text-secondary mb-1">Expires</label>
+                    <input
+                      type="date"
+                      value={form.expiresAt}
+                      onChange={(e) => setForm({ ...form, expiresAt: e.target.value })}
+                      className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                    />
+                    <p className="text-xs text-text-muted mt-1">Leave empty for a key that doesn&apos;t expire.</p>
+                  </div>
+                  <label className="flex items-start gap-2 text-sm text-text-secondary">
+                    <input
+                      type="checkbox"
+                      checked={form.runAsMe}
+                      onChange={(e) => setForm({ ...form, runAsMe: e.target.checked })}
+                      className="mt-0.5 rounded border-border"
+                    />
+                    <span>
+                      Run as me
+                      <span className="block text-xs text-text-muted">
+                        The key acts as your account and never gets more access than your role.
+                        Otherwise it belongs to the whole site.
+                      </span>
+                    </span>
+                  </label>
+                </div>
+
+                <div className="flex justify-end gap-2 mt-6">
+                  <button
+                    onClick={closeModal}
+                    className="rounded-lg border border-border px-4 py-2 text-sm font-medium text-text-secondary hover:bg-bg-hover transition-colors"
+                  >
+                    Cancel
+                  </button>
+                  <button
+                    onClick={handleSubmit}
+                    disabled={!form.name.trim() || form.scopes.length === 0 || createKey.isPending}
+                    className="rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 disabled:opacity-50 transition-colors"
+                  >
+                    Create
+                  </button>
+                </div>
+              </>
+            )}
+          </div>
+        </div>
+      )}
+    </div>
+  );
+}
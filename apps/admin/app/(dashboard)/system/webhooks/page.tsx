@@ -0,0 +1,550 @@
+"use client";
+
+import { useState } from "react";
+import {
+  Webhook,
+  Plus,
+  Pencil,
+  Trash2,
+  X,
+  Copy,
+  Check,
+  Eye,
+  EyeOff,
+  RefreshCw,
+  RotateCcw,
+  Loader2,
+  ChevronLeft,
+  ChevronRight,
+} from "@/lib/icons";
+import {
+  useWebhookEvents,
+  useWebhookEndpoints,
+  useCreateWebhookEndpoint,
+  useUpdateWebhookEndpoint,
+  useDeleteWebhookEndpoint,
+  useRotateWebhookSecret,
+  useWebhookDeliveries,
+  useWebhookDelivery,
+  useRedeliverWebhook,
+} from "@/hooks/use-webhooks";
+import { useConfirm } from "@/hooks/use-confirm";
+import type { WebhookEndpoint, WebhookDeliveryStatus } from "@repo/shared/types";
+
+interface EndpointForm {
+  name: string;
+  url: string;
+  description: string;
+  events: string[];
+  active: boolean;
+}
+
+const emptyForm: EndpointForm = {
+  name: "",
+  url: "",
+  description: "",
+  events: [],
+  active: true,
+};
+
+const deliveryStatuses: WebhookDeliveryStatus[] = ["pending", "retrying", "succeeded", "failed"];
+
+function statusColor(status: string) {
+  switch (status) {
+    case "succeeded":
+      return "bg-green-500/10 text-green-400";
+    case "failed":
+      return "bg-red-500/10 text-red-400";
+    case "retrying":
+      return "bg-yellow-500/10 text-yellow-400";
+    default:
+      return "bg-bg-elevated text-text-muted";
+  }
+}
+
+function formatPayload(payload: unknown) {
+  if (typeof payload === "string") return payload;
+  return JSON.stringify(payload, null, 2);
+}
+
+export default function WebhooksPage() {
+  const confirm = useConfirm();
+  const { data: eventNames } = useWebhookEvents();
+  const { data: endpoints, isLoading } = useWebhookEndpoints();
+  const createEndpoint = useCreateWebhookEndpoint();
+  const updateEndpoint = useUpdateWebhookEndpoint();
+  const deleteEndpoint = useDeleteWebhookEndpoint();
+  const rotateSecret = useRotateWebhookSecret();
+  const redeliver = useRedeliverWebhook();
+
+  const [showModal, setShowModal] = useState(false);
+  const [editingId, setEditingId] = useState<number | null>(null);
+  const [form, setForm] = useState<EndpointForm>(emptyForm);
+  const [revealed, setRevealed] = useState<number | null>(null);
+  const [copied, setCopied] = useState<number | null>(null);
+
+  const [page, setPage] = useState(1);
+  const [endpointFilter, setEndpointFilter] = useState(0);
+  const [statusFilter, setStatusFilter] = useState("");
+  const [selectedDelivery, setSelectedDelivery] = useState(0);
+  const { data: deliveries, isLoading: deliveriesLoading } = useWebhookDeliveries(
+    page,
+    endpointFilter,
+    statusFilter
+  );
+  const { data: delivery } = useWebhookDelivery(selectedDelivery);
+
+  const closeModal = () => {
+    setShowModal(false);
+    setEditingId(null);
+    setForm(emptyForm);
+  };
+
+  const openEdit = (ep: WebhookEndpoint) => {
+    setForm({
+      name: ep.name,
+      url: ep.url,
+      description: ep.description ?? "",
+      events: ep.events ?? [],
+      active: ep.active,
+    });
+    setEditingId(ep.id);
+    setShowModal(true);
+  };
+
+  const toggleEvent = (name: string) => {
+    setForm((prev) => ({
+      ...prev,
+      events: prev.events.includes(name)
+        ? prev.events.filter((e) => e !== name)
+        : [...prev.events, name],
+    }));
+  };
+
+  const handleSubmit = () => {
+    if (editingId) {
+      updateEndpoint.mutate({ id: editingId, ...form }, { onSuccess: closeModal });
+    } else {
+      createEndpoint.mutate(form, { onSuccess: closeModal });
+    }
+  };
+
+  const handleDelete = async (id: number) => {
+    const ok = await confirm({
+      title: "Delete Webhook Endpoint",
+      description: "Stop sending events to this endpoint? Queued retries are dropped.",
+      confirmLabel: "Delete",
+      variant: "danger",
+    });
+    if (ok) deleteEndpoint.mutate(id);
+  };
+
+  const handleRotate = async (id: number) => {
+    const ok = await confirm({
+      title: "Rotate Signing Secret",
+      description: "The old secret stops working immediately. Update your receiver with the new one.",
+      confirmLabel: "Rotate",
+      variant: "danger",
+    });
+    if (ok) rotateSecret.mutate(id);
+  };
+
+  const handleCopy = (id: number, secret: string) => {
+    navigator.clipboard.writeText(secret);
+    setCopied(id);
+    setTimeout(() => setCopied(null), 2000);
+  };
+
+  const allEvents = form.events.includes("*");
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center justify-between">
+        <div>
+          <h1 className="text-2xl font-bold text-foreground">Webhooks</h1>
+          <p className="text-sm text-text-secondary mt-1">
+            Send signed event payloads to external services
+          </p>
+        </div>
+        <button
+          onClick={() => setShowModal(true)}
+          className="flex items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 transition-colors"
+        >
+          <Plus className="h-4 w-4" />
+          Add Endpoint
+        </button>
+      </div>
+
+      {/* Endpoints */}
+      {isLoading ? (
+        <div className="flex justify-center py-12">
+          <Loader2 className="h-6 w-6 animate-spin text-accent" />
+        </div>
+      ) : !endpoints || endpoints.length === 0 ? (
+        <div className="rounded-xl border border-border bg-bg-secondary p-12 text-center">
+          <Webhook className="h-10 w-10 text-text-muted mx-auto mb-3" />
+          <p className="text-sm text-text-secondary">No webhook endpoints yet.</p>
+        </div>
+      ) : (
+        <div className="space-y-3">
+          {endpoints.map((ep) => (
+            <div key={ep.id} className="rounded-xl border border-border bg-bg-secondary p-5">
+              <div className="flex items-start justify-between gap-4">
+                <div className="min-w-0">
+                  <div className="flex items-center gap-2">
+                    <p className="font-medium text-foreground">{ep.name}</p>
+                    <span
+                      className={`rounded-full px-2 py-0.5 text-xs font-medium ${
+                        ep.active ? "bg-green-500/10 text-green-400" : "bg-zinc-500/10 text-zinc-400"
+                      }`}
+                    >
+                      {ep.active ? "Active" : "Disabled"}
+                    </span>
+                  </div>
+                  <p className="text-sm text-text-secondary font-mono truncate mt-0.5">{ep.url}</p>
+                  <p className="text-xs text-text-muted mt-1">
+                    {(ep.events ?? []).includes("*")
+                      ? "All events"
+                      : (ep.events ?? []).join(", ") || "No events"}
+                  </p>
+                </div>
+                <div className="flex items-center gap-1 shrink-0">
+                  <button
+                    onClick={() => openEdit(ep)}
+                    className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover transition-colors"
+                    title="Edit"
+                  >
+                    <Pencil className="h-4 w-4" />
+                  </button>
+                  <button
+                    onClick={() => handleDelete(ep.id)}
+                    className="rounded-lg p-1.5 text-text-muted hover:bg-red-500/10 hover:text-red-400 transition-colors"
+                    title="Delete"
+                  >
+                    <Trash2 className="h-4 w-4" />
+                  </button>
+                </div>
+              </div>
+
+              <div className="flex items-center gap-2 mt-3">
+                <span className="text-xs text-text-muted">Signing secret</span>
+                <code className="rounded bg-bg-tertiary px-2 py-0.5 text-xs text-text-secondary font-mono">
+                  {revealed === ep.id ? ep.secret : "whsec_••••••••••••"}
+                </code>
+                <button
+                  onClick={() => setRevealed(revealed === ep.id ? null : ep.id)}
+                  className="rounded p-1 text-text-muted hover:bg-bg-hover transition-colors"
+                  title={revealed === ep.id ? "Hide" : "Reveal"}
+                >
+                  {revealed === ep.id ? <EyeOff className="h-3.5 w-3.5" /> : <Eye className="h-3.5 w-3.5" />}
+                </button>
+                <button
+                  onClick={() => handleCopy(ep.id, ep.secret)}
+                  className="rounded p-1 text-text-muted hover:bg-bg-hover transition-colors"
+                  title="Copy"
+                >
+                  {copied === ep.id ? <Check className="h-3.5 w-3.5 text-green-400" /> : <Copy className="h-3.5 w-3.5" />}
+                </button>
+                <button
+                  onClick={() => handleRotate(ep.id)}
+                  className="rounded p-1 text-text-muted hover:bg-bg-hover transition-colors"
+                  title="Rotate secret"
+                >
+                  <RotateCcw className="h-3.5 w-3.5" />
+                </button>
+              </div>
+            </div>
+          ))}
+        </div>
+      )}
+
+      {/* Delivery log */}
+      <div className="space-y-3">
+        <div className="flex items-center justify-between gap-4">
+          <h2 className="text-lg font-semibold text-foreground">Recent Deliveries</h2>
+          <div className="flex items-center gap-2">
+            <select
+              value={endpointFilter}
+              onChange={(e) => {
+                setEndpointFilter(Number(e.target.value));
+                setPage(1);
+              }}
+              className="rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+            >
+              <option value={0}>All endpoints</option>
+              {(endpoints ?? []).map((ep) => (
+                <option key={ep.id} value={ep.id}>
+                  {ep.name}
+                </option>
+              ))}
+            </select>
+            <select
+              value={statusFilter}
+              onChange={(e) => {
+                setStatusFilter(e.target.value);
+                setPage(1);
+              }}
+              className="rounded-lg border border-border bg-bg-elevated px-3 py-1.5 text-sm text-foreground focus:border-accent focus:outline-none"
+            >
+              <option value="">All statuses</option>
+              {deliveryStatuses.map((s) => (
+                <option key={s} value={s}>
+                  {s.charAt(0).toUpperCase() + s.slice(1)}
+                </option>
+              ))}
+            </select>
+          </div>
+        </div>
+
+        <div className="rounded-xl border border-border bg-bg-secondary overflow-hidden">
+          <table className="w-full">
+            <thead>
+              <tr className="border-b border-border">
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Event</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Endpoint</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Status</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Response</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Attempts</th>
+                <th className="px-4 py-3 text-left text-xs font-medium text-text-muted uppercase">Sent</th>
+                <th className="px-4 py-3" />
+              </tr>
+            </thead>
+            <tbody>
+              {deliveriesLoading ? (
+                <tr>
+                  <td colSpan={7} className="px-4 py-8 text-center">
+                    <Loader2 className="h-5 w-5 animate-spin text-accent mx-auto" />
+                  </td>
+                </tr>
+              ) : !deliveries?.data?.length ? (
+                <tr>
+                  <td colSpan={7} className="px-4 py-8 text-center text-sm text-text-muted">
+                    No deliveries yet
+                  </td>
+                </tr>
+              ) : (
+                deliveries.data.map((d) => (
+                  <tr
+                    key={d.id}
+                    onClick={() => setSelectedDelivery(d.id)}
+                    className="border-b border-border/50 hover:bg-bg-hover cursor-pointer transition-colors"
+                  >
+                    <td className="px-4 py-3 text-sm font-mono text-foreground">{d.event}</td>
+                    <td className="px-4 py-3 text-sm text-text-secondary">{d.endpoint?.name ?? `#${d.endpoint_id}`}</td>
+                    <td className="px-4 py-3">
+                      <span className={`rounded-full px-2 py-0.5 text-xs font-medium capitalize ${statusColor(d.status)}`}>
+                        {d.status}
+                      </span>
+                    </td>
+                    <td className="px-4 py-3 text-sm text-text-secondary">
+                      {d.response_code ? `${d.response_code} · ${d.duration_ms}ms` : "—"}
+                    </td>
+                    <td className="px-4 py-3 text-sm text-text-secondary">{d.attempts}</td>
+                    <td className="px-4 py-3 text-xs text-text-muted">{new Date(d.created_at).toLocaleString()}</td>
+                    <td className="px-4 py-3 text-right">
+                      <button
+                        onClick={(e) => {
+                          e.stopPropagation();
+                          redeliver.mutate(d.id);
+                        }}
+                        className="flex items-center gap-1 rounded-lg px-2 py-1 text-xs text-text-secondary hover:bg-bg-elevated transition-colors ml-auto"
+                      >
+                        <RefreshCw className="h-3 w-3" />
+                        Redeliver
+                      </button>
+                    </td>
+                  </tr>
+                ))
+              )}
+            </tbody>
+          </table>
+        </div>
+
+        {deliveries && deliveries.meta.pages > 1 && (
+          <div className="flex items-center justify-end gap-2">
+            <button
+              onClick={() => setPage((p) => Math.max(1, p - 1))}
+              disabled={page <= 1}
+              className="rounded-lg border border-border p-1.5 text-text-secondary hover:bg-bg-hover disabled:opacity-50 transition-colors"
+            >
+              <ChevronLeft className="h-4 w-4" />
+            </button>
+            <span className="text-sm text-text-muted">
+              Page {page} of {deliveries.meta.pages}
+            </span>
+            <button
+              onClick={() => setPage((p) => Math.min(deliveries.meta.pages, p + 1))}
+              disabled={page >= deliveries.meta.pages}
+              className="rounded-lg border border-border p-1.5 text-text-secondary hover:bg-bg-hover disabled:opacity-50 transition-colors"
+            >
+              <ChevronRight className="h-4 w-4" />
+            </button>
+          </div>
+        )}
+      </div>
+
+      {/* Delivery detail */}
+      {selectedDelivery > 0 && delivery && (
+        <div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+          <div className="w-full max-w-2xl rounded-xl border border-border bg-bg-elevated p-6 shadow-2xl mx-4 max-h-[90vh] overflow-y-auto">
+            <div className="flex items-center justify-between mb-4">
+              <div>
+                <h2 className="text-lg font-semibold text-foreground font-mono">{delivery.event}</h2>
+                <p className="text-xs text-text-muted mt-0.5">
+                  {delivery.event_id} · {delivery.endpoint?.url}
+                </p>
+              </div>
+              <button
+                onClick={() => setSelectedDelivery(0)}
+                className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover transition-colors"
+              >
+                <X className="h-5 w-5" />
+              </button>
+            </div>
+
+            <div className="grid grid-cols-3 gap-3 mb-4">
+              <div className="rounded-lg border border-border p-3">
+                <p className="text-xs text-text-muted uppercase">Status</p>
+                <span className={`inline-block mt-1 rounded-full px-2 py-0.5 text-xs font-medium capitalize ${statusColor(delivery.status)}`}>
+                  {delivery.status}
+                </span>
+              </div>
+              <div className="rounded-lg border border-border p-3">
+                <p className="text-xs text-text-muted uppercase">Response</p>
+                <p className="text-sm text-foreground mt-1">{delivery.response_code || "—"}</p>
+              </div>
+              <div className="rounded-lg border border-border p-3">
+                <p className="text-xs text-text-muted uppercase">Attempts</p>
+                <p className="text-sm text-foreground mt-1">{delivery.attempts}</p>
+              </div>
+            </div>
+
+            {delivery.error && (
+              <p className="mb-4 rounded-lg bg-red-500/10 px-3 py-2 text-sm text-red-400">{delivery.error}</p>
+            )}
+
+            <p className="text-xs font-medium text-text-muted uppercase mb-2">Payload</p>
+            <pre className="text-xs text-text-secondary font-mono bg-bg-tertiary rounded-lg p-3 overflow-x-auto max-h-64">
+              {formatPayload(delivery.payload)}
+            </pre>
+
+            {delivery.response_body && (
+              <>
+                <p className="text-xs font-medium text-text-muted uppercase mt-4 mb-2">Response Body</p>
+                <pre className="text-xs text-text-secondary font-mono bg-bg-tertiary rounded-lg p-3 overflow-x-auto max-h-48">
+                  {delivery.response_body}
+                </pre>
+              </>
+            )}
+
+            <div className="flex justify-end mt-6">
+              <button
+                onClick={() => redeliver.mutate(delivery.id)}
+                disabled={redeliver.isPending}
+                className="flex items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 disabled:opacity-50 transition-colors"
+              >
+                <RefreshCw className="h-4 w-4" />
+                Redeliver
+              </button>
+            </div>
+          </div>
+        </div>
+      )}
+
+      {/* Endpoint modal */}
+      {showModal && (
+        <div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+          <div className="w-full max-w-lg rounded-xl border border-border bg-bg-elevated p-6 shadow-2xl mx-4 max-h-[90vh] overflow-y-auto">
+            <div className="flex items-center justify-between mb-5">
+              <h2 className="text-lg font-semibold text-foreground">
+                {editingId ? "Edit Endpoint" : "Add Endpoint"}
+              </h2>
+              <button
+                onClick={closeModal}
+                className="rounded-lg p-1.5 text-text-muted hover:bg-bg-hover transition-colors"
+              >
+                <X className="h-5 w-5" />
+              </button>
+            </div>
+
+            <div className="space-y-4">
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">Name</label>
+                <input
+                  type="text"
+                  value={form.name}
+                  onChange={(e) => setForm({ ...form, name: e.target.value })}
+                  placeholder="Zapier"
+                  className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                  autoFocus
+                />
+              </div>
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">URL</label>
+                <input
+                  type="url"
+                  value={form.url}
+                  onChange={(e) => setForm({ ...form, url: e.target.value })}
+                  placeholder="https://hooks.example.com/grit"
+                  className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground font-mono focus:border-accent focus:outline-none"
+                />
+              </div>
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">Description</label>
+                <input
+                  type="text"
+                  value={form.description}
+                  onChange={(e) => setForm({ ...form, description: e.target.value })}
+                  placeholder="Optional"
+                  className="w-full rounded-lg border border-border bg-bg-secondary px-3 py-2 text-sm text-foreground focus:border-accent focus:outline-none"
+                />
+              </div>
+              <div>
+                <label className="block text-sm font-medium text-text-secondary mb-1">Events</label>
+                <div className="max-h-56 overflow-y-auto space-y-1.5 rounded-lg border border-border bg-bg-secondary px-3 py-2">
+                  {(eventNames ?? []).map((name) => (
+                    <label key={name} className="flex items-center gap-2 text-sm text-text-secondary">
+                      <input
+                        type="checkbox"
+                        checked={form.events.includes(name)}
+                        disabled={allEvents && name !== "*"}
+                        onChange={() => toggleEvent(name)}
+                        className="rounded border-border"
+                      />
+                      {name === "*" ? "All events" : <span className="font-mono text-xs">{name}</span>}
+                    </label>
+                  ))}
+                </div>
+              </div>
+              <label className="flex items-center gap-2 text-sm text-text-secondary">
+                <input
+                  type="checkbox"
+                  checked={form.active}
+                  onChange={(e) => setForm({ ...form, active: e.target.checked })}
+                  className="rounded border-border"
+                />
+                Active
+              </label>
+            </div>
+
+            <div className="flex justify-end gap-2 mt-6">
+              <button
+                onClick={closeModal}
+                className="rounded-lg border border-border px-4 py-2 text-sm font-medium text-text-secondary hover:bg-bg-hover transition-colors"
+              >
+                Cancel
+              </button>
+              <button
+                onClick={handleSubmit}
+                disabled={!form.name.trim() || !form.url.trim() || createEndpoint.isPending || updateEndpoint.isPending}
+                className="rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent/90 disabled:opacity-50 transition-colors"
+              >
+                {editingId ? "Update" : "Create"}
+              </button>
+            </div>
+          </div>
+        </div>
+      )}
+    </div>
+  );
+}
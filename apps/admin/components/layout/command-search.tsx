@@ -36,6 +36,8 @@ const searchItems: SearchItem[] = [
   { label: "Jobs", href: "/system/jobs", icon: "Briefcase", category: "System" },
   { label: "Files", href: "/system/files", icon: "FolderOpen", category: "System" },
   { label: "Cron", href: "/system/cron", icon: "Calendar", category: "System" },
+  { label: "Webhooks", href: "/system/webhooks", icon: "Webhook", category: "System" },
+  { label: "API Keys", href: "/system/api-keys", icon: "KeyRound", category: "System" },
   { label: "Security", href: "/system/security", icon: "Shield", category: "System" },
 ];
 
@@ -169,6 +169,8 @@ export function Sidebar({ user, collapsed, onToggle, mobileOpen, onMobileClose }
         { label: "Jobs", href: "/system/jobs", icon: "Briefcase" },
         { label: "Files", href: "/system/files", icon: "FolderOpen" },
         { label: "Cron", href: "/system/cron", icon: "Calendar" },
+        { label: "Webhooks", href: "/system/webhooks", icon: "Webhook" },
+        { label: "API Keys", href: "/system/api-keys", icon: "KeyRound" },
         { label: "Security", href: "/system/security", icon: "Shield" },
       ]
     : [];
@@ -0,0 +1,92 @@
+"use client";
+
+import { useSessions, useRevokeSession, useRevokeOtherSessions } from "@/hooks/use-profile";
+import { Monitor, Smartphone, LogOut, Loader2 } from "@/lib/icons";
+
+function timeAgo(dateStr: string): string {
+  const seconds = Math.floor((Date.now() - new Date(dateStr).getTime()) / 1000);
+  if (seconds < 60) return "just now";
+  const minutes = Math.floor(seconds / 60);
+  if (minutes < 60) return `${minutes} minute${minutes !== 1 ? "s" : ""} ago`;
+  const hours = Math.floor(minutes / 60);
+  if (hours < 24) return `${hours} hour${hours !== 1 ? "s" : ""} ago`;
+  const days = Math.floor(hours / 24);
+  return `${days} day${days !== 1 ? "s" : ""} ago`;
+}
+
+function isMobile(device: string) {
+  return /iOS|iPadOS|Android/.test(device);
+}
+
+export function ActiveSessions() {
+  const { data: sessions, isLoading } = useSessions();
+  const revoke = useRevokeSession();
+  const revokeOthers = useRevokeOtherSessions();
+  const hasOthers = (sessions ?? []).some((s) => !s.current);
+
+  return (
+    <div className="rounded-xl border border-border bg-bg-secondary">
+      <div className="flex items-start justify-between border-b border-border px-6 py-4">
+        <div>
+          <div className="flex items-center gap-2">
+            <Monitor className="h-4 w-4 text-accent" />
+            <h3 className="font-semibold text-foreground">Active Sessions</h3>
+          </div>
+          <p className="mt-1 text-xs text-text-muted">
+            Devices signed in to your account. Sign out any you don&apos;t recognise.
+          </p>
+        </div>
+        {hasOthers && (
+          <button
+            onClick={() => revokeOthers.mutate()}
+            disabled={revokeOthers.isPending}
+            className="flex items-center gap-2 rounded-lg border border-border px-3 py-1.5 text-xs font-medium text-text-secondary hover:bg-bg-hover hover:text-foreground disabled:opacity-50 transition-colors"
+          >
+            <LogOut className="h-3.5 w-3.5" />
+            Sign out other devices
+          </button>
+        )}
+      </div>
+      <div className="divide-y divide-border">
+        {isLoading ? (
+          <div className="flex justify-center p-6">
+            <Loader2 className="h-5 w-5 animate-spin text-text-muted" />
+          </div>
+        ) : (
+          (sessions ?? []).map((s) => {
+            const Icon = isMobile(s.device) ? Smartphone : Monitor;
+            return (
+              <div key={s.id} className="flex items-center justify-between px-6 py-4">
+                <div className="flex items-center gap-3">
+                  <Icon className="h-5 w-5 text-text-muted" />
+                  <div>
+                    <p className="text-sm font-medium text-foreground">
+                      {s.device}
+                      {s.current && (
+                        <span className="ml-2 rounded-full bg-success/10 px-2 py-0.5 text-xs font-medium text-success">
+                          This device
+                        </span>
+                      )}
+                    </p>
+                    <p className="text-xs text-text-muted">
+                      {s.ip_address} · Last active {timeAgo(s.last_used_at)}
+                    </p>
+                  </div>
+                </div>
+                {!s.current && (
+                  <button
+                    onClick={() => revoke.mutate(s.id)}
+                    disabled={revoke.isPending}
+                    className="rounded-lg px-3 py-1.5 text-xs font-medium text-danger hover:bg-danger/10 disabled:opacity-50 transition-colors"
+                  >
+                    Sign out
+                  </button>
+                )}
+              </div>
+            );
+          })
+        )}
+      </div>
+    </div>
+  );
+}
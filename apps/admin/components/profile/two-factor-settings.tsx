@@ -0,0 +1,219 @@
+"use client";
+
+import { useState } from "react";
+import {
+  useTwoFactorStatus,
+  useSetupTwoFactor,
+  useEnableTwoFactor,
+  useDisableTwoFactor,
+  useRegenerateRecoveryCodes,
+} from "@/hooks/use-profile";
+import { Shield, Copy, Loader2 } from "@/lib/icons";
+import { toast } from "sonner";
+
+const inputClass =
+  "w-full rounded-lg border border-border bg-bg-tertiary px-3 py-2 text-sm text-foreground placeholder:text-text-muted focus:border-accent focus:outline-none focus:ring-1 focus:ring-accent tracking-widest";
+
+function copy(text: string) {
+  navigator.clipboard.writeText(text);
+  toast.success("Copied to clipboard");
+}
+
+function RecoveryCodes({ codes, onDone }: { codes: string[]; onDone: () => void }) {
+  return (
+    <div className="space-y-3">
+      <p className="text-sm text-text-secondary">
+        Save these recovery codes somewhere safe. Each one signs you in once if you lose
+        your authenticator app. They won&apos;t be shown again.
+      </p>
+      <div className="grid grid-cols-2 gap-2 rounded-lg bg-bg-tertiary p-4 font-mono text-sm text-foreground">
+        {codes.map((code) => (
+          <span key={code}>{code}</span>
+        ))}
+      </div>
+      <div className="flex gap-2">
+        <button
+          onClick={() => copy(codes.join("\n"))}
+          className="flex items-center gap-2 rounded-lg border border-border px-3 py-1.5 text-xs font-medium text-text-secondary hover:bg-bg-hover hover:text-foreground transition-colors"
+        >
+          <Copy className="h-3.5 w-3.5" />
+          Copy codes
+        </button>
+        <button
+          onClick={onDone}
+          className="rounded-lg bg-accent px-3 py-1.5 text-xs font-medium text-white hover:bg-accent-hover transition-colors"
+        >
+          I&apos;ve saved them
+        </button>
+      </div>
+    </div>
+  );
+}
+
+export function TwoFactorSettings() {
+  const { data: status, isLoading } = useTwoFactorStatus();
+  const setup = useSetupTwoFactor();
+  const enable = useEnableTwoFactor();
+  const disable = useDisableTwoFactor();
+  const regenerate = useRegenerateRecoveryCodes();
+
+  const [code, setCode] = useState("");
+  const [recoveryCodes, setRecoveryCodes] = useState<string[] | null>(null);
+
+  const onEnable = (e: React.FormEvent) => {
+    e.preventDefault();
+    enable.mutate(code.trim(), {
+      onSuccess: (codes) => {
+        setRecoveryCodes(codes);
+        setCode("");
+        setup.reset();
+      },
+    });
+  };
+
+  const onDisable = () => {
+    disable.mutate(code.trim(), { onSuccess: () => setCode("") });
+  };
+
+  const onRegenerate = () => {
+    regenerate.mutate(code.trim(), {
+      onSuccess: (codes) => {
+        setRecoveryCodes(codes);
+        setCode("");
+      },
+    });
+  };
+
+  const renderBody = () => {
+    if (isLoading || !status) {
+      return (
+        <div className="flex justify-center">
+          <Loader2 className="h-5 w-5 animate-spin text-text-muted" />
+        </div>
+      );
+    }
+
+    if (recoveryCodes) {
+      return <RecoveryCodes codes={recoveryCodes} onDone={() => setRecoveryCodes(null)} />;
+    }
+
+    if (status.enabled) {
+      return (
+        <div className="space-y-4">
+          <p className="text-sm text-text-secondary">
+            Two-factor authentication is on. You have{" "}
+            <span className="font-medium text-foreground">{status.recovery_codes_remaining}</span>{" "}
+            unused recovery code{status.recovery_codes_remaining !== 1 ? "s" : ""}.
+          </p>
+          <div className="flex flex-wrap items-center gap-2">
+            <input
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              placeholder="Authentication code"
+              autoComplete="one-time-code"
+              className={inputClass + " max-w-[200px]"}
+            />
+            <button
+              onClick={onRegenerate}
+              disabled={!code.trim() || regenerate.isPending}
+              className="rounded-lg border border-border px-3 py-2 text-xs font-medium text-text-secondary hover:bg-bg-hover hover:text-foreground disabled:opacity-50 transition-colors"
+            >
+              New recovery codes
+            </button>
+            {!status.required && (
+              <button
+                onClick={onDisable}
+                disabled={!code.trim() || disable.isPending}
+                className="rounded-lg px-3 py-2 text-xs font-medium text-danger hover:bg-danger/10 disabled:opacity-50 transition-colors"
+              >
+                Turn off
+              </button>
+            )}
+          </div>
+          <p className="text-xs text-text-muted">
+            Enter a current code from your app to make changes.
+          </p>
+        </div>
+      );
+    }
+
+    if (setup.data) {
+      return (
+        <form onSubmit={onEnable} className="space-y-4">
+          <p className="text-sm text-text-secondary">
+            Add this account to your authenticator app using the setup key below, or open the
+            link on your phone. Then enter the 6-digit code it shows.
+          </p>
+          <div className="flex items-center gap-2">
+            <code className="flex-1 break-all rounded-lg bg-bg-tertiary px-3 py-2 font-mono text-sm text-foreground">
+              {setup.data.secret}
+            </code>
+            <button
+              type="button"
+              onClick={() => copy(setup.data.secret)}
+              className="rounded-lg border border-border p-2 text-text-secondary hover:bg-bg-hover hover:text-foreground transition-colors"
+            >
+              <Copy className="h-4 w-4" />
+            </button>
+          </div>
+          <a
+            href={setup.data.otpauth_uri}
+            className="inline-block text-xs text-accent hover:text-accent-hover"
+          >
+            Open in authenticator app
+          </a>
+          <div className="flex items-center gap-2">
+            <input
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              placeholder="123456"
+              autoComplete="one-time-code"
+              className={inputClass + " max-w-[200px]"}
+            />
+            <button
+              type="submit"
+              disabled={!code.trim() || enable.isPending}
+              className="flex items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent-hover disabled:opacity-50 transition-colors"
+            >
+              {enable.isPending && <Loader2 className="h-4 w-4 animate-spin" />}
+              Turn on
+            </button>
+          </div>
+        </form>
+      );
+    }
+
+    return (
+      <div className="flex items-center justify-between gap-4">
+        <p className="text-sm text-text-secondary">
+          {status.required
+            ? "Your role requires two-factor authentication. Set it up to keep using the admin panel."
+            : "Protect your account with a code from an authenticator app when you sign in."}
+        </p>
+        <button
+          onClick={() => setup.mutate()}
+          disabled={setup.isPending}
+          className="flex shrink-0 items-center gap-2 rounded-lg bg-accent px-4 py-2 text-sm font-medium text-white hover:bg-accent-hover disabled:opacity-50 transition-colors"
+        >
+          {setup.isPending && <Loader2 className="h-4 w-4 animate-spin" />}
+          Set up
+        </button>
+      </div>
+    );
+  };
+
+  return (
+    <div className="rounded-xl border border-border bg-bg-secondary">
+      <div className="border-b border-border px-6 py-4">
+        <div className="flex items-center gap-2">
+          <Shield className="h-4 w-4 text-accent" />
+          <h3 className="font-semibold text-foreground">Two-Factor Authentication</h3>
+        </div>
+        <p className="mt-1 text-xs text-text-muted">
+          Require a code from your phone in addition to your password.
+        </p>
+      </div>
+      <div className="p-6">{renderBody()}</div>
+    </div>
+  );
+}
@@ -0,0 +1,61 @@
+"use client";
+
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { toast } from "sonner";
+import { apiClient } from "@/lib/api-client";
+import type { ApiKey } from "@repo/shared/types";
+
+export function useApiKeyScopes() {
+  return useQuery({
+    queryKey: ["api-key-scopes"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/api-keys/scopes");
+      return data.data as string[];
+    },
+    staleTime: Infinity,
+  });
+}
+
+export function useApiKeys() {
+  return useQuery({
+    queryKey: ["api-keys"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/api-keys");
+      return data.data as ApiKey[];
+    },
+  });
+}
+
+export function useCreateApiKey() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (body: {
+      name: string;
+      scopes: string[];
+      user_id?: number;
+      expires_at?: string;
+    }) => {
+      const { data } = await apiClient.post("/api/api-keys", body);
+      return data.data as { api_key: ApiKey; key: string };
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["api-keys"] });
+      toast.success("API key created");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to create API key"),
+  });
+}
+
+export function useRevokeApiKey() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(`/api/api-keys/${id}`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["api-keys"] });
+      toast.success("API key revoked");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to revoke API key"),
+  });
+}
@@ -23,6 +23,15 @@ interface AuthResponse {
       refresh_token: string;
       expires_at: number;
     };
+    two_factor_setup_required?: boolean;
+  };
+}
+
+interface TwoFactorChallenge {
+  data: {
+    two_factor_required: true;
+    challenge_token: string;
+    expires_at: string;
   };
 }
 
@@ -31,6 +40,14 @@ function storeTokens(tokens: { access_token: string; refresh_token: string }) {
   Cookies.set("refresh_token", tokens.refresh_token, { expires: 7 });
 }
 
+// Admins who must enroll in 2FA are sent to their profile to set it up.
+function homePath(data: AuthResponse["data"]) {
+  if (data.user.role === "USER" || data.two_factor_setup_required) {
+    return "/profile";
+  }
+  return "/dashboard";
+}
+
 function clearTokens() {
   Cookies.remove("access_token");
   Cookies.remove("refresh_token");
@@ -54,16 +71,39 @@ export function useLogin() {
 
   return useMutation({
     mutationFn: async (credentials: { email: string; password: string }) => {
-      const { data } = await apiClient.post<AuthResponse>(
+      const { data } = await apiClient.post<AuthResponse | TwoFactorChallenge>(
         "/api/auth/login",
         credentials
       );
       return data;
     },
     onSuccess: (data) => {
+      // Accounts with 2FA get a challenge instead; the login page asks for
+      // the code and finishes with useLoginTwoFactor.
+      if ("two_factor_required" in data.data) return;
       storeTokens(data.data.tokens);
       queryClient.setQueryData(["me"], data.data.user);
-      router.push(data.data.user.role === "USER" ? "/profile" : "/dashboard");
+      router.push(homePath(data.data));
+    },
+  });
+}
+
+export function useLoginTwoFactor() {
+  const router = useRouter();
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (payload: { challenge_token: string; code: string }) => {
+      const { data } = await apiClient.post<AuthResponse>(
+        "/api/auth/login/2fa",
+        payload
+      );
+      return data;
+    },
+    onSuccess: (data) => {
+      storeTokens(data.data.tokens);
+      queryClient.setQueryData(["me"], data.data.user);
+      router.push(homePath(data.data));
     },
   });
 }
@@ -7,6 +7,7 @@ import type {
   Calendar,
   BookingEventType,
   Availability,
+  AvailabilityOverride,
   Appointment,
 } from "@repo/shared/types";
 
@@ -83,7 +84,7 @@ export function useDeleteCalendar() {
 export function useCreateEventType() {
   const qc = useQueryClient();
   return useMutation({
-    mutationFn: async ({ calendarId, ...body }: Partial<BookingEventType> & { calendarId: number }) => {
+    mutationFn: async ({ calendarId, ...body }: Partial<BookingEventType> & { calendarId: number; host_calendar_ids?: number[] }) => {
       const { data } = await apiClient.post(`/api/booking/calendars/${calendarId}/event-types`, body);
       return data.data as BookingEventType;
     },
@@ -98,7 +99,7 @@ export function useCreateEventType() {
 export function useUpdateEventType() {
   const qc = useQueryClient();
   return useMutation({
-    mutationFn: async ({ id, ...body }: Partial<BookingEventType> & { id: number }) => {
+    mutationFn: async ({ id, ...body }: Partial<BookingEventType> & { id: number; host_calendar_ids?: number[] }) => {
       const { data } = await apiClient.put(`/api/booking/event-types/${id}`, body);
       return data.data as BookingEventType;
     },
@@ -141,6 +142,37 @@ export function useSetAvailability() {
   });
 }
 
+// --- Date overrides ---
+
+export function useCreateOverride() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ calendarId, ...body }: { calendarId: number } & Partial<AvailabilityOverride>) => {
+      const { data } = await apiClient.post(`/api/booking/calendars/${calendarId}/overrides`, body);
+      return data.data as AvailabilityOverride;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["booking-calendars"] });
+      toast.success("Date override added");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to add date override"),
+  });
+}
+
+export function useDeleteOverride() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(`/api/booking/overrides/${id}`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["booking-calendars"] });
+      toast.success("Date override removed");
+    },
+    onError: () => toast.error("Failed to remove date override"),
+  });
+}
+
 // --- Appointments ---
 
 export function useAppointments(page = 1, status = "", upcoming = false) {
@@ -172,13 +204,14 @@ export function useCancelAppointment() {
   return useMutation({
     mutationFn: async (id: number) => {
       const { data } = await apiClient.post(`/api/booking/appointments/${id}/cancel`);
-      return data.data as Appointment;
+      return data as { data: Appointment; refunded: boolean };
     },
-    onSuccess: () => {
+    onSuccess: (res) => {
       qc.invalidateQueries({ queryKey: ["booking-appointments"] });
-      toast.success("Appointment cancelled");
+      toast.success(res.refunded ? "Appointment cancelled and refunded" : "Appointment cancelled");
     },
-    onError: () => toast.error("Failed to cancel appointment"),
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error || "Failed to cancel appointment"),
   });
 }
 
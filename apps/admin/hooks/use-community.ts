@@ -10,6 +10,8 @@ import type {
   ThreadReply,
   CommunityEvent,
   EventAttendee,
+  ModerationQueueItem,
+  ModerationLog,
 } from "@repo/shared/types";
 
 // --- Spaces ---
@@ -248,6 +250,107 @@ export function useDeleteReply() {
   });
 }
 
+// --- Moderation ---
+
+export function useModerationQueue(spaceId?: number) {
+  return useQuery({
+    queryKey: ["community-moderation-queue", spaceId],
+    queryFn: async () => {
+      const params = spaceId ? `?space_id=${spaceId}` : "";
+      const { data } = await apiClient.get(`/api/community/moderation/queue${params}`);
+      return data.data as ModerationQueueItem[];
+    },
+  });
+}
+
+export function useModerationLogs(params: { spaceId?: number; action?: string; page?: number } = {}) {
+  return useQuery({
+    queryKey: ["community-moderation-logs", params],
+    queryFn: async () => {
+      const sp = new URLSearchParams();
+      if (params.spaceId) sp.set("space_id", String(params.spaceId));
+      if (params.action) sp.set("action", params.action);
+      sp.set("page", String(params.page ?? 1));
+      const { data } = await apiClient.get(`/api/community/moderation/logs?${sp}`);
+      return data as { data: ModerationLog[]; meta: { total: number; page: number; page_size: number; pages: number } };
+    },
+  });
+}
+
+export function useModerateContent() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({
+      type,
+      id,
+      action,
+      reason,
+    }: {
+      type: "thread" | "reply";
+      id: number;
+      action: "approve" | "hide" | "delete";
+      reason?: string;
+    }) => {
+      const path = `/api/community/moderation/${type}/${id}`;
+      if (action === "delete") {
+        await apiClient.delete(path, { data: { reason } });
+      } else {
+        await apiClient.post(`${path}/${action}`, { reason });
+      }
+      return action;
+    },
+    onSuccess: (action) => {
+      qc.invalidateQueries({ queryKey: ["community-moderation-queue"] });
+      qc.invalidateQueries({ queryKey: ["community-moderation-logs"] });
+      qc.invalidateQueries({ queryKey: ["community-threads"] });
+      toast.success(action === "approve" ? "Content approved" : action === "hide" ? "Content hidden" : "Content deleted");
+    },
+    onError: () => toast.error("Failed to moderate content"),
+  });
+}
+
+export function useDismissReport() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ id, reason }: { id: number; reason?: string }) => {
+      await apiClient.post(`/api/community/reports/${id}/dismiss`, { reason });
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["community-moderation-queue"] });
+      qc.invalidateQueries({ queryKey: ["community-moderation-logs"] });
+      toast.success("Report dismissed");
+    },
+    onError: () => toast.error("Failed to dismiss report"),
+  });
+}
+
+export function useSanctionMember() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({
+      memberId,
+      action,
+      minutes,
+      reason,
+    }: {
+      memberId: number;
+      action: "mute" | "unmute" | "ban" | "unban";
+      minutes?: number;
+      reason?: string;
+    }) => {
+      const { data } = await apiClient.post(`/api/community/members/${memberId}/${action}`, { minutes, reason });
+      return data.data as CommunityMember;
+    },
+    onSuccess: (_d, vars) => {
+      qc.invalidateQueries({ queryKey: ["community-members"] });
+      qc.invalidateQueries({ queryKey: ["community-moderation-logs"] });
+      const messages = { mute: "Member muted", unmute: "Member unmuted", ban: "Member banned", unban: "Member unbanned" };
+      toast.success(messages[vars.action]);
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update member"),
+  });
+}
+
 // --- Events ---
 
 export function useCommunityEvents(spaceId?: number) {
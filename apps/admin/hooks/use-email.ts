@@ -15,6 +15,7 @@ import type {
   EmailDashboardStats,
   EmailSend,
   CampaignStats,
+  CampaignLinkStats,
   Contact,
   ImportResult,
 } from "@repo/shared/types";
@@ -328,7 +329,11 @@ export function useCampaignStats(id: number) {
     queryKey: ["email-campaigns", id, "stats"],
     queryFn: async () => {
       const { data } = await apiClient.get(`/api/email/campaigns/${id}/stats`);
-      return data.data as CampaignStats;
+      return {
+        ...data.data,
+        links: data.links ?? [],
+        unique_clickers: data.unique_clickers ?? 0,
+      } as CampaignStats & { links: CampaignLinkStats[]; unique_clickers: number };
     },
     enabled: id > 0,
   });
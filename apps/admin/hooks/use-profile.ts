@@ -1,7 +1,9 @@
-import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api-client";
 import { useRouter } from "next/navigation";
 import Cookies from "js-cookie";
+import { toast } from "sonner";
+import type { UserSession, TwoFactorStatus, TwoFactorSetup } from "@repo/shared/types";
 
 interface UpdateProfileData {
   first_name?: string;
@@ -52,3 +54,125 @@ export function useDeleteAccount() {
     },
   });
 }
+
+export function useResendVerification() {
+  return useMutation({
+    mutationFn: async () => {
+      await apiClient.post("/api/auth/resend-verification");
+    },
+    onSuccess: () => toast.success("Verification email sent"),
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error?.message || "Failed to send verification email"),
+  });
+}
+
+export function useSessions() {
+  return useQuery({
+    queryKey: ["sessions"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/profile/sessions");
+      return data.data as UserSession[];
+    },
+  });
+}
+
+export function useRevokeSession() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(`/api/profile/sessions/${id}`);
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["sessions"] });
+      toast.success("Device signed out");
+    },
+    onError: () => toast.error("Failed to sign out device"),
+  });
+}
+
+export function useRevokeOtherSessions() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async () => {
+      await apiClient.delete("/api/profile/sessions");
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["sessions"] });
+      toast.success("Signed out of all other devices");
+    },
+    onError: () => toast.error("Failed to sign out other devices"),
+  });
+}
+
+export function useTwoFactorStatus() {
+  return useQuery({
+    queryKey: ["two-factor"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/profile/2fa");
+      return data.data as TwoFactorStatus;
+    },
+  });
+}
+
+export function useSetupTwoFactor() {
+  return useMutation({
+    mutationFn: async () => {
+      const { data } = await apiClient.post("/api/profile/2fa/setup");
+      return data.data as TwoFactorSetup;
+    },
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error?.message || "Failed to start two-factor setup"),
+  });
+}
+
+export function useEnableTwoFactor() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (code: string) => {
+      const { data } = await apiClient.post("/api/profile/2fa/enable", { code });
+      return data.data.recovery_codes as string[];
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["two-factor"] });
+      toast.success("Two-factor authentication enabled");
+    },
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error?.message || "Failed to enable two-factor authentication"),
+  });
+}
+
+export function useDisableTwoFactor() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (code: string) => {
+      await apiClient.post("/api/profile/2fa/disable", { code });
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["two-factor"] });
+      toast.success("Two-factor authentication disabled");
+    },
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error?.message || "Failed to disable two-factor authentication"),
+  });
+}
+
+export function useRegenerateRecoveryCodes() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (code: string) => {
+      const { data } = await apiClient.post("/api/profile/2fa/recovery-codes", { code });
+      return data.data.recovery_codes as string[];
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["two-factor"] });
+      toast.success("New recovery codes generated");
+    },
+    onError: (err: any) =>
+      toast.error(err.response?.data?.error?.message || "Failed to generate recovery codes"),
+  });
+}
@@ -0,0 +1,129 @@
+"use client";
+
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { toast } from "sonner";
+import { apiClient } from "@/lib/api-client";
+import type { WebhookEndpoint, WebhookDelivery } from "@repo/shared/types";
+
+// --- Endpoints ---
+
+export function useWebhookEvents() {
+  return useQuery({
+    queryKey: ["webhook-events"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/webhook-endpoints/events");
+      return data.data as string[];
+    },
+    staleTime: Infinity,
+  });
+}
+
+export function useWebhookEndpoints() {
+  return useQuery({
+    queryKey: ["webhook-endpoints"],
+    queryFn: async () => {
+      const { data } = await apiClient.get("/api/webhook-endpoints");
+      return data.data as WebhookEndpoint[];
+    },
+  });
+}
+
+export function useCreateWebhookEndpoint() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (body: Partial<WebhookEndpoint>) => {
+      const { data } = await apiClient.post("/api/webhook-endpoints", body);
+      return data.data as WebhookEndpoint;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["webhook-endpoints"] });
+      toast.success("Webhook endpoint added");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to add webhook endpoint"),
+  });
+}
+
+export function useUpdateWebhookEndpoint() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ id, ...body }: Partial<WebhookEndpoint> & { id: number }) => {
+      const { data } = await apiClient.put(`/api/webhook-endpoints/${id}`, body);
+      return data.data as WebhookEndpoint;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["webhook-endpoints"] });
+      toast.success("Webhook endpoint updated");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update webhook endpoint"),
+  });
+}
+
+export function useDeleteWebhookEndpoint() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(`/api/webhook-endpoints/${id}`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["webhook-endpoints"] });
+      toast.success("Webhook endpoint deleted");
+    },
+    onError: () => toast.error("Failed to delete webhook endpoint"),
+  });
+}
+
+export function useRotateWebhookSecret() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (id: number) => {
+      const { data } = await apiClient.post(`/api/webhook-endpoints/${id}/rotate-secret`);
+      return data.data as WebhookEndpoint;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["webhook-endpoints"] });
+      toast.success("Signing secret rotated");
+    },
+    onError: () => toast.error("Failed to rotate signing secret"),
+  });
+}
+
+// --- Deliveries ---
+
+export function useWebhookDeliveries(page = 1, endpointId = 0, status = "") {
+  return useQuery({
+    queryKey: ["webhook-deliveries", { page, endpointId, status }],
+    queryFn: async () => {
+      const sp = new URLSearchParams({ page: String(page) });
+      if (endpointId) sp.set("endpoint_id", String(endpointId));
+      if (status) sp.set("status", status);
+      const { data } = await apiClient.get(`/api/webhook-deliveries?${sp}`);
+      return data as { data: WebhookDelivery[]; meta: { total: number; page: number; page_size: number; pages: number } };
+    },
+  });
+}
+
+export function useWebhookDelivery(id: number) {
+  return useQuery({
+    queryKey: ["webhook-deliveries", id],
+    queryFn: async () => {
+      const { data } = await apiClient.get(`/api/webhook-deliveries/${id}`);
+      return data.data as WebhookDelivery;
+    },
+    enabled: id > 0,
+  });
+}
+
+export function useRedeliverWebhook() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (id: number) => {
+      const { data } = await apiClient.post(`/api/webhook-deliveries/${id}/redeliver`);
+      return data.data as WebhookDelivery;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["webhook-deliveries"] });
+      toast.success("Redelivery queued");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to redeliver"),
+  });
+}
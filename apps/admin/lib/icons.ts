@@ -108,6 +108,9 @@ import {
   Copy,
   Plug,
   Unplug,
+  Webhook,
+  RotateCcw,
+  KeyRound,
   Type as TypeIcon,
   type LucideIcon,
 } from "lucide-react";
@@ -169,6 +172,8 @@ export const iconMap: Record<string, LucideIcon> = {
   ArrowDown,
   Share2,
   Type,
+  Webhook,
+  KeyRound,
 };
 
 export function getIcon(name: string): LucideIcon {
@@ -286,4 +291,7 @@ export {
   Plug,
   Unplug,
   Server,
+  Webhook,
+  RotateCcw,
+  KeyRound,
 };
@@ -71,13 +71,16 @@ func main() {
 		}
 	}
 
-	// Email (Resend)
+	// Email (Resend, SMTP, or local files — see MAIL_DRIVER)
 	var mailer *mail.Mailer
-	if cfg.ResendAPIKey != "" && cfg.ResendAPIKey != "re_your_api_key" {
-		mailer = mail.New(cfg.ResendAPIKey, cfg.MailFrom)
-		log.Println("Email service configured")
+	if transport, err := mail.NewTransport(cfg.Mail); err != nil {
+		log.Printf("Warning: Email unavailable: %v (emails disabled)", err)
 	} else {
-		log.Println("Warning: Resend API key not set (emails disabled)")
+		mailer = mail.NewWithTransport(transport, cfg.MailFrom)
+		mailer.SetSuppressionCheck(func(email string, transactional bool) bool {
+			return models.IsEmailBlocked(db, email, transactional)
+		})
+		log.Printf("Email service configured (%s)", cfg.Mail.Driver)
 	}
 
 	// AI service
@@ -141,6 +144,9 @@ func main() {
 			Storage: storageService,
 			Cache:   cacheService,
 			Jobs:    jobClient,
+			AppURL:  cfg.AppURL,
+
+			TrackingSecret: cfg.TrackingSecret,
 		})
 		if err != nil {
 			log.Printf("Warning: Background worker failed to start: %v", err)
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
@@ -20,6 +23,18 @@ type StorageConfig struct {
 	PublicURL string // Public base URL for serving files (e.g. R2 dev URL)
 }
 
+// MailConfig holds settings for the outgoing mail transport.
+type MailConfig struct {
+	Driver         string // "resend", "smtp", "file", or "maildir"
+	ResendAPIKey   string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
+	SMTPEncryption string // "starttls", "tls", or "none"
+	FileDir        string // Output directory for the file and maildir drivers
+}
+
 // Config holds all application configuration.
 type Config struct {
 	AppName     string
@@ -32,14 +47,25 @@ type Config struct {
 	JWTAccessExpiry  time.Duration
 	JWTRefreshExpiry time.Duration
 
+	// RequireEmailVerification blocks community posting until the user has
+	// verified their email address.
+	RequireEmailVerification bool
+
+	// RequireAdminTwoFactor keeps owners and admins out of the admin API
+	// until they have turned on two-factor authentication.
+	RequireAdminTwoFactor bool
+
 	RedisURL string
 
 	// Storage
 	StorageDriver string        // "minio", "r2", or "b2"
 	Storage       StorageConfig // Resolved config for the active driver
 
-	ResendAPIKey string
-	MailFrom     string
+	MailFrom string
+	Mail     MailConfig // Resolved config for the active driver
+
+	ResendWebhookSecret string // Signing secret for delivery/bounce webhooks (whsec_...)
+	TrackingSecret      string // Signs click-tracking links; derived from JWTSecret when unset
 
 	CORSOrigins []string
 
@@ -98,11 +124,24 @@ func Load() (*Config, error) {
 		JWTSecret:   getEnv("JWT_SECRET", ""),
 		RedisURL:    getEnv("REDIS_URL", "redis://localhost:6379"),
 
+		RequireEmailVerification: getEnv("REQUIRE_EMAIL_VERIFICATION", "false") == "true",
+		RequireAdminTwoFactor:    getEnv("REQUIRE_ADMIN_2FA", "false") == "true",
+
 		StorageDriver: storageDriver,
 		Storage:       resolveStorage(storageDriver),
 
-		ResendAPIKey: getEnv("RESEND_API_KEY", ""),
-		MailFrom:     getEnv("MAIL_FROM", "noreply@localhost"),
+		MailFrom: getEnv("MAIL_FROM", "noreply@localhost"),
+		Mail: MailConfig{
+			Driver:         getEnv("MAIL_DRIVER", "resend"),
+			ResendAPIKey:   getEnv("RESEND_API_KEY", ""),
+			SMTPHost:       getEnv("SMTP_HOST", ""),
+			SMTPPort:       getEnv("SMTP_PORT", "587"),
+			SMTPUsername:   getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
+			SMTPEncryption: getEnv("SMTP_ENCRYPTION", "starttls"),
+			FileDir:        getEnv("MAIL_FILE_DIR", "tmp/mail"),
+		},
+		ResendWebhookSecret: getEnv("RESEND_WEBHOOK_SECRET", ""),
 
 		CORSOrigins: trimSlice(strings.Split(getEnv("CORS_ORIGINS", "http://localhost:3000,http://localhost:3001"), ",")),
 
@@ -146,6 +185,13 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("JWT_SECRET is required")
 	}
 
+	// Tracking links end up in every inbox they're sent to, so they're never
+	// signed with the JWT key itself.
+	cfg.TrackingSecret = getEnv("TRACKING_SECRET", "")
+	if cfg.TrackingSecret == "" {
+		cfg.TrackingSecret = deriveSecret(cfg.JWTSecret, "click-tracking")
+	}
+
 	// Parse durations
 	accessExpiry, err := time.ParseDuration(getEnv("JWT_ACCESS_EXPIRY", "15m"))
 	if err != nil {
@@ -162,6 +208,14 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// deriveSecret returns a key for a single purpose derived from secret, so a
+// leak of one derived key doesn't expose secret or the other keys.
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // IsDevelopment returns true if the app is running in development mode.
 func (c *Config) IsDevelopment() bool {
 	return c.AppEnv == "development"
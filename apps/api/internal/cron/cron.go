@@ -3,6 +3,7 @@ package cron
 import (
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/hibiken/asynq"
 )
@@ -14,9 +15,32 @@ type Task struct {
 	Type     string `json:"type"`
 }
 
-// RegisteredTasks holds the list of cron tasks for the admin API.
+// RegisteredTasks holds the list of cron tasks for the admin API: the built-in
+// tasks followed by any scheduled workflows. Read it through Tasks.
 var RegisteredTasks []Task
 
+var (
+	tasksMu       sync.RWMutex
+	builtinTasks  []Task
+	workflowTasks []Task
+)
+
+// Tasks returns a snapshot of RegisteredTasks.
+func Tasks() []Task {
+	tasksMu.RLock()
+	defer tasksMu.RUnlock()
+	return append([]Task{}, RegisteredTasks...)
+}
+
+// SetWorkflowTasks replaces the scheduled workflows listed in RegisteredTasks.
+// They all run through the workflow:check-scheduled task registered below.
+func SetWorkflowTasks(tasks []Task) {
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+	workflowTasks = tasks
+	RegisteredTasks = append(append([]Task{}, builtinTasks...), workflowTasks...)
+}
+
 // Scheduler wraps asynq.Scheduler for cron-like job scheduling.
 type Scheduler struct {
 	scheduler *asynq.Scheduler
@@ -56,8 +80,69 @@ func New(redisURL string) (*Scheduler, error) {
 		Type:     "campaign:check-scheduled",
 	})
 
+	// Fire due schedule-triggered workflows — every minute
+	_, err = scheduler.Register("* * * * *", asynq.NewTask("workflow:check-scheduled", nil))
+	if err != nil {
+		return nil, fmt.Errorf("registering workflow schedule check: %w", err)
+	}
+	RegisteredTasks = append(RegisteredTasks, Task{
+		Name:     "Run scheduled workflows",
+		Schedule: "* * * * *",
+		Type:     "workflow:check-scheduled",
+	})
+
+	// Send due email sequence steps — every minute
+	_, err = scheduler.Register("* * * * *", asynq.NewTask("sequence:process", nil))
+	if err != nil {
+		return nil, fmt.Errorf("registering sequence processing: %w", err)
+	}
+	RegisteredTasks = append(RegisteredTasks, Task{
+		Name:     "Send email sequence steps",
+		Schedule: "* * * * *",
+		Type:     "sequence:process",
+	})
+
+	// Email students about newly unlocked drip lessons — nightly
+	_, err = scheduler.Register("0 2 * * *", asynq.NewTask("course:drip-notify", nil))
+	if err != nil {
+		return nil, fmt.Errorf("registering course drip notifications: %w", err)
+	}
+	RegisteredTasks = append(RegisteredTasks, Task{
+		Name:     "Notify students of unlocked lessons",
+		Schedule: "0 2 * * *",
+		Type:     "course:drip-notify",
+	})
+
+	// Email community notification digests — daily; weekly digests go out
+	// seven days after the last one
+	_, err = scheduler.Register("0 8 * * *", asynq.NewTask("community:digest", nil))
+	if err != nil {
+		return nil, fmt.Errorf("registering community digest: %w", err)
+	}
+	RegisteredTasks = append(RegisteredTasks, Task{
+		Name:     "Send community digests",
+		Schedule: "0 8 * * *",
+		Type:     "community:digest",
+	})
+
+	// Release paid bookings whose payment hold ran out — every minute
+	_, err = scheduler.Register("* * * * *", asynq.NewTask("booking:release-holds", nil))
+	if err != nil {
+		return nil, fmt.Errorf("registering booking hold release: %w", err)
+	}
+	RegisteredTasks = append(RegisteredTasks, Task{
+		Name:     "Release unpaid booking holds",
+		Schedule: "* * * * *",
+		Type:     "booking:release-holds",
+	})
+
 	// grit:cron-tasks
 
+	tasksMu.Lock()
+	builtinTasks = RegisteredTasks
+	RegisteredTasks = append(append([]Task{}, builtinTasks...), workflowTasks...)
+	tasksMu.Unlock()
+
 	return &Scheduler{scheduler: scheduler}, nil
 }
 
@@ -72,3 +72,19 @@ const (
 	PagePublished = "website.page.published"
 	PostPublished = "website.post.published"
 )
+
+// All lists every event name above, for features that let admins pick
+// events to subscribe to, such as outbound webhooks.
+var All = []string{
+	ContactCreated, ContactUpdated, ContactDeleted, ContactTagged,
+	EmailSubscribed, EmailUnsubscribed, EmailCampaignSent, EmailOpened, EmailClicked, EmailBounced,
+	EmailSequenceEnrolled, EmailSequenceCompleted, EmailSequenceStepSent,
+	CourseEnrolled, CourseLessonCompleted, CourseCompleted,
+	PurchaseCompleted, PurchaseRefunded,
+	SubscriptionCreated, SubscriptionRenewed, SubscriptionCancelled, SubscriptionPastDue,
+	CommunityThreadCreated, CommunityReplyCreated, CommunityMemberJoined,
+	BookingConfirmed, BookingCancelled, BookingRescheduled,
+	FunnelVisited, FunnelConverted,
+	AffiliateReferral, AffiliateCommission,
+	PagePublished, PostPublished,
+}
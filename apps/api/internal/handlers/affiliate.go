@@ -6,6 +6,7 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,8 +14,13 @@ import (
 
 	"gritcms/apps/api/internal/events"
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
+// referralCookie holds "code:linkID:clickedAt" for the last referral link a
+// visitor followed; linkID is empty when the click wasn't on a specific link.
+const referralCookie = "grit_ref"
+
 type AffiliateHandler struct {
 	DB *gorm.DB
 }
@@ -289,16 +295,10 @@ func (h *AffiliateHandler) ApproveCommission(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Commission not found"})
 		return
 	}
-	now := time.Now()
-	h.DB.Model(&comm).Updates(map[string]interface{}{
-		"status":      models.CommissionApproved,
-		"approved_at": now,
-	})
-
-	// Add to account balance
-	h.DB.Model(&models.AffiliateAccount{}).Where("id = ?", comm.AccountID).
-		UpdateColumn("balance", gorm.Expr("balance + ?", comm.Amount))
-
+	if !services.ApproveCommission(h.DB, &comm) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only pending commissions can be approved"})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"data": comm})
 }
 
@@ -460,18 +460,44 @@ func (h *AffiliateHandler) Dashboard(c *gin.Context) {
 
 // ---------- Public: Referral Tracking ----------
 
+// TrackReferral records a click on an affiliate's referral link and sets the
+// referral cookie. Checkout reads the cookie to attribute the buyer to the
+// affiliate for the program's cookie window. An optional ?link= (link ID or
+// slug) credits the click and any resulting sale to a specific link.
 func (h *AffiliateHandler) TrackReferral(c *gin.Context) {
 	code := c.Param("code")
 	var account models.AffiliateAccount
-	if err := h.DB.Where("referral_code = ? AND status = ?", code, models.AffiliateStatusActive).
+	if err := h.DB.Preload("Program").Where("referral_code = ? AND status = ?", code, models.AffiliateStatusActive).
 		First(&account).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid referral code"})
 		return
 	}
 
-	// Increment click on first link
-	h.DB.Model(&models.AffiliateLink{}).Where("account_id = ?", account.ID).
-		UpdateColumn("clicks", gorm.Expr("clicks + 1"))
+	var link models.AffiliateLink
+	linkQ := h.DB.Where("account_id = ?", account.ID)
+	if ref := c.Query("link"); ref != "" {
+		if id, err := strconv.Atoi(ref); err == nil {
+			linkQ = linkQ.Where("id = ?", id)
+		} else {
+			linkQ = linkQ.Where("slug = ?", ref)
+		}
+	}
+	hasLink := linkQ.Order("id ASC").First(&link).Error == nil
+	if hasLink {
+		h.DB.Model(&link).UpdateColumn("clicks", gorm.Expr("clicks + 1"))
+	}
+
+	cookieDays := 30
+	if account.Program != nil && account.Program.CookieDays > 0 {
+		cookieDays = account.Program.CookieDays
+	}
+	linkPart := ""
+	if hasLink {
+		linkPart = strconv.FormatUint(uint64(link.ID), 10)
+	}
+	value := account.ReferralCode + ":" + linkPart + ":" + strconv.FormatInt(time.Now().Unix(), 10)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(referralCookie, value, cookieDays*86400, "/", "", c.Request.TLS != nil, true)
 
 	events.Emit(events.AffiliateReferral, map[string]interface{}{
 		"account_id": account.ID, "referral_code": code,
@@ -481,12 +507,44 @@ func (h *AffiliateHandler) TrackReferral(c *gin.Context) {
 		"data": gin.H{
 			"referral_code": account.ReferralCode,
 			"account_id":    account.ID,
+			"cookie_days":   cookieDays,
 		},
 	})
 }
 
 // ---------- Helpers ----------
 
+// recordReferralCookie attributes the contact to the affiliate whose referral
+// link set the visitor's referral cookie, as of the click.
+func recordReferralCookie(c *gin.Context, db *gorm.DB, contact *models.Contact) {
+	value, err := c.Cookie(referralCookie)
+	if err != nil {
+		return
+	}
+	if code, linkID, clickedAt, ok := parseReferral(value); ok {
+		services.RecordReferral(db, contact, code, linkID, clickedAt)
+	}
+}
+
+// parseReferral splits a referral cookie into the code, optional link ID and
+// click time. Cookies without a click time aren't trusted.
+func parseReferral(value string) (string, *uint, time.Time, bool) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, time.Time{}, false
+	}
+	clicked, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || clicked <= 0 {
+		return "", nil, time.Time{}, false
+	}
+	var linkID *uint
+	if id, err := strconv.ParseUint(parts[1], 10, 64); err == nil && id > 0 {
+		v := uint(id)
+		linkID = &v
+	}
+	return parts[0], linkID, time.Unix(clicked, 0), true
+}
+
 func generateReferralCode() string {
 	b := make([]byte, 6)
 	rand.Read(b)
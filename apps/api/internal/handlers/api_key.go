@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// APIKeyHandler manages scoped API keys for server-to-server access.
+type APIKeyHandler struct {
+	DB *gorm.DB
+}
+
+type createAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	UserID    *uint      `json:"user_id"` // omit for a tenant key
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ListScopes returns the scopes a key can be granted.
+func (h *APIKeyHandler) ListScopes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": models.APIKeyScopes()})
+}
+
+// List returns every API key, newest first. Revoked keys are included.
+func (h *APIKeyHandler) List(c *gin.Context) {
+	var keys []models.APIKey
+	h.DB.Preload("User").Order("created_at DESC").Find(&keys)
+	c.JSON(http.StatusOK, gin.H{"data": keys})
+}
+
+// Create issues a new API key. The key is only returned in this response.
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !models.IsAPIKeyScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown scope: " + scope})
+			return
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	tenantID := c.GetUint("tenant_id")
+	if req.UserID != nil {
+		var user models.User
+		if err := h.DB.First(&user, *req.UserID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "User not found"})
+			return
+		}
+		if !user.Active {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "User is disabled"})
+			return
+		}
+		tenantID = user.TenantID
+	}
+
+	key, prefix, err := services.GenerateAPIKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API key"})
+		return
+	}
+	scopes, _ := json.Marshal(req.Scopes)
+
+	apiKey := models.APIKey{
+		TenantID:    tenantID,
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Prefix:      prefix,
+		KeyHash:     services.HashToken(key),
+		Scopes:      datatypes.JSON(scopes),
+		ExpiresAt:   req.ExpiresAt,
+		CreatedByID: c.GetUint("user_id"),
+	}
+	if err := h.DB.Create(&apiKey).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+
+	log.Printf("[APIKeys] User %d created API key %d (%s)", apiKey.CreatedByID, apiKey.ID, apiKey.Prefix)
+	c.JSON(http.StatusCreated, gin.H{
+		"data": gin.H{
+			"api_key": apiKey,
+			"key":     key,
+		},
+		"message": "API key created. Copy it now; it won't be shown again.",
+	})
+}
+
+// Revoke disables an API key immediately. The row is kept for auditing.
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var apiKey models.APIKey
+	if err := h.DB.First(&apiKey, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+
+	if apiKey.RevokedAt == nil {
+		if err := h.DB.Model(&apiKey).Update("revoked_at", time.Now()).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+			return
+		}
+		log.Printf("[APIKeys] User %d revoked API key %d (%s)", c.GetUint("user_id"), apiKey.ID, apiKey.Prefix)
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
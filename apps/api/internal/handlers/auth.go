@@ -1,19 +1,25 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/markbates/goth"
 	"github.com/markbates/goth/gothic"
 	"gorm.io/gorm"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"gritcms/apps/api/internal/config"
+	"gritcms/apps/api/internal/mail"
 	"gritcms/apps/api/internal/models"
 	"gritcms/apps/api/internal/services"
 )
@@ -23,6 +29,7 @@ type AuthHandler struct {
 	DB          *gorm.DB
 	AuthService *services.AuthService
 	Config      *config.Config
+	Mailer      *mail.Mailer
 }
 
 type registerRequest struct {
@@ -37,6 +44,11 @@ type loginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type loginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
 type refreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
@@ -50,6 +62,10 @@ type resetPasswordRequest struct {
 	Password string `json:"password" binding:"required,min=8"`
 }
 
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 // Register creates a new user account.
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req registerRequest
@@ -103,7 +119,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.AuthService.GenerateTokenPair(user.ID, user.Email, user.Role)
+	h.sendVerificationEmail(user)
+
+	tokens, err := h.AuthService.StartSession(h.DB, &user, sessionMeta(c))
 	if err != nil {
 		log.Printf("[Auth] Failed to generate tokens for user %s: %v", user.Email, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -182,7 +200,71 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.AuthService.GenerateTokenPair(user.ID, user.Email, user.Role)
+	// With 2FA on, the password only earns a short-lived challenge that
+	// LoginTwoFactor exchanges for tokens together with a code.
+	if services.TwoFactorEnabled(h.DB, user.ID) {
+		challenge, expiresAt := h.AuthService.TwoFactorChallenge(&user)
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"two_factor_required": true,
+				"challenge_token":     challenge,
+				"expires_at":          expiresAt,
+			},
+			"message": "Enter the code from your authenticator app",
+		})
+		return
+	}
+
+	h.completeLogin(c, &user)
+}
+
+// LoginTwoFactor completes a login started by Login for a user with 2FA on,
+// given the challenge token and an authenticator or recovery code.
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req loginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	user, err := h.AuthService.ParseTwoFactorChallenge(h.DB, req.ChallengeToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_CHALLENGE",
+				"message": "Your sign-in attempt has expired. Please sign in again.",
+			},
+		})
+		return
+	}
+
+	if !user.Active {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "ACCOUNT_DISABLED",
+				"message": "Your account has been disabled",
+			},
+		})
+		return
+	}
+
+	if err := services.VerifySecondFactor(h.DB, user.ID, req.Code); err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+// completeLogin starts a session for an authenticated user and writes the
+// login response.
+func (h *AuthHandler) completeLogin(c *gin.Context, user *models.User) {
+	tokens, err := h.AuthService.StartSession(h.DB, user, sessionMeta(c))
 	if err != nil {
 		log.Printf("[Auth] Failed to generate tokens for user %s: %v", user.Email, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -194,11 +276,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	data := gin.H{
+		"user":   user,
+		"tokens": tokens,
+	}
+	if h.Config.RequireAdminTwoFactor && models.IsAdminRole(user.Role) && !services.TwoFactorEnabled(h.DB, user.ID) {
+		data["two_factor_setup_required"] = true
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"data": gin.H{
-			"user":   user,
-			"tokens": tokens,
-		},
+		"data":    data,
 		"message": "Logged in successfully",
 	})
 }
@@ -216,8 +303,26 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	claims, err := h.AuthService.ValidateToken(req.RefreshToken)
-	if err != nil {
+	tokens, _, err := h.AuthService.RefreshSession(h.DB, req.RefreshToken, sessionMeta(c))
+	switch {
+	case errors.Is(err, services.ErrAccountDisabled):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "ACCOUNT_DISABLED",
+				"message": "Your account has been disabled",
+			},
+		})
+		return
+	case errors.Is(err, services.ErrRefreshTokenReused):
+		log.Printf("[Auth] Refresh token reuse detected from %s, session revoked", c.ClientIP())
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "TOKEN_REUSED",
+				"message": "This session has been signed out for your security. Please sign in again.",
+			},
+		})
+		return
+	case errors.Is(err, services.ErrInvalidRefreshToken):
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": gin.H{
 				"code":    "INVALID_TOKEN",
@@ -225,10 +330,8 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 			},
 		})
 		return
-	}
-
-	tokens, err := h.AuthService.GenerateTokenPair(claims.UserID, claims.Email, claims.Role)
-	if err != nil {
+	case err != nil:
+		log.Printf("[Auth] Failed to refresh session: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "TOKEN_ERROR",
@@ -246,9 +349,13 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 	})
 }
 
-// Logout invalidates the user's session.
+// Logout revokes the session the request was made with.
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// In a production system, you would blacklist the refresh token in Redis
+	if sessionID := c.GetUint("session_id"); sessionID != 0 {
+		if err := services.RevokeSession(h.DB, sessionID, models.SessionRevokedLogout); err != nil {
+			log.Printf("[Auth] Failed to revoke session %d: %v", sessionID, err)
+		}
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logged out successfully",
 	})
@@ -272,7 +379,8 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	})
 }
 
-// ForgotPassword initiates a password reset.
+// ForgotPassword emails a single-use password reset link. The response is the
+// same whether or not the email belongs to an account.
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	var req forgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -285,16 +393,29 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
+	sent := gin.H{
+		"message": "If an account with that email exists, a password reset link has been sent",
+	}
+
 	var user models.User
-	if err := h.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
+	if err := h.DB.Where("email = ?", req.Email).First(&user).Error; err != nil || !user.Active {
 		// Return success even if email not found (security)
-		c.JSON(http.StatusOK, gin.H{
-			"message": "If an account with that email exists, a password reset link has been sent",
-		})
+		c.JSON(http.StatusOK, sent)
+		return
+	}
+
+	// One email a minute per account is plenty, and stops the form being
+	// used to flood an inbox.
+	var recent int64
+	h.DB.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND created_at > ?", user.ID, time.Now().Add(-time.Minute)).
+		Count(&recent)
+	if recent > 0 {
+		c.JSON(http.StatusOK, sent)
 		return
 	}
 
-	token, err := services.GenerateResetToken()
+	token, err := services.GenerateOpaqueToken()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
@@ -305,15 +426,40 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
-	// For Phase 1, just log the token (email integration comes in Phase 4)
-	log.Printf("Password reset token for %s: %s", user.Email, token)
+	// Only the newest link works; requesting another retires the old ones.
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.PasswordResetToken{
+			TenantID:  user.TenantID,
+			UserID:    user.ID,
+			TokenHash: services.HashToken(token),
+			ExpiresAt: time.Now().Add(models.PasswordResetTTL),
+		}).Error
+	})
+	if err != nil {
+		log.Printf("[Auth] Failed to store reset token for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to send a reset link right now. Please try again later.",
+			},
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "If an account with that email exists, a password reset link has been sent",
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(h.Config.OAuthFrontendURL, "/"), url.QueryEscape(token))
+	h.sendAuthEmail(user, "Reset your password", "password-reset", map[string]interface{}{
+		"ResetURL": resetURL,
+		"Expiry":   "1 hour",
 	})
+
+	c.JSON(http.StatusOK, sent)
 }
 
-// ResetPassword resets a user's password with a valid token.
+// ResetPassword sets a new password with a reset token. The token is consumed
+// and every token issued to the user before the reset stops working.
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req resetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -326,8 +472,6 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	// Phase 1: simplified reset (in production, validate the token against stored tokens)
-	// For now, this is a placeholder that demonstrates the API contract
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -338,13 +482,229 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		})
 		return
 	}
-	_ = hashedPassword
+
+	var user models.User
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		var reset models.PasswordResetToken
+		if err := tx.Where("token_hash = ?", services.HashToken(req.Token)).First(&reset).Error; err != nil {
+			return errInvalidResetToken
+		}
+
+		// Claim the token; of two concurrent requests only one gets a row.
+		now := time.Now()
+		claim := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", reset.ID, now).
+			Update("used_at", now)
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return errInvalidResetToken
+		}
+
+		if err := tx.First(&user, reset.UserID).Error; err != nil {
+			return errInvalidResetToken
+		}
+		if err := tx.Model(&user).Updates(map[string]interface{}{
+			"password":      string(hashedPassword),
+			"token_version": gorm.Expr("token_version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		_, err := services.RevokeUserSessions(tx, user.ID, models.SessionRevokedPasswordReset)
+		return err
+	})
+	if errors.Is(err, errInvalidResetToken) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TOKEN",
+				"message": "This reset link is invalid or has expired. Please request a new one.",
+			},
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("[Auth] Failed to reset password: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to reset your password right now. Please try again later.",
+			},
+		})
+		return
+	}
+
+	h.sendAuthEmail(user, "Your password was changed", "password-changed", map[string]interface{}{
+		"LoginURL": strings.TrimRight(h.Config.OAuthFrontendURL, "/") + "/login",
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password reset successfully. Please sign in with your new password.",
+	})
+}
+
+var errInvalidResetToken = errors.New("invalid or expired reset token")
+
+// sessionMeta describes the device a request came from.
+func sessionMeta(c *gin.Context) services.SessionMeta {
+	return services.SessionMeta{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// sendAuthEmail sends an account email in the background, so response
+// timing doesn't reveal whether an address has an account.
+func (h *AuthHandler) sendAuthEmail(user models.User, subject, template string, data map[string]interface{}) {
+	if h.Mailer == nil {
+		log.Printf("[Auth] Mail not configured, %q email to user %d not sent", template, user.ID)
+		return
+	}
+	data["AppName"] = models.GetSetting(h.DB, "site_name", "GritCMS")
+	data["Year"] = time.Now().Year()
+	data["Name"] = user.FirstName
+
+	go func() {
+		err := h.Mailer.Send(context.Background(), mail.SendOptions{
+			To:       user.Email,
+			Subject:  subject,
+			Template: template,
+			Data:     data,
+		})
+		if err != nil {
+			log.Printf("[Auth] Failed to send %q email to user %d: %v", template, user.ID, err)
+		}
+	}()
+}
+
+// VerifyEmail confirms a user's email address with the token from their
+// verification email.
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	user, err := h.AuthService.VerifyEmail(h.DB, req.Token)
+	if errors.Is(err, services.ErrInvalidVerificationToken) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TOKEN",
+				"message": "This verification link is invalid or has expired. Please request a new one.",
+			},
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("[Auth] Failed to verify email: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to verify your email right now. Please try again later.",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    user,
+		"message": "Email verified successfully",
+	})
+}
+
+// verificationResendInterval is how long a user must wait between
+// verification emails.
+const verificationResendInterval = time.Minute
+
+// ResendVerification sends the current user a fresh verification email.
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var user models.User
+	if err := h.DB.First(&user, c.GetUint("user_id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "User not found",
+			},
+		})
+		return
+	}
+
+	if user.EmailVerifiedAt != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "ALREADY_VERIFIED",
+				"message": "Your email address is already verified",
+			},
+		})
+		return
+	}
+
+	if user.VerifySentAt != nil {
+		if wait := time.Until(user.VerifySentAt.Add(verificationResendInterval)); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": gin.H{
+					"code":    "RATE_LIMITED",
+					"message": "A verification email was just sent. Please wait a minute before requesting another.",
+				},
+			})
+			return
+		}
+	}
+
+	h.sendVerificationEmail(user)
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Password reset successfully",
+		"message": "Verification email sent",
+	})
+}
+
+// sendVerificationEmail emails a user a link that verifies their address.
+func (h *AuthHandler) sendVerificationEmail(user models.User) {
+	token := h.AuthService.EmailVerificationToken(user.ID, user.Email)
+	h.DB.Model(&user).Update("verify_sent_at", time.Now())
+
+	verifyURL := fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(h.Config.OAuthFrontendURL, "/"), url.QueryEscape(token))
+	h.sendAuthEmail(user, "Verify your email address", "email-verification", map[string]interface{}{
+		"VerifyURL": verifyURL,
+		"Expiry":    "48 hours",
 	})
 }
 
+// providerVerifiedEmail reports whether an OAuth provider vouches for the
+// email it returned. Google says so per user; GitHub only hands out verified
+// addresses.
+func providerVerifiedEmail(provider string, gothUser goth.User) bool {
+	switch provider {
+	case "google":
+		for _, key := range []string{"verified_email", "email_verified"} {
+			switch v := gothUser.RawData[key].(type) {
+			case bool:
+				if v {
+					return true
+				}
+			case string:
+				if v == "true" {
+					return true
+				}
+			}
+		}
+		return false
+	case "github":
+		return gothUser.Email != ""
+	}
+	return false
+}
+
 // OAuthBegin redirects the user to the OAuth provider's consent screen.
 func (h *AuthHandler) OAuthBegin(c *gin.Context) {
 	provider := c.Param("provider")
@@ -373,6 +733,9 @@ func (h *AuthHandler) OAuthCallback(c *gin.Context) {
 		return
 	}
 
+	now := time.Now()
+	emailVerified := providerVerifiedEmail(provider, gothUser)
+
 	// Find or create user by email
 	var user models.User
 	result := h.DB.Where("email = ?", gothUser.Email).First(&user)
@@ -380,15 +743,16 @@ func (h *AuthHandler) OAuthCallback(c *gin.Context) {
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			// Create new user from OAuth data
-			now := time.Now()
 			user = models.User{
-				FirstName:       gothUser.FirstName,
-				LastName:        gothUser.LastName,
-				Email:           gothUser.Email,
-				Avatar:          gothUser.AvatarURL,
-				Provider:        provider,
-				Active:          true,
-				EmailVerifiedAt: &now,
+				FirstName: gothUser.FirstName,
+				LastName:  gothUser.LastName,
+				Email:     gothUser.Email,
+				Avatar:    gothUser.AvatarURL,
+				Provider:  provider,
+				Active:    true,
+			}
+			if emailVerified {
+				user.EmailVerifiedAt = &now
 			}
 
 			if provider == "google" {
@@ -434,6 +798,9 @@ func (h *AuthHandler) OAuthCallback(c *gin.Context) {
 		if user.Provider == "local" {
 			updates["provider"] = provider
 		}
+		if user.EmailVerifiedAt == nil && emailVerified {
+			updates["email_verified_at"] = now
+		}
 
 		if len(updates) > 0 {
 			h.DB.Model(&user).Updates(updates)
@@ -446,8 +813,16 @@ func (h *AuthHandler) OAuthCallback(c *gin.Context) {
 		return
 	}
 
-	// Generate JWT tokens
-	tokens, err := h.AuthService.GenerateTokenPair(user.ID, user.Email, user.Role)
+	// Users with 2FA on finish signing in with a code on the login page
+	if services.TwoFactorEnabled(h.DB, user.ID) {
+		challenge, _ := h.AuthService.TwoFactorChallenge(&user)
+		redirectURL := fmt.Sprintf("%s/login?challenge_token=%s", h.Config.OAuthFrontendURL, url.QueryEscape(challenge))
+		c.Redirect(http.StatusTemporaryRedirect, redirectURL)
+		return
+	}
+
+	// Sign the user in on this device
+	tokens, err := h.AuthService.StartSession(h.DB, &user, sessionMeta(c))
 	if err != nil {
 		log.Printf("OAuth: failed to generate tokens: %v", err)
 		redirectURL := fmt.Sprintf("%s/login?error=%s", h.Config.OAuthFrontendURL, url.QueryEscape("Failed to sign in."))
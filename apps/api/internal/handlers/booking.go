@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
@@ -9,29 +10,33 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"gritcms/apps/api/internal/config"
 	"gritcms/apps/api/internal/events"
 	"gritcms/apps/api/internal/integrations"
+	"gritcms/apps/api/internal/jobs"
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
 type BookingHandler struct {
 	DB       *gorm.DB
 	Meetings *integrations.MeetingService
+	Jobs     *jobs.Client
 	Cfg      *config.Config
 }
 
-func NewBookingHandler(db *gorm.DB, meetings *integrations.MeetingService, cfg *config.Config) *BookingHandler {
-	return &BookingHandler{DB: db, Meetings: meetings, Cfg: cfg}
+func NewBookingHandler(db *gorm.DB, meetings *integrations.MeetingService, jobClient *jobs.Client, cfg *config.Config) *BookingHandler {
+	return &BookingHandler{DB: db, Meetings: meetings, Jobs: jobClient, Cfg: cfg}
 }
 
 // ---------- Calendars ----------
 
 func (h *BookingHandler) ListCalendars(c *gin.Context) {
 	var calendars []models.Calendar
-	h.DB.Preload("EventTypes").Preload("Availabilities").
+	h.DB.Preload("EventTypes.Hosts").Preload("Availabilities").
 		Order("created_at DESC").Find(&calendars)
 	c.JSON(http.StatusOK, gin.H{"data": calendars})
 }
@@ -39,7 +44,8 @@ func (h *BookingHandler) ListCalendars(c *gin.Context) {
 func (h *BookingHandler) GetCalendar(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	var cal models.Calendar
-	if err := h.DB.Preload("EventTypes").Preload("Availabilities").
+	if err := h.DB.Preload("EventTypes.Hosts").Preload("Availabilities").
+		Preload("Overrides", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC, start_time ASC") }).
 		First(&cal, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar not found"})
 		return
@@ -79,7 +85,7 @@ func (h *BookingHandler) UpdateCalendar(c *gin.Context) {
 	}
 	sanitizeUpdates(body)
 	h.DB.Model(&cal).Updates(body)
-	h.DB.Preload("EventTypes").Preload("Availabilities").First(&cal, id)
+	h.DB.Preload("EventTypes.Hosts").Preload("Availabilities").First(&cal, id)
 	c.JSON(http.StatusOK, gin.H{"data": cal})
 }
 
@@ -96,22 +102,41 @@ func (h *BookingHandler) DeleteCalendar(c *gin.Context) {
 
 func (h *BookingHandler) CreateEventType(c *gin.Context) {
 	calID, _ := strconv.Atoi(c.Param("id"))
-	var body models.BookingEventType
+	var body struct {
+		models.BookingEventType
+		HostCalendarIDs []uint `json:"host_calendar_ids"`
+	}
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if body.AssignmentStrategy == "" {
+		body.AssignmentStrategy = models.AssignRoundRobin
+	}
+	if !validAssignmentStrategy(body.AssignmentStrategy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "assignment_strategy must be round_robin or least_booked"})
+		return
+	}
 	body.TenantID = 1
 	body.CalendarID = uint(calID)
 	body.Slug = generateEventTypeSlug(h.DB, body.Name)
 	if body.DurationMinutes == 0 {
 		body.DurationMinutes = 30
 	}
-	if err := h.DB.Create(&body).Error; err != nil {
+	if body.ReminderOffsets == nil {
+		body.ReminderOffsets = datatypes.JSON([]byte("[1440,60]"))
+	}
+	et := body.BookingEventType
+	et.Hosts = nil
+	if err := h.DB.Create(&et).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create event type"})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"data": body})
+	if err := h.setEventTypeHosts(&et, body.HostCalendarIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save hosts"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": et})
 }
 
 func (h *BookingHandler) UpdateEventType(c *gin.Context) {
@@ -127,11 +152,71 @@ func (h *BookingHandler) UpdateEventType(c *gin.Context) {
 		return
 	}
 	sanitizeUpdates(body)
+	if offsets, ok := body["reminder_offsets"]; ok {
+		var minutes []int
+		raw, _ := json.Marshal(offsets)
+		if offsets != nil && json.Unmarshal(raw, &minutes) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "reminder_offsets must be a list of minutes"})
+			return
+		}
+		if minutes == nil {
+			minutes = []int{}
+		}
+		raw, _ = json.Marshal(minutes)
+		body["reminder_offsets"] = datatypes.JSON(raw)
+	}
+	if strategy, ok := body["assignment_strategy"]; ok {
+		if s, _ := strategy.(string); !validAssignmentStrategy(s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "assignment_strategy must be round_robin or least_booked"})
+			return
+		}
+	}
+	var hostIDs []uint
+	rawHosts, setHosts := body["host_calendar_ids"]
+	if setHosts {
+		raw, _ := json.Marshal(rawHosts)
+		if rawHosts != nil && json.Unmarshal(raw, &hostIDs) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "host_calendar_ids must be a list of calendar IDs"})
+			return
+		}
+		delete(body, "host_calendar_ids")
+	}
+	delete(body, "hosts")
 	h.DB.Model(&et).Updates(body)
-	h.DB.First(&et, etID)
+	if setHosts {
+		if err := h.setEventTypeHosts(&et, hostIDs); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save hosts"})
+			return
+		}
+	}
+	h.DB.Preload("Hosts").First(&et, etID)
 	c.JSON(http.StatusOK, gin.H{"data": et})
 }
 
+// setEventTypeHosts replaces the calendars pooled into an event type. The
+// event type's own calendar is always a host, so it isn't stored.
+func (h *BookingHandler) setEventTypeHosts(et *models.BookingEventType, calendarIDs []uint) error {
+	var hosts []models.Calendar
+	ids := make([]uint, 0, len(calendarIDs))
+	for _, id := range calendarIDs {
+		if id != et.CalendarID {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		h.DB.Where("id IN ?", ids).Find(&hosts)
+	}
+	if err := h.DB.Model(et).Association("Hosts").Replace(hosts); err != nil {
+		return err
+	}
+	et.Hosts = hosts
+	return nil
+}
+
+func validAssignmentStrategy(s string) bool {
+	return s == models.AssignRoundRobin || s == models.AssignLeastBooked
+}
+
 func (h *BookingHandler) DeleteEventType(c *gin.Context) {
 	etID, _ := strconv.Atoi(c.Param("etId"))
 	if err := h.DB.Delete(&models.BookingEventType{}, etID).Error; err != nil {
@@ -163,6 +248,68 @@ func (h *BookingHandler) SetAvailability(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": body})
 }
 
+// ListOverrides lists a calendar's date overrides. Pass ?upcoming=true to
+// skip past dates.
+func (h *BookingHandler) ListOverrides(c *gin.Context) {
+	calID, _ := strconv.Atoi(c.Param("id"))
+	q := h.DB.Where("calendar_id = ?", calID)
+	if c.Query("upcoming") == "true" {
+		q = q.Where("date >= ?", time.Now().Format("2006-01-02"))
+	}
+	var overrides []models.AvailabilityOverride
+	q.Order("date ASC, start_time ASC").Find(&overrides)
+	c.JSON(http.StatusOK, gin.H{"data": overrides})
+}
+
+// CreateOverride adds a date override to a calendar. Leave start_time and
+// end_time empty to block the whole day.
+func (h *BookingHandler) CreateOverride(c *gin.Context) {
+	calID, _ := strconv.Atoi(c.Param("id"))
+	var cal models.Calendar
+	if err := h.DB.First(&cal, calID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar not found"})
+		return
+	}
+	var body models.AvailabilityOverride
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := time.Parse("2006-01-02", body.Date); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
+		return
+	}
+	if (body.StartTime == "") != (body.EndTime == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Set both start_time and end_time, or neither to block the day"})
+		return
+	}
+	if !body.Blocked() {
+		startH, startM := parseTime(body.StartTime)
+		endH, endM := parseTime(body.EndTime)
+		if endH*60+endM <= startH*60+startM {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+			return
+		}
+	}
+	body.ID = 0
+	body.TenantID = 1
+	body.CalendarID = cal.ID
+	if err := h.DB.Create(&body).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create override"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": body})
+}
+
+func (h *BookingHandler) DeleteOverride(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("overrideId"))
+	if err := h.DB.Delete(&models.AvailabilityOverride{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete override"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Override deleted"})
+}
+
 // ---------- Appointments ----------
 
 func (h *BookingHandler) ListAppointments(c *gin.Context) {
@@ -181,6 +328,9 @@ func (h *BookingHandler) ListAppointments(c *gin.Context) {
 	if etID := c.Query("event_type_id"); etID != "" {
 		q = q.Where("event_type_id = ?", etID)
 	}
+	if calID, _ := strconv.Atoi(c.Query("calendar_id")); calID > 0 {
+		q = q.Scopes(models.HostAppointments(uint(calID)))
+	}
 	if upcoming := c.Query("upcoming"); upcoming == "true" {
 		q = q.Where("start_at > ?", time.Now())
 	}
@@ -188,7 +338,7 @@ func (h *BookingHandler) ListAppointments(c *gin.Context) {
 	q.Count(&total)
 
 	var appointments []models.Appointment
-	q.Preload("EventType").Preload("Contact").
+	q.Preload("EventType").Preload("Contact").Preload("Calendar").
 		Order("start_at ASC").
 		Offset((page - 1) * pageSize).
 		Limit(pageSize).
@@ -206,22 +356,67 @@ func (h *BookingHandler) ListAppointments(c *gin.Context) {
 func (h *BookingHandler) GetAppointment(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("appointmentId"))
 	var appt models.Appointment
-	if err := h.DB.Preload("EventType").Preload("Contact").
+	if err := h.DB.Preload("EventType").Preload("Contact").Preload("Calendar").
 		First(&appt, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": appt})
+	var reminders []models.AppointmentReminder
+	h.DB.Where("appointment_id = ?", appt.ID).Order("send_at ASC").Find(&reminders)
+	c.JSON(http.StatusOK, gin.H{"data": appt, "reminders": reminders})
 }
 
+// CancelAppointment cancels an appointment. A paid appointment is refunded
+// when cancelled inside its event type's refund window; pass {"refund": bool}
+// to override that.
 func (h *BookingHandler) CancelAppointment(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("appointmentId"))
 	var appt models.Appointment
-	if err := h.DB.First(&appt, id).Error; err != nil {
+	if err := h.DB.Preload("EventType").First(&appt, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
 		return
 	}
-	h.DB.Model(&appt).Update("status", models.AppointmentCancelled)
+	var body struct {
+		Refund *bool `json:"refund"`
+	}
+	_ = c.ShouldBindJSON(&body) // the body is optional
+
+	refunded := false
+	if appt.OrderID != nil {
+		var order models.Order
+		if err := h.DB.First(&order, *appt.OrderID).Error; err == nil && order.Status == models.OrderStatusPaid {
+			refund := appt.EventType != nil &&
+				time.Until(appt.StartAt) >= time.Duration(appt.EventType.RefundWindowHours)*time.Hour
+			if body.Refund != nil {
+				refund = *body.Refund
+			}
+			if refund {
+				if err := services.RefundBookingOrder(h.DB, &order); err != nil {
+					log.Printf("[booking] Failed to refund order %d: %v", order.ID, err)
+					c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to refund the payment; the appointment was not cancelled"})
+					return
+				}
+				refunded = true
+			}
+		}
+	}
+
+	wasPending := appt.Status == models.AppointmentPending
+	h.DB.Model(&appt).Updates(map[string]interface{}{
+		"status":   models.AppointmentCancelled,
+		"sequence": gorm.Expr("sequence + 1"),
+	})
+	appt.Status = models.AppointmentCancelled
+	jobs.CancelAppointmentReminders(h.DB, h.Jobs, appt.ID, "")
+	if wasPending {
+		// Never confirmed, so there's no invite to withdraw
+		if appt.OrderID != nil {
+			h.DB.Model(&models.Order{}).Where("id = ? AND status = ?", *appt.OrderID, models.OrderStatusPending).
+				Update("status", models.OrderStatusFailed)
+		}
+	} else {
+		h.sendBookingEmail(appt.ID, jobs.BookingEmailCancelled)
+	}
 
 	// Cancel external meetings (non-blocking)
 	if h.Meetings != nil {
@@ -234,7 +429,7 @@ func (h *BookingHandler) CancelAppointment(c *gin.Context) {
 		"appointment_id": appt.ID, "contact_id": appt.ContactID,
 	})
 
-	c.JSON(http.StatusOK, gin.H{"data": appt})
+	c.JSON(http.StatusOK, gin.H{"data": appt, "refunded": refunded})
 }
 
 func (h *BookingHandler) CompleteAppointment(c *gin.Context) {
@@ -245,6 +440,14 @@ func (h *BookingHandler) CompleteAppointment(c *gin.Context) {
 		return
 	}
 	h.DB.Model(&appt).Update("status", models.AppointmentCompleted)
+	jobs.CancelAppointmentReminders(h.DB, h.Jobs, appt.ID, models.ReminderKindReminder)
+
+	var et models.BookingEventType
+	if h.DB.First(&et, appt.EventTypeID).Error == nil {
+		if err := jobs.ScheduleAppointmentFollowUp(h.DB, h.Jobs, &appt, &et); err != nil {
+			log.Printf("[booking] Failed to schedule follow-up for appointment %d: %v", appt.ID, err)
+		}
+	}
 	c.JSON(http.StatusOK, gin.H{"data": appt})
 }
 
@@ -255,6 +458,10 @@ func (h *BookingHandler) RescheduleAppointment(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
 		return
 	}
+	if appt.Status == models.AppointmentPending {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Appointment is awaiting payment"})
+		return
+	}
 	var body struct {
 		StartAt string `json:"start_at"`
 	}
@@ -277,6 +484,7 @@ func (h *BookingHandler) RescheduleAppointment(c *gin.Context) {
 		"start_at": newStart,
 		"end_at":   newEnd,
 		"status":   models.AppointmentConfirmed,
+		"sequence": gorm.Expr("sequence + 1"),
 	})
 	appt.StartAt = newStart
 	appt.EndAt = newEnd
@@ -289,6 +497,9 @@ func (h *BookingHandler) RescheduleAppointment(c *gin.Context) {
 		}
 	}
 
+	h.scheduleReminders(&appt, &et)
+	h.sendBookingEmail(appt.ID, jobs.BookingEmailRescheduled)
+
 	events.Emit(events.BookingRescheduled, map[string]interface{}{
 		"appointment_id": appt.ID, "contact_id": appt.ContactID,
 	})
@@ -324,101 +535,32 @@ func (h *BookingHandler) GetPublicEventType(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": et})
 }
 
+// GetAvailableSlots lists the free start times on a date, in the event
+// type's calendar timezone, across all of its hosts.
 func (h *BookingHandler) GetAvailableSlots(c *gin.Context) {
 	slug := c.Param("slug")
 	dateStr := c.Query("date") // YYYY-MM-DD
 
 	var et models.BookingEventType
-	if err := h.DB.Preload("Calendar.Availabilities").Where("slug = ?", slug).First(&et).Error; err != nil {
+	if err := h.DB.Preload("Calendar").Where("slug = ?", slug).First(&et).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Event type not found"})
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
-		return
-	}
-
-	// Load timezone
-	tz := "UTC"
-	if et.Calendar != nil && et.Calendar.Timezone != "" {
-		tz = et.Calendar.Timezone
-	}
-	loc, err := time.LoadLocation(tz)
-	if err != nil {
-		loc = time.UTC
-	}
-
-	// Find availability for this day of week
-	dayOfWeek := int(date.Weekday())
-	var avails []models.Availability
+	loc := time.UTC
 	if et.Calendar != nil {
-		for _, a := range et.Calendar.Availabilities {
-			if a.DayOfWeek == dayOfWeek {
-				avails = append(avails, a)
-			}
-		}
-	}
-
-	if len(avails) == 0 {
-		c.JSON(http.StatusOK, gin.H{"data": []string{}})
-		return
+		loc = calendarLocation(*et.Calendar)
 	}
-
-	// Get existing appointments for this date
-	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
-	dayEnd := dayStart.Add(24 * time.Hour)
-	var existing []models.Appointment
-	h.DB.Where("event_type_id = ? AND start_at >= ? AND start_at < ? AND status != ?",
-		et.ID, dayStart, dayEnd, models.AppointmentCancelled).Find(&existing)
-
-	// Check max per day
-	if et.MaxPerDay > 0 && len(existing) >= et.MaxPerDay {
-		c.JSON(http.StatusOK, gin.H{"data": []string{}})
+	date, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
 		return
 	}
 
-	// Generate slots
-	duration := time.Duration(et.DurationMinutes) * time.Minute
-	bufferBefore := time.Duration(et.BufferBefore) * time.Minute
-	bufferAfter := time.Duration(et.BufferAfter) * time.Minute
-
-	var slots []string
-	for _, avail := range avails {
-		startH, startM := parseTime(avail.StartTime)
-		endH, endM := parseTime(avail.EndTime)
-
-		slotStart := time.Date(date.Year(), date.Month(), date.Day(), startH, startM, 0, 0, loc)
-		windowEnd := time.Date(date.Year(), date.Month(), date.Day(), endH, endM, 0, 0, loc)
-
-		for slotStart.Add(duration).Before(windowEnd) || slotStart.Add(duration).Equal(windowEnd) {
-			slotEnd := slotStart.Add(duration)
-			blocked := false
-
-			// Check slot doesn't overlap with existing appointments (including buffers)
-			for _, appt := range existing {
-				apptStart := appt.StartAt.Add(-bufferBefore)
-				apptEnd := appt.EndAt.Add(bufferAfter)
-				if slotStart.Before(apptEnd) && slotEnd.After(apptStart) {
-					blocked = true
-					break
-				}
-			}
-
-			// Don't offer slots in the past
-			if slotStart.Before(time.Now()) {
-				blocked = true
-			}
-
-			if !blocked {
-				slots = append(slots, slotStart.Format(time.RFC3339))
-			}
-
-			slotStart = slotStart.Add(duration + bufferAfter)
-		}
+	slots := []string{}
+	for _, s := range h.availableSlots(&et, date, date.AddDate(0, 0, 1)) {
+		slots = append(slots, s.In(loc).Format(time.RFC3339))
 	}
-
 	c.JSON(http.StatusOK, gin.H{"data": slots})
 }
 
@@ -456,6 +598,20 @@ func (h *BookingHandler) BookAppointment(c *gin.Context) {
 	}
 	endAt := startAt.Add(time.Duration(et.DurationMinutes) * time.Minute)
 
+	// Refuse the slot unless a host is still free for it, then assign one
+	free := h.freeHosts(&et, startAt)
+	if len(free) == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "That time is no longer available"})
+		return
+	}
+	host := h.pickHost(&et, free)
+
+	paid := et.Price > 0
+	if paid && h.Cfg.StripeSecretKey == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Payments not configured"})
+		return
+	}
+
 	// Upsert contact
 	firstName, lastName := splitName(body.Name)
 	var contact models.Contact
@@ -481,33 +637,76 @@ func (h *BookingHandler) BookAppointment(c *gin.Context) {
 		EndAt:       endAt,
 		Status:      models.AppointmentConfirmed,
 		Notes:       body.Notes,
-	}
-	if err := h.DB.Create(&appt).Error; err != nil {
+		CalendarID:  &host.ID,
+	}
+	if paid {
+		// Hold the slot until the client pays through /api/checkout
+		holdUntil := time.Now().Add(models.AppointmentHoldDuration)
+		appt.Status = models.AppointmentPending
+		appt.HoldExpiresAt = &holdUntil
+	}
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if paid {
+			order := models.Order{
+				TenantID:        1,
+				ContactID:       contact.ID,
+				OrderNumber:     generateOrderNumber(),
+				Status:          models.OrderStatusPending,
+				Subtotal:        float64(et.Price),
+				Total:           float64(et.Price),
+				Currency:        "USD",
+				PaymentProvider: "stripe",
+				Items: []models.OrderItem{{
+					TenantID:  1,
+					ProductID: et.ProductID,
+					Quantity:  1,
+					UnitPrice: float64(et.Price),
+					Total:     float64(et.Price),
+				}},
+			}
+			if err := tx.Create(&order).Error; err != nil {
+				return err
+			}
+			appt.OrderID = &order.ID
+		}
+		return tx.Create(&appt).Error
+	})
+	if err != nil {
 		log.Printf("[booking] Failed to create appointment: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to book appointment: " + err.Error()})
 		return
 	}
 
-	// Create external meetings (non-blocking — never fail the booking)
-	if h.Meetings != nil {
-		meetingURL, mErr := h.Meetings.CreateMeetingForAppointment(&appt, et, contact)
-		if mErr != nil {
-			log.Printf("[booking] Failed to create meeting: %v", mErr)
-		} else if meetingURL != "" {
-			h.DB.Model(&appt).Updates(map[string]interface{}{
-				"meeting_url":     meetingURL,
-				"google_event_id": appt.GoogleEventID,
-				"zoom_meeting_id": appt.ZoomMeetingID,
-			})
-			appt.MeetingURL = meetingURL
-		}
+	if paid {
+		c.JSON(http.StatusCreated, gin.H{"data": appt, "requires_payment": true})
+		return
 	}
 
-	events.Emit(events.BookingConfirmed, map[string]interface{}{
-		"appointment_id": appt.ID, "contact_id": contact.ID, "event_type": et.Name,
-	})
+	services.ConfirmAppointment(h.DB, h.Meetings, h.Jobs, &appt, et, contact)
+	c.JSON(http.StatusCreated, gin.H{"data": appt, "requires_payment": false})
+}
 
-	c.JSON(http.StatusCreated, gin.H{"data": appt})
+// scheduleReminders queues the event type's reminders for an appointment,
+// replacing any already scheduled. Failures are logged, never returned.
+func (h *BookingHandler) scheduleReminders(appt *models.Appointment, et *models.BookingEventType) {
+	if h.Jobs == nil {
+		return
+	}
+	if err := jobs.ScheduleAppointmentReminders(h.DB, h.Jobs, appt, et); err != nil {
+		log.Printf("[booking] Failed to schedule reminders for appointment %d: %v", appt.ID, err)
+	}
+}
+
+// sendBookingEmail queues a confirmation, reschedule or cancellation email
+// with the .ics invite.
+func (h *BookingHandler) sendBookingEmail(appointmentID uint, kind string) {
+	if h.Jobs == nil {
+		return
+	}
+	if err := h.Jobs.EnqueueBookingEmail(appointmentID, kind); err != nil {
+		log.Printf("[booking] Failed to queue %s email for appointment %d: %v", kind, appointmentID, err)
+	}
 }
 
 // ---------- Integration Endpoints ----------
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"gritcms/apps/api/internal/models"
+)
+
+// timeSpan is a half-open [Start, End) interval.
+type timeSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (s timeSpan) overlaps(start, end time.Time) bool {
+	return start.Before(s.End) && end.After(s.Start)
+}
+
+// eventTypeHosts returns the active calendars an event type can be booked
+// on — its own calendar and any pooled hosts — ordered by ID, with their
+// weekly hours and upcoming date overrides loaded.
+func (h *BookingHandler) eventTypeHosts(et *models.BookingEventType) []models.Calendar {
+	ids := []uint{et.CalendarID}
+	var hostIDs []uint
+	h.DB.Table("booking_event_type_hosts").
+		Where("booking_event_type_id = ?", et.ID).
+		Pluck("calendar_id", &hostIDs)
+	ids = append(ids, hostIDs...)
+
+	var hosts []models.Calendar
+	h.DB.Preload("Availabilities").
+		Preload("Overrides", "date >= ?", time.Now().AddDate(0, 0, -2).Format("2006-01-02")).
+		Where("id IN ? AND status = ?", ids, models.CalendarStatusActive).
+		Order("id ASC").
+		Find(&hosts)
+	return hosts
+}
+
+// hostSlots lists the start times in [from, to) at which the event type can
+// be booked on a host. It honours the host's weekly hours and date
+// overrides, its existing appointments and Google busy times (padded by the
+// event type's buffers), the event type's notice and horizon limits, and its
+// per-day cap on this host.
+func (h *BookingHandler) hostSlots(et *models.BookingEventType, host models.Calendar, from, to time.Time) []time.Time {
+	loc := calendarLocation(host)
+	duration := time.Duration(et.DurationMinutes) * time.Minute
+	bufferBefore := time.Duration(et.BufferBefore) * time.Minute
+	bufferAfter := time.Duration(et.BufferAfter) * time.Minute
+	if duration <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	earliest := now.Add(time.Duration(et.MinNoticeMinutes) * time.Minute)
+	var latest time.Time
+	if et.MaxAdvanceDays > 0 {
+		latest = now.AddDate(0, 0, et.MaxAdvanceDays)
+	}
+	if !to.After(earliest) || (!latest.IsZero() && from.After(latest)) {
+		return nil
+	}
+
+	// Look a day either side so host-local days that straddle the range,
+	// and appointments counted against them, are covered.
+	rangeStart := from.Add(-24 * time.Hour)
+	rangeEnd := to.Add(24 * time.Hour)
+
+	var existing []models.Appointment
+	h.DB.Scopes(models.SlotTakingAppointments, models.HostAppointments(host.ID)).
+		Where("start_at < ? AND end_at > ?", rangeEnd, rangeStart).
+		Find(&existing)
+
+	var busy []timeSpan
+	perDay := map[string]int{}
+	for _, appt := range existing {
+		busy = append(busy, timeSpan{appt.StartAt.Add(-bufferBefore), appt.EndAt.Add(bufferAfter)})
+		if appt.EventTypeID == et.ID {
+			perDay[appt.StartAt.In(loc).Format("2006-01-02")]++
+		}
+	}
+	if h.Meetings != nil && h.Meetings.Google.IsConnected() {
+		periods, err := h.Meetings.Google.BusyTimes(host.GoogleCalendarID, rangeStart, rangeEnd)
+		if err != nil {
+			// Fail open: a Google outage shouldn't take booking down.
+			log.Printf("[booking] Failed to load Google busy times for calendar %d: %v", host.ID, err)
+		}
+		for _, p := range periods {
+			busy = append(busy, timeSpan{p.Start.Add(-bufferBefore), p.End.Add(bufferAfter)})
+		}
+	}
+
+	var slots []time.Time
+	first := dateIn(from.In(loc).AddDate(0, 0, -1))
+	last := dateIn(to.In(loc))
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		dayKey := day.Format("2006-01-02")
+		for _, window := range hostWindows(host, day, loc) {
+			for start := window.Start; !start.Add(duration).After(window.End); start = start.Add(duration + bufferAfter) {
+				end := start.Add(duration)
+				if start.Before(from) || !start.Before(to) || start.Before(earliest) {
+					continue
+				}
+				if !latest.IsZero() && start.After(latest) {
+					continue
+				}
+				if et.MaxPerDay > 0 && perDay[dayKey] >= et.MaxPerDay {
+					continue
+				}
+				free := true
+				for _, b := range busy {
+					if b.overlaps(start, end) {
+						free = false
+						break
+					}
+				}
+				if free {
+					slots = append(slots, start)
+				}
+			}
+		}
+	}
+	return slots
+}
+
+// hostWindows returns a host's bookable hours on a local date. Overrides for
+// the date replace the weekly hours; a blocking override leaves none.
+func hostWindows(host models.Calendar, day time.Time, loc *time.Location) []timeSpan {
+	at := func(hhmm string) time.Time {
+		hour, minute := parseTime(hhmm)
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+	}
+
+	dayKey := day.Format("2006-01-02")
+	var windows []timeSpan
+	overridden := false
+	for _, o := range host.Overrides {
+		if o.Date != dayKey {
+			continue
+		}
+		if o.Blocked() {
+			return nil
+		}
+		overridden = true
+		windows = append(windows, timeSpan{at(o.StartTime), at(o.EndTime)})
+	}
+	if overridden {
+		return windows
+	}
+
+	for _, a := range host.Availabilities {
+		if a.DayOfWeek == int(day.Weekday()) {
+			windows = append(windows, timeSpan{at(a.StartTime), at(a.EndTime)})
+		}
+	}
+	return windows
+}
+
+// freeHosts returns the hosts that can take a booking starting at startAt.
+func (h *BookingHandler) freeHosts(et *models.BookingEventType, startAt time.Time) []models.Calendar {
+	var free []models.Calendar
+	for _, host := range h.eventTypeHosts(et) {
+		slots := h.hostSlots(et, host, startAt, startAt.Add(time.Minute))
+		if len(slots) > 0 && slots[0].Equal(startAt) {
+			free = append(free, host)
+		}
+	}
+	return free
+}
+
+// pickHost chooses which free host takes a booking. Round robin picks the
+// host this event type was assigned to least recently; least booked picks
+// the host with the fewest upcoming appointments. Ties go to the lowest ID.
+func (h *BookingHandler) pickHost(et *models.BookingEventType, free []models.Calendar) models.Calendar {
+	best := free[0]
+	if len(free) == 1 {
+		return best
+	}
+
+	switch et.AssignmentStrategy {
+	case models.AssignLeastBooked:
+		var bestCount int64 = -1
+		for _, host := range free {
+			var count int64
+			h.DB.Model(&models.Appointment{}).
+				Scopes(models.SlotTakingAppointments, models.HostAppointments(host.ID)).
+				Where("start_at > ?", time.Now()).
+				Count(&count)
+			if bestCount < 0 || count < bestCount {
+				best, bestCount = host, count
+			}
+		}
+	default:
+		var bestLast time.Time
+		for i, host := range free {
+			var last models.Appointment
+			var lastAt time.Time
+			if err := h.DB.Scopes(models.SlotTakingAppointments, models.HostAppointments(host.ID)).
+				Where("event_type_id = ?", et.ID).
+				Order("created_at DESC").
+				First(&last).Error; err == nil {
+				lastAt = last.CreatedAt
+			}
+			if i == 0 || lastAt.Before(bestLast) {
+				best, bestLast = host, lastAt
+			}
+		}
+	}
+	return best
+}
+
+// availableSlots merges the free slots of all of an event type's hosts in
+// [from, to), earliest first.
+func (h *BookingHandler) availableSlots(et *models.BookingEventType, from, to time.Time) []time.Time {
+	seen := map[int64]bool{}
+	var slots []time.Time
+	for _, host := range h.eventTypeHosts(et) {
+		for _, s := range h.hostSlots(et, host, from, to) {
+			if !seen[s.Unix()] {
+				seen[s.Unix()] = true
+				slots = append(slots, s)
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Before(slots[j]) })
+	return slots
+}
+
+func calendarLocation(cal models.Calendar) *time.Location {
+	if cal.Timezone != "" {
+		if loc, err := time.LoadLocation(cal.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// dateIn truncates t to midnight in its own location.
+func dateIn(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
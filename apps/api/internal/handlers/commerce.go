@@ -204,6 +204,15 @@ func (h *CommerceHandler) UpdatePrice(c *gin.Context) {
 	}
 	sanitizeUpdates(input)
 
+	// Stripe prices are immutable: a new one is created on the next checkout.
+	delete(input, "stripe_price_id")
+	for _, key := range []string{"amount", "currency", "interval"} {
+		if _, ok := input[key]; ok {
+			input["stripe_price_id"] = ""
+			break
+		}
+	}
+
 	if err := h.db.Model(&price).Updates(input).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update price"})
 		return
@@ -729,6 +738,13 @@ func (h *CommerceHandler) CancelSubscription(c *gin.Context) {
 		return
 	}
 
+	if sub.PaymentProvider == "stripe" && sub.ProviderSubscriptionID != "" {
+		if err := cancelStripeSubscription(sub.ProviderSubscriptionID, input.Immediately); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel subscription with Stripe"})
+			return
+		}
+	}
+
 	now := time.Now()
 	if input.Immediately {
 		sub.Status = models.SubscriptionCancelled
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"math"
 	"net/http"
 	"strconv"
@@ -8,8 +9,10 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
+	"gritcms/apps/api/internal/events"
 	"gritcms/apps/api/internal/models"
 )
 
@@ -80,6 +83,11 @@ func (h *CommunityHandler) UpdateSpace(c *gin.Context) {
 		return
 	}
 	sanitizeUpdates(input)
+	if val, ok := input["blocked_keywords"]; ok && val != nil {
+		if b, err := json.Marshal(val); err == nil {
+			input["blocked_keywords"] = datatypes.JSON(b)
+		}
+	}
 	h.db.Model(&space).Updates(input)
 	h.db.First(&space, id)
 	c.JSON(http.StatusOK, gin.H{"data": space})
@@ -252,6 +260,7 @@ func (h *CommunityHandler) CreateThread(c *gin.Context) {
 		return
 	}
 	h.db.Preload("Author").First(&thread, thread.ID)
+	events.Emit(events.CommunityThreadCreated, thread)
 	c.JSON(http.StatusCreated, gin.H{"data": thread})
 }
 
@@ -332,6 +341,7 @@ func (h *CommunityHandler) CreateReply(c *gin.Context) {
 		"last_activity_at": time.Now(),
 	})
 	h.db.Preload("Author").First(&reply, reply.ID)
+	events.Emit(events.CommunityReplyCreated, reply)
 	c.JSON(http.StatusCreated, gin.H{"data": reply})
 }
 
@@ -379,42 +389,52 @@ func (h *CommunityHandler) ToggleReaction(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if input.Type == "" {
-		input.Type = "like"
+	action, reaction := h.toggleReaction(input.ReactableType, input.ReactableID, input.ContactID, input.Type)
+	if action == "removed" {
+		c.JSON(http.StatusOK, gin.H{"action": action})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"action": action, "data": reaction})
+}
+
+// toggleReaction adds the contact's reaction to a thread or reply, or removes
+// it if already present, keeping the like count in step.
+func (h *CommunityHandler) toggleReaction(reactableType string, reactableID, contactID uint, reactionType string) (string, *models.Reaction) {
+	if reactionType == "" {
+		reactionType = "like"
 	}
 
 	var existing models.Reaction
 	result := h.db.Where("reactable_type = ? AND reactable_id = ? AND contact_id = ? AND type = ?",
-		input.ReactableType, input.ReactableID, input.ContactID, input.Type).First(&existing)
+		reactableType, reactableID, contactID, reactionType).First(&existing)
 
 	if result.Error == nil {
 		// Remove reaction
 		h.db.Delete(&existing)
 		// Decrement like count
-		if input.ReactableType == "thread" {
-			h.db.Model(&models.Thread{}).Where("id = ?", input.ReactableID).UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)"))
+		if reactableType == "thread" {
+			h.db.Model(&models.Thread{}).Where("id = ?", reactableID).UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)"))
 		} else {
-			h.db.Model(&models.Reply{}).Where("id = ?", input.ReactableID).UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)"))
+			h.db.Model(&models.Reply{}).Where("id = ?", reactableID).UpdateColumn("like_count", gorm.Expr("GREATEST(like_count - 1, 0)"))
 		}
-		c.JSON(http.StatusOK, gin.H{"action": "removed"})
-		return
+		return "removed", nil
 	}
 
 	// Add reaction
 	reaction := models.Reaction{
 		TenantID:      1,
-		ReactableType: input.ReactableType,
-		ReactableID:   input.ReactableID,
-		ContactID:     input.ContactID,
-		Type:          input.Type,
+		ReactableType: reactableType,
+		ReactableID:   reactableID,
+		ContactID:     contactID,
+		Type:          reactionType,
 	}
 	h.db.Create(&reaction)
-	if input.ReactableType == "thread" {
-		h.db.Model(&models.Thread{}).Where("id = ?", input.ReactableID).UpdateColumn("like_count", gorm.Expr("like_count + 1"))
+	if reactableType == "thread" {
+		h.db.Model(&models.Thread{}).Where("id = ?", reactableID).UpdateColumn("like_count", gorm.Expr("like_count + 1"))
 	} else {
-		h.db.Model(&models.Reply{}).Where("id = ?", input.ReactableID).UpdateColumn("like_count", gorm.Expr("like_count + 1"))
+		h.db.Model(&models.Reply{}).Where("id = ?", reactableID).UpdateColumn("like_count", gorm.Expr("like_count + 1"))
 	}
-	c.JSON(http.StatusCreated, gin.H{"action": "added", "data": reaction})
+	return "added", &reaction
 }
 
 // ===================== EVENTS =====================
@@ -0,0 +1,852 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// ===================== MEMBER ENDPOINTS =====================
+//
+// Member-facing routes under /api/student/community. The authenticated user
+// acts as their Contact, and the space Type decides who may read and take
+// part: anyone can read public spaces, private spaces are invite-only, and
+// paid spaces require owning the space's product.
+
+// spaceAccess is what a contact may do in a space.
+type spaceAccess struct {
+	member  *models.CommunityMember
+	canRead bool
+	canPost bool
+	isStaff bool // space admin or moderator
+	banned  bool
+}
+
+// memberContact resolves the authenticated user to their contact. With create
+// set, a contact is created (or linked to the user) when none exists yet.
+func (h *CommunityHandler) memberContact(c *gin.Context, create bool) *models.Contact {
+	user, _ := c.Get("user")
+	u := user.(models.User)
+
+	var contact models.Contact
+	if err := h.db.Where("email = ? AND tenant_id = ?", u.Email, 1).First(&contact).Error; err != nil {
+		if !create {
+			return nil
+		}
+		contact = models.Contact{
+			TenantID:  1,
+			Email:     u.Email,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Source:    "organic",
+			UserID:    &u.ID,
+		}
+		if err := h.db.Create(&contact).Error; err != nil {
+			return nil
+		}
+	} else if contact.UserID == nil {
+		contact.UserID = &u.ID
+		h.db.Save(&contact)
+	}
+	return &contact
+}
+
+// spaceAccessFor works out what the contact may do in the space.
+func (h *CommunityHandler) spaceAccessFor(space *models.Space, contact *models.Contact) spaceAccess {
+	var access spaceAccess
+	if contact != nil {
+		var member models.CommunityMember
+		if err := h.db.Where("space_id = ? AND contact_id = ?", space.ID, contact.ID).First(&member).Error; err == nil {
+			access.member = &member
+			access.banned = member.BannedAt != nil
+			access.isStaff = !access.banned && (member.Role == models.MemberRoleAdmin || member.Role == models.MemberRoleModerator)
+		}
+	}
+
+	switch space.Type {
+	case models.SpaceTypePrivate:
+		access.canRead = access.member != nil && !access.banned
+	case models.SpaceTypePaid:
+		access.canRead = access.member != nil && !access.banned &&
+			(access.isStaff || space.ProductID == nil || models.OwnsProduct(h.db, contact.ID, *space.ProductID))
+	default:
+		access.canRead = true
+	}
+
+	muted := access.member != nil && access.member.MutedUntil != nil && access.member.MutedUntil.After(time.Now())
+	access.canPost = access.canRead && access.member != nil && !access.banned && !muted
+	return access
+}
+
+// paramID parses the named path param as a record ID. Anything that isn't a
+// positive integer can't name a record, so callers treat it as not found.
+func paramID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	return uint(id), err == nil && id > 0
+}
+
+// memberSpace loads the space named by the :id param (an ID or a slug) and the
+// contact's access to it. Private spaces are hidden from non-members.
+func (h *CommunityHandler) memberSpace(c *gin.Context, contact *models.Contact) (*models.Space, spaceAccess, bool) {
+	param := c.Param("id")
+	var space models.Space
+	q := h.db
+	if id, err := strconv.Atoi(param); err == nil {
+		q = q.Where("id = ?", id)
+	} else {
+		q = q.Where("slug = ?", param)
+	}
+	if err := q.First(&space).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+		return nil, spaceAccess{}, false
+	}
+	access := h.spaceAccessFor(&space, contact)
+	if space.Type == models.SpaceTypePrivate && access.member == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+		return nil, spaceAccess{}, false
+	}
+	return &space, access, true
+}
+
+// threadSpace loads a thread's space and the contact's access to it,
+// responding with an error if the contact can't read it.
+func (h *CommunityHandler) threadSpace(c *gin.Context, thread *models.Thread, contact *models.Contact) (*models.Space, spaceAccess, bool) {
+	var space models.Space
+	if err := h.db.First(&space, thread.SpaceID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return nil, spaceAccess{}, false
+	}
+	access := h.spaceAccessFor(&space, contact)
+	if !access.canRead {
+		if space.Type == models.SpaceTypePrivate {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		} else {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Join this space to view its threads"})
+		}
+		return nil, spaceAccess{}, false
+	}
+	return &space, access, true
+}
+
+// denyPost responds with why the contact can't post in a space.
+func denyPost(c *gin.Context, access spaceAccess) {
+	switch {
+	case access.member == nil:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Join this space to take part"})
+	case access.banned:
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are banned from this space"})
+	case !access.canRead:
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": "Your access to this space has ended"})
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are muted in this space", "muted_until": access.member.MutedUntil})
+	}
+}
+
+// memberAuthor limits preloaded authors to what other members may see.
+func memberAuthor(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "first_name", "last_name", "avatar_url")
+}
+
+// --- Spaces ---
+
+// StudentListSpaces lists the spaces the member can see, with their membership.
+func (h *CommunityHandler) StudentListSpaces(c *gin.Context) {
+	contact := h.memberContact(c, false)
+
+	var spaces []models.Space
+	h.db.Order("sort_order ASC, created_at ASC").Find(&spaces)
+
+	result := make([]models.Space, 0, len(spaces))
+	for _, space := range spaces {
+		access := h.spaceAccessFor(&space, contact)
+		if space.Type == models.SpaceTypePrivate && access.member == nil {
+			continue
+		}
+		space.Membership = access.member
+		h.db.Model(&models.CommunityMember{}).Where("space_id = ?", space.ID).Count(&space.MemberCount)
+		h.db.Model(&models.Thread{}).Where("space_id = ? AND moderation_status = ?", space.ID, models.ModerationApproved).Count(&space.ThreadCount)
+		result = append(result, space)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// StudentGetSpace returns a space with the member's membership and access.
+func (h *CommunityHandler) StudentGetSpace(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	space, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	space.Membership = access.member
+	h.db.Model(&models.CommunityMember{}).Where("space_id = ?", space.ID).Count(&space.MemberCount)
+	h.db.Model(&models.Thread{}).Where("space_id = ? AND moderation_status = ?", space.ID, models.ModerationApproved).Count(&space.ThreadCount)
+	following := contact != nil && services.IsFollowing(h.db, contact.ID, models.FollowableSpace, space.ID)
+	c.JSON(http.StatusOK, gin.H{"data": space, "can_read": access.canRead, "can_post": access.canPost, "following": following})
+}
+
+// StudentJoinSpace adds the member to a public space, or to a paid space
+// whose product they own. Private spaces are joined by invitation only.
+func (h *CommunityHandler) StudentJoinSpace(c *gin.Context) {
+	contact := h.memberContact(c, true)
+	if contact == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join space"})
+		return
+	}
+	space, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	if access.banned {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are banned from this space"})
+		return
+	}
+	if access.member != nil {
+		c.JSON(http.StatusOK, gin.H{"data": access.member, "message": "Already a member"})
+		return
+	}
+
+	switch space.Type {
+	case models.SpaceTypePrivate:
+		c.JSON(http.StatusForbidden, gin.H{"error": "This space is invite-only"})
+		return
+	case models.SpaceTypePaid:
+		if space.ProductID != nil && !models.OwnsProduct(h.db, contact.ID, *space.ProductID) {
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": "This space requires a purchase", "product_id": space.ProductID})
+			return
+		}
+	}
+
+	// Members who left earlier are restored rather than re-created, since the
+	// (contact, space) index also covers soft-deleted rows.
+	var member models.CommunityMember
+	err := h.db.Unscoped().Where("space_id = ? AND contact_id = ?", space.ID, contact.ID).First(&member).Error
+	if err == nil && member.BannedAt != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are banned from this space"})
+		return
+	}
+	if err == nil {
+		member.DeletedAt = gorm.DeletedAt{}
+		member.Role = models.MemberRoleMember
+		member.JoinedAt = time.Now()
+		err = h.db.Unscoped().Save(&member).Error
+	} else {
+		member = models.CommunityMember{
+			TenantID:  1,
+			SpaceID:   space.ID,
+			ContactID: contact.ID,
+			Role:      models.MemberRoleMember,
+			JoinedAt:  time.Now(),
+		}
+		err = h.db.Create(&member).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join space"})
+		return
+	}
+
+	events.Emit(events.CommunityMemberJoined, member)
+	c.JSON(http.StatusCreated, gin.H{"data": member})
+}
+
+// StudentLeaveSpace removes the member from a space.
+func (h *CommunityHandler) StudentLeaveSpace(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	_, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	if access.member == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You are not a member of this space"})
+		return
+	}
+	// Banned members keep their (soft-deleted) row so the ban survives leaving.
+	h.db.Delete(access.member)
+	c.JSON(http.StatusOK, gin.H{"message": "Left space"})
+}
+
+// --- Threads ---
+
+// StudentListThreads lists a space's threads, pinned first.
+func (h *CommunityHandler) StudentListThreads(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	space, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	if !access.canRead {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Join this space to view its threads"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	q := h.db.Model(&models.Thread{}).Where("space_id = ?", space.ID).Scopes(visibleTo(contact, access))
+	if threadType := c.Query("type"); threadType != "" {
+		q = q.Where("type = ?", threadType)
+	}
+
+	var total int64
+	q.Count(&total)
+
+	orderBy := "last_activity_at DESC"
+	switch c.Query("sort") {
+	case "popular":
+		orderBy = "like_count DESC"
+	case "newest":
+		orderBy = "created_at DESC"
+	}
+
+	var threads []models.Thread
+	q.Preload("Author", memberAuthor).Order("CASE WHEN status = 'pinned' THEN 0 ELSE 1 END, " + orderBy).
+		Offset(offset).Limit(pageSize).Find(&threads)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": threads,
+		"meta": gin.H{"total": total, "page": page, "page_size": pageSize, "pages": int(math.Ceil(float64(total) / float64(pageSize)))},
+	})
+}
+
+// StudentGetThread returns a thread with its replies and the member's own
+// reactions to them.
+func (h *CommunityHandler) StudentGetThread(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var thread models.Thread
+	threadID, ok := paramID(c, "threadId")
+	if !ok || h.db.First(&thread, threadID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if !canSee(thread.ModerationStatus, thread.AuthorID, contact, access) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	visible := visibleTo(contact, access)
+	h.db.Preload("Author", memberAuthor).Preload("Replies", func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(visible).Where("parent_id IS NULL").Order("created_at ASC")
+	}).Preload("Replies.Author", memberAuthor).Preload("Replies.Children", func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(visible).Order("created_at ASC")
+	}).Preload("Replies.Children.Author", memberAuthor).First(&thread, thread.ID)
+
+	reactions := []models.Reaction{}
+	if contact != nil {
+		replyIDs := []uint{}
+		for _, r := range thread.Replies {
+			replyIDs = append(replyIDs, r.ID)
+			for _, child := range r.Children {
+				replyIDs = append(replyIDs, child.ID)
+			}
+		}
+		h.db.Where("contact_id = ? AND ((reactable_type = 'thread' AND reactable_id = ?) OR (reactable_type = 'reply' AND reactable_id IN ?))",
+			contact.ID, thread.ID, append(replyIDs, 0)).Find(&reactions)
+	}
+	following := contact != nil && services.IsFollowing(h.db, contact.ID, models.FollowableThread, thread.ID)
+	c.JSON(http.StatusOK, gin.H{"data": thread, "my_reactions": reactions, "following": following})
+}
+
+// StudentCreateThread posts a thread in a space the member belongs to. Only
+// space staff can post announcements.
+func (h *CommunityHandler) StudentCreateThread(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	space, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	if !access.canPost {
+		denyPost(c, access)
+		return
+	}
+
+	var input struct {
+		Title   string         `json:"title" binding:"required"`
+		Content datatypes.JSON `json:"content"`
+		Type    string         `json:"type"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	switch input.Type {
+	case "":
+		input.Type = models.ThreadTypeDiscussion
+	case models.ThreadTypeDiscussion, models.ThreadTypeQuestion:
+	case models.ThreadTypeAnnouncement:
+		if !access.isStaff {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only moderators can post announcements"})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid thread type"})
+		return
+	}
+
+	hold := h.holdReason(space, access, contact.ID, input.Title, string(input.Content))
+	thread := models.Thread{
+		TenantID:         1,
+		SpaceID:          space.ID,
+		AuthorID:         contact.ID,
+		Title:            input.Title,
+		Content:          input.Content,
+		Type:             input.Type,
+		Status:           models.ThreadStatusOpen,
+		LastActivityAt:   time.Now(),
+		ModerationStatus: models.ModerationApproved,
+	}
+	if hold != "" {
+		thread.ModerationStatus = models.ModerationPending
+	}
+	if err := h.db.Create(&thread).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create thread"})
+		return
+	}
+	h.db.Preload("Author", memberAuthor).First(&thread, thread.ID)
+
+	if hold != "" {
+		h.logModeration(space.ID, models.ModActionHold, "thread", thread.ID, nil, hold, nil)
+		c.JSON(http.StatusCreated, gin.H{"data": thread, "message": "Your thread is awaiting approval"})
+		return
+	}
+	events.Emit(events.CommunityThreadCreated, thread)
+	c.JSON(http.StatusCreated, gin.H{"data": thread})
+}
+
+// StudentUpdateThread edits the title and content of the member's own thread.
+func (h *CommunityHandler) StudentUpdateThread(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var thread models.Thread
+	threadID, ok := paramID(c, "threadId")
+	if !ok || h.db.First(&thread, threadID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	space, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if contact == nil || thread.AuthorID != contact.ID || thread.ModerationStatus == models.ModerationHidden {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own threads"})
+		return
+	}
+	if !access.canPost {
+		denyPost(c, access)
+		return
+	}
+
+	var input struct {
+		Title   *string        `json:"title"`
+		Content datatypes.JSON `json:"content"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updates := map[string]interface{}{}
+	if input.Title != nil && *input.Title != "" {
+		updates["title"] = *input.Title
+	}
+	if input.Content != nil {
+		updates["content"] = input.Content
+	}
+	title, content := thread.Title, thread.Content
+	if t, ok := updates["title"].(string); ok {
+		title = t
+	}
+	if input.Content != nil {
+		content = input.Content
+	}
+	// Edits can't slip blocked keywords past the filter.
+	if kw := matchBlockedKeyword(space, title, string(content)); kw != "" && !access.isStaff &&
+		thread.ModerationStatus == models.ModerationApproved {
+		updates["moderation_status"] = models.ModerationPending
+		h.logModeration(space.ID, models.ModActionHold, "thread", thread.ID, nil, fmt.Sprintf("Edited to match blocked keyword %q", kw), nil)
+	}
+	if len(updates) > 0 {
+		h.db.Model(&thread).Updates(updates)
+	}
+	h.db.Preload("Author", memberAuthor).First(&thread, thread.ID)
+	c.JSON(http.StatusOK, gin.H{"data": thread})
+}
+
+// StudentDeleteThread deletes the member's own thread. Space staff can delete
+// any thread in their space.
+func (h *CommunityHandler) StudentDeleteThread(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var thread models.Thread
+	threadID, ok := paramID(c, "threadId")
+	if !ok || h.db.First(&thread, threadID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if (contact == nil || thread.AuthorID != contact.ID) && !access.isStaff {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own threads"})
+		return
+	}
+	h.db.Delete(&thread)
+	c.JSON(http.StatusOK, gin.H{"message": "Thread deleted"})
+}
+
+// --- Replies ---
+
+// StudentCreateReply replies to an open thread, optionally under another reply.
+func (h *CommunityHandler) StudentCreateReply(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var thread models.Thread
+	threadID, ok := paramID(c, "threadId")
+	if !ok || h.db.First(&thread, threadID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	space, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if thread.ModerationStatus != models.ModerationApproved && !access.isStaff {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	if !access.canPost {
+		denyPost(c, access)
+		return
+	}
+	if thread.Status == models.ThreadStatusClosed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "This thread is closed"})
+		return
+	}
+
+	var input struct {
+		Content  datatypes.JSON `json:"content" binding:"required"`
+		ParentID *uint          `json:"parent_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.ParentID != nil {
+		var count int64
+		h.db.Model(&models.Reply{}).Where("id = ? AND thread_id = ?", *input.ParentID, thread.ID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent reply not found in this thread"})
+			return
+		}
+	}
+
+	hold := h.holdReason(space, access, contact.ID, string(input.Content))
+	reply := models.Reply{
+		TenantID:         1,
+		ThreadID:         thread.ID,
+		AuthorID:         contact.ID,
+		Content:          input.Content,
+		ParentID:         input.ParentID,
+		ModerationStatus: models.ModerationApproved,
+	}
+	if hold != "" {
+		reply.ModerationStatus = models.ModerationPending
+	}
+	if err := h.db.Create(&reply).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reply"})
+		return
+	}
+	h.db.Preload("Author", memberAuthor).First(&reply, reply.ID)
+
+	if hold != "" {
+		h.logModeration(space.ID, models.ModActionHold, "reply", reply.ID, nil, hold, nil)
+		c.JSON(http.StatusCreated, gin.H{"data": reply, "message": "Your reply is awaiting approval"})
+		return
+	}
+	h.db.Model(&models.Thread{}).Where("id = ?", thread.ID).Updates(map[string]interface{}{
+		"reply_count":      gorm.Expr("reply_count + 1"),
+		"last_activity_at": time.Now(),
+	})
+	events.Emit(events.CommunityReplyCreated, reply)
+	c.JSON(http.StatusCreated, gin.H{"data": reply})
+}
+
+// StudentUpdateReply edits the content of the member's own reply.
+func (h *CommunityHandler) StudentUpdateReply(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var reply models.Reply
+	replyID, ok := paramID(c, "replyId")
+	if !ok || h.db.First(&reply, replyID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+		return
+	}
+	var thread models.Thread
+	if err := h.db.First(&thread, reply.ThreadID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+		return
+	}
+	space, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if contact == nil || reply.AuthorID != contact.ID || reply.ModerationStatus == models.ModerationHidden {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own replies"})
+		return
+	}
+	if !access.canPost {
+		denyPost(c, access)
+		return
+	}
+
+	var input struct {
+		Content datatypes.JSON `json:"content" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updates := map[string]interface{}{"content": input.Content}
+	// Edits can't slip blocked keywords past the filter.
+	if kw := matchBlockedKeyword(space, string(input.Content)); kw != "" && !access.isStaff &&
+		reply.ModerationStatus == models.ModerationApproved {
+		updates["moderation_status"] = models.ModerationPending
+		h.db.Model(&models.Thread{}).Where("id = ?", reply.ThreadID).
+			UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)"))
+		h.logModeration(space.ID, models.ModActionHold, "reply", reply.ID, nil, fmt.Sprintf("Edited to match blocked keyword %q", kw), nil)
+	}
+	h.db.Model(&reply).Updates(updates)
+	h.db.Preload("Author", memberAuthor).First(&reply, reply.ID)
+	c.JSON(http.StatusOK, gin.H{"data": reply})
+}
+
+// StudentDeleteReply deletes the member's own reply. Space staff can delete
+// any reply in their space.
+func (h *CommunityHandler) StudentDeleteReply(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var reply models.Reply
+	replyID, ok := paramID(c, "replyId")
+	if !ok || h.db.First(&reply, replyID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+		return
+	}
+	var thread models.Thread
+	if err := h.db.First(&thread, reply.ThreadID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+		return
+	}
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if (contact == nil || reply.AuthorID != contact.ID) && !access.isStaff {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own replies"})
+		return
+	}
+	h.db.Delete(&reply)
+	if reply.ModerationStatus == models.ModerationApproved {
+		h.db.Model(&models.Thread{}).Where("id = ?", reply.ThreadID).UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)"))
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Reply deleted"})
+}
+
+// --- Reactions ---
+
+// StudentToggleReaction adds or removes the member's reaction to a thread or reply.
+func (h *CommunityHandler) StudentToggleReaction(c *gin.Context) {
+	var input struct {
+		ReactableType string `json:"reactable_type" binding:"required"` // thread, reply
+		ReactableID   uint   `json:"reactable_id" binding:"required"`
+		Type          string `json:"type"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	switch input.Type {
+	case "", "like", "heart", "celebrate":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reaction type"})
+		return
+	}
+
+	threadID := input.ReactableID
+	replyStatus := models.ModerationApproved
+	switch input.ReactableType {
+	case "thread":
+	case "reply":
+		var reply models.Reply
+		if err := h.db.First(&reply, input.ReactableID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+			return
+		}
+		threadID, replyStatus = reply.ThreadID, reply.ModerationStatus
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reactable_type must be thread or reply"})
+		return
+	}
+
+	contact := h.memberContact(c, false)
+	var thread models.Thread
+	if err := h.db.First(&thread, threadID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	// Only approved content can be reacted to.
+	if thread.ModerationStatus != models.ModerationApproved || replyStatus != models.ModerationApproved {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Content not found"})
+		return
+	}
+	if !access.canPost {
+		denyPost(c, access)
+		return
+	}
+
+	action, reaction := h.toggleReaction(input.ReactableType, input.ReactableID, contact.ID, input.Type)
+	if action == "removed" {
+		c.JSON(http.StatusOK, gin.H{"action": action})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"action": action, "data": reaction})
+}
+
+// --- Events ---
+
+// StudentListEvents lists upcoming and live events in the spaces the member
+// can read, with the member's registration.
+func (h *CommunityHandler) StudentListEvents(c *gin.Context) {
+	contact := h.memberContact(c, false)
+
+	q := h.db.Model(&models.CommunityEvent{}).
+		Where("status IN ?", []string{models.EventStatusUpcoming, models.EventStatusLive})
+	if spaceID := c.Query("space_id"); spaceID != "" {
+		q = q.Where("space_id = ?", spaceID)
+	}
+	var list []models.CommunityEvent
+	q.Order("start_at ASC").Find(&list)
+
+	readable := map[uint]bool{}
+	result := make([]models.CommunityEvent, 0, len(list))
+	for _, event := range list {
+		canRead, seen := readable[event.SpaceID]
+		if !seen {
+			var space models.Space
+			if err := h.db.First(&space, event.SpaceID).Error; err == nil {
+				canRead = h.spaceAccessFor(&space, contact).canRead
+			}
+			readable[event.SpaceID] = canRead
+		}
+		if !canRead {
+			continue
+		}
+		h.db.Model(&models.EventAttendee{}).Where("event_id = ? AND status != ?", event.ID, "cancelled").Count(&event.AttendeeCount)
+		if contact != nil {
+			var attendee models.EventAttendee
+			if err := h.db.Where("event_id = ? AND contact_id = ?", event.ID, contact.ID).First(&attendee).Error; err == nil {
+				event.Registration = &attendee
+			}
+		}
+		result = append(result, event)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// StudentRegisterForEvent registers the member for an event in a space they
+// belong to, up to the event's attendee limit.
+func (h *CommunityHandler) StudentRegisterForEvent(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var event models.CommunityEvent
+	eventID, ok := paramID(c, "eventId")
+	if !ok || h.db.First(&event, eventID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+	var space models.Space
+	if err := h.db.First(&space, event.SpaceID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+	access := h.spaceAccessFor(&space, contact)
+	if access.member == nil || !access.canRead {
+		if space.Type == models.SpaceTypePrivate && access.member == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
+		denyPost(c, access)
+		return
+	}
+	if event.Status != models.EventStatusUpcoming && event.Status != models.EventStatusLive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Registration for this event is closed"})
+		return
+	}
+
+	var attendee models.EventAttendee
+	registered := h.db.Where("event_id = ? AND contact_id = ?", event.ID, contact.ID).First(&attendee).Error == nil
+	if registered && attendee.Status != "cancelled" {
+		c.JSON(http.StatusOK, gin.H{"data": attendee, "message": "Already registered"})
+		return
+	}
+
+	if event.MaxAttendees > 0 {
+		var count int64
+		h.db.Model(&models.EventAttendee{}).Where("event_id = ? AND status != ?", event.ID, "cancelled").Count(&count)
+		if count >= int64(event.MaxAttendees) {
+			c.JSON(http.StatusConflict, gin.H{"error": "This event is full"})
+			return
+		}
+	}
+
+	var err error
+	if registered {
+		attendee.Status = "registered"
+		err = h.db.Save(&attendee).Error
+	} else {
+		attendee = models.EventAttendee{
+			TenantID:  1,
+			EventID:   event.ID,
+			ContactID: contact.ID,
+			Status:    "registered",
+		}
+		err = h.db.Create(&attendee).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": attendee})
+}
+
+// StudentCancelEventRegistration cancels the member's registration for an event.
+func (h *CommunityHandler) StudentCancelEventRegistration(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	if contact == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Registration not found"})
+		return
+	}
+	res := h.db.Model(&models.EventAttendee{}).
+		Where("event_id = ? AND contact_id = ? AND status != ?", c.Param("eventId"), contact.ID, "cancelled").
+		Update("status", "cancelled")
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Registration not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Registration cancelled"})
+}
@@ -0,0 +1,687 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+)
+
+// ===================== MODERATION =====================
+//
+// Members flag threads and replies with reports. Moderators work through a
+// queue of reported and held content, and every action is written to the
+// space's moderation log. The same handlers serve site admins (admin routes,
+// any space) and space moderators (member routes, their own spaces).
+
+// moderator is who is acting on a moderation request.
+type moderator struct {
+	userID    uint
+	contactID *uint
+	siteAdmin bool
+}
+
+// moderatorFor authorizes the authenticated user to moderate a space: site
+// admins moderate every space, members only spaces where they are staff.
+// Tenant API keys moderate as the site; Auth has already checked the key has
+// the community scope the route needs.
+func (h *CommunityHandler) moderatorFor(c *gin.Context, spaceID uint) (*moderator, bool) {
+	user, _ := c.Get("user")
+	u := user.(models.User)
+	if key, ok := c.Get("api_key"); ok && key.(*models.APIKey).UserID == nil {
+		return &moderator{userID: u.ID, siteAdmin: true}, true
+	}
+	if u.Role == models.RoleAdmin || u.Role == models.RoleOwner {
+		mod := &moderator{userID: u.ID, siteAdmin: true}
+		if contact := h.memberContact(c, false); contact != nil {
+			mod.contactID = &contact.ID
+		}
+		return mod, true
+	}
+
+	if contact := h.memberContact(c, false); contact != nil {
+		var space models.Space
+		if err := h.db.First(&space, spaceID).Error; err == nil {
+			if access := h.spaceAccessFor(&space, contact); access.isStaff {
+				return &moderator{userID: u.ID, contactID: &contact.ID}, true
+			}
+		}
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "You can't moderate this space"})
+	return nil, false
+}
+
+// logModeration writes an entry to the space's moderation log. A nil
+// moderator records an automatic action.
+func (h *CommunityHandler) logModeration(spaceID uint, action, targetType string, targetID uint, mod *moderator, reason string, metadata map[string]interface{}) {
+	entry := models.ModerationLog{
+		TenantID:   1,
+		SpaceID:    spaceID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+	}
+	if mod != nil {
+		entry.ActorUserID = &mod.userID
+		entry.ActorContactID = mod.contactID
+	}
+	if metadata != nil {
+		raw, _ := json.Marshal(metadata)
+		entry.Metadata = datatypes.JSON(raw)
+	}
+	h.db.Create(&entry)
+}
+
+// canSee reports whether content with the given moderation status and author
+// is visible to the contact.
+func canSee(status string, authorID uint, contact *models.Contact, access spaceAccess) bool {
+	switch {
+	case status == models.ModerationApproved || access.isStaff:
+		return true
+	case status == models.ModerationPending && contact != nil:
+		return authorID == contact.ID
+	default:
+		return false
+	}
+}
+
+// visibleTo scopes a thread or reply query to what the contact may see:
+// approved content plus their own held posts. Space staff see everything.
+func visibleTo(contact *models.Contact, access spaceAccess) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if access.isStaff {
+			return db
+		}
+		if contact == nil {
+			return db.Where("moderation_status = ?", models.ModerationApproved)
+		}
+		return db.Where("moderation_status = ? OR (moderation_status = ? AND author_id = ?)",
+			models.ModerationApproved, models.ModerationPending, contact.ID)
+	}
+}
+
+// holdReason returns why a member's post should be held for review, or "" to
+// publish it straight away. Staff posts are never held.
+func (h *CommunityHandler) holdReason(space *models.Space, access spaceAccess, contactID uint, texts ...string) string {
+	if access.isStaff {
+		return ""
+	}
+	if kw := matchBlockedKeyword(space, texts...); kw != "" {
+		return fmt.Sprintf("Matched blocked keyword %q", kw)
+	}
+	if space.RequireFirstPostApproval && !h.hasApprovedPost(space.ID, contactID) {
+		return "First post in space"
+	}
+	return ""
+}
+
+// matchBlockedKeyword returns the first of the space's blocked keywords found
+// in texts, ignoring case.
+func matchBlockedKeyword(space *models.Space, texts ...string) string {
+	if len(space.BlockedKeywords) == 0 {
+		return ""
+	}
+	var keywords []string
+	if err := json.Unmarshal(space.BlockedKeywords, &keywords); err != nil {
+		return ""
+	}
+	body := strings.ToLower(strings.Join(texts, "\n"))
+	for _, kw := range keywords {
+		kw = strings.TrimSpace(kw)
+		if kw != "" && strings.Contains(body, strings.ToLower(kw)) {
+			return kw
+		}
+	}
+	return ""
+}
+
+// hasApprovedPost reports whether the contact has an approved thread or reply
+// in the space.
+func (h *CommunityHandler) hasApprovedPost(spaceID, contactID uint) bool {
+	var count int64
+	h.db.Model(&models.Thread{}).
+		Where("space_id = ? AND author_id = ? AND moderation_status = ?", spaceID, contactID, models.ModerationApproved).
+		Count(&count)
+	if count > 0 {
+		return true
+	}
+	h.db.Model(&models.Reply{}).
+		Joins("JOIN threads ON threads.id = replies.thread_id").
+		Where("threads.space_id = ? AND replies.author_id = ? AND replies.moderation_status = ?", spaceID, contactID, models.ModerationApproved).
+		Count(&count)
+	return count > 0
+}
+
+// moderationTarget is a thread or reply under moderation.
+type moderationTarget struct {
+	Type    string
+	Thread  *models.Thread
+	Reply   *models.Reply
+	SpaceID uint
+}
+
+func (t *moderationTarget) id() uint {
+	if t.Reply != nil {
+		return t.Reply.ID
+	}
+	return t.Thread.ID
+}
+
+func (t *moderationTarget) status() string {
+	if t.Reply != nil {
+		return t.Reply.ModerationStatus
+	}
+	return t.Thread.ModerationStatus
+}
+
+// loadModerationTarget loads the thread or reply named by the :type and
+// :targetId params.
+func (h *CommunityHandler) loadModerationTarget(c *gin.Context) (*moderationTarget, bool) {
+	targetID, ok := paramID(c, "targetId")
+	switch c.Param("type") {
+	case "thread", "threads":
+		var thread models.Thread
+		if !ok || h.db.First(&thread, targetID).Error != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+			return nil, false
+		}
+		return &moderationTarget{Type: "thread", Thread: &thread, SpaceID: thread.SpaceID}, true
+	case "reply", "replies":
+		var reply models.Reply
+		if !ok || h.db.First(&reply, targetID).Error != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+			return nil, false
+		}
+		var thread models.Thread
+		if err := h.db.Unscoped().First(&thread, reply.ThreadID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+			return nil, false
+		}
+		return &moderationTarget{Type: "reply", Reply: &reply, Thread: &thread, SpaceID: thread.SpaceID}, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Type must be thread or reply"})
+		return nil, false
+	}
+}
+
+// setModerationStatus moves content to a new moderation status, keeping the
+// thread's reply count in step with its approved replies. Content approved
+// for the first time is announced as newly created.
+func (h *CommunityHandler) setModerationStatus(t *moderationTarget, status string) {
+	previous := t.status()
+	if previous == status {
+		return
+	}
+	if t.Reply != nil {
+		h.db.Model(t.Reply).Update("moderation_status", status)
+		t.Reply.ModerationStatus = status
+		if status == models.ModerationApproved {
+			h.db.Model(&models.Thread{}).Where("id = ?", t.Reply.ThreadID).Updates(map[string]interface{}{
+				"reply_count":      gorm.Expr("reply_count + 1"),
+				"last_activity_at": time.Now(),
+			})
+		} else if previous == models.ModerationApproved {
+			h.db.Model(&models.Thread{}).Where("id = ?", t.Reply.ThreadID).
+				UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)"))
+		}
+		if previous == models.ModerationPending && status == models.ModerationApproved {
+			events.Emit(events.CommunityReplyCreated, *t.Reply)
+		}
+		return
+	}
+
+	h.db.Model(t.Thread).Update("moderation_status", status)
+	t.Thread.ModerationStatus = status
+	if previous == models.ModerationPending && status == models.ModerationApproved {
+		h.db.Model(t.Thread).Update("last_activity_at", time.Now())
+		events.Emit(events.CommunityThreadCreated, *t.Thread)
+	}
+}
+
+// closeReports closes the open reports on a thread or reply.
+func (h *CommunityHandler) closeReports(reportableType string, reportableID uint, status string) int64 {
+	now := time.Now()
+	return h.db.Model(&models.ContentReport{}).
+		Where("reportable_type = ? AND reportable_id = ? AND status = ?", reportableType, reportableID, models.ReportStatusOpen).
+		Updates(map[string]interface{}{"status": status, "resolved_at": now}).RowsAffected
+}
+
+// --- Reports (members) ---
+
+// StudentReportContent flags a thread or reply for the space's moderators.
+func (h *CommunityHandler) StudentReportContent(c *gin.Context) {
+	var input struct {
+		ReportableType string `json:"reportable_type" binding:"required"` // thread, reply
+		ReportableID   uint   `json:"reportable_id" binding:"required"`
+		Reason         string `json:"reason"`
+		Details        string `json:"details"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	switch input.Reason {
+	case "":
+		input.Reason = "other"
+	case "spam", "abuse", "off_topic", "other":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason"})
+		return
+	}
+
+	contact := h.memberContact(c, false)
+	if contact == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Join this space to take part"})
+		return
+	}
+
+	var thread models.Thread
+	var authorID uint
+	var status string
+	switch input.ReportableType {
+	case "thread":
+		if err := h.db.First(&thread, input.ReportableID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+			return
+		}
+		authorID, status = thread.AuthorID, thread.ModerationStatus
+	case "reply":
+		var reply models.Reply
+		if err := h.db.First(&reply, input.ReportableID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+			return
+		}
+		if err := h.db.First(&thread, reply.ThreadID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+			return
+		}
+		authorID, status = reply.AuthorID, reply.ModerationStatus
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reportable_type must be thread or reply"})
+		return
+	}
+
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if !canSee(status, authorID, contact, access) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Content not found"})
+		return
+	}
+	if authorID == contact.ID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You can't report your own post"})
+		return
+	}
+
+	var existing models.ContentReport
+	if err := h.db.Where("reportable_type = ? AND reportable_id = ? AND reporter_id = ? AND status = ?",
+		input.ReportableType, input.ReportableID, contact.ID, models.ReportStatusOpen).First(&existing).Error; err == nil {
+		c.JSON(http.StatusOK, gin.H{"data": existing, "message": "Already reported"})
+		return
+	}
+
+	report := models.ContentReport{
+		TenantID:       1,
+		SpaceID:        thread.SpaceID,
+		ReportableType: input.ReportableType,
+		ReportableID:   input.ReportableID,
+		ReporterID:     contact.ID,
+		Reason:         input.Reason,
+		Details:        input.Details,
+		Status:         models.ReportStatusOpen,
+	}
+	if err := h.db.Create(&report).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to report content"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": report, "message": "Thanks, our moderators will take a look"})
+}
+
+// --- Queue ---
+
+// moderationItem is a reported or held thread or reply in the queue.
+type moderationItem struct {
+	Type             string                 `json:"type"` // thread, reply
+	ID               uint                   `json:"id"`
+	SpaceID          uint                   `json:"space_id"`
+	ThreadID         uint                   `json:"thread_id"`
+	Title            string                 `json:"title"` // the thread's title, for replies too
+	Content          datatypes.JSON         `json:"content"`
+	ModerationStatus string                 `json:"moderation_status"`
+	Author           *models.Contact        `json:"author,omitempty"`
+	Reports          []models.ContentReport `json:"reports"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// ModerationQueue lists held content and content with open reports, oldest
+// first. Admins can filter by ?space_id; moderators see their space (:id).
+func (h *CommunityHandler) ModerationQueue(c *gin.Context) {
+	spaceID := c.Param("id")
+	if spaceID == "" {
+		spaceID = c.Query("space_id")
+	}
+	if c.Param("id") != "" {
+		id, _ := strconv.Atoi(spaceID)
+		if _, ok := h.moderatorFor(c, uint(id)); !ok {
+			return
+		}
+	}
+
+	inSpace := func(db *gorm.DB, column string) *gorm.DB {
+		if spaceID != "" {
+			return db.Where(column+" = ?", spaceID)
+		}
+		return db
+	}
+
+	items := map[string]*moderationItem{}
+	addThread := func(t models.Thread) *moderationItem {
+		key := fmt.Sprintf("thread:%d", t.ID)
+		if items[key] == nil {
+			items[key] = &moderationItem{
+				Type: "thread", ID: t.ID, SpaceID: t.SpaceID, ThreadID: t.ID, Title: t.Title,
+				Content: t.Content, ModerationStatus: t.ModerationStatus, Author: t.Author,
+				Reports: []models.ContentReport{}, CreatedAt: t.CreatedAt,
+			}
+		}
+		return items[key]
+	}
+	addReply := func(r models.Reply, t models.Thread) *moderationItem {
+		key := fmt.Sprintf("reply:%d", r.ID)
+		if items[key] == nil {
+			items[key] = &moderationItem{
+				Type: "reply", ID: r.ID, SpaceID: t.SpaceID, ThreadID: t.ID, Title: t.Title,
+				Content: r.Content, ModerationStatus: r.ModerationStatus, Author: r.Author,
+				Reports: []models.ContentReport{}, CreatedAt: r.CreatedAt,
+			}
+		}
+		return items[key]
+	}
+
+	// Held content
+	var threads []models.Thread
+	inSpace(h.db.Where("moderation_status = ?", models.ModerationPending), "space_id").
+		Preload("Author").Find(&threads)
+	for _, t := range threads {
+		addThread(t)
+	}
+	var replies []models.Reply
+	inSpace(h.db.Joins("JOIN threads ON threads.id = replies.thread_id").
+		Where("replies.moderation_status = ?", models.ModerationPending), "threads.space_id").
+		Preload("Author").Find(&replies)
+	for _, r := range replies {
+		var t models.Thread
+		h.db.Unscoped().First(&t, r.ThreadID)
+		addReply(r, t)
+	}
+
+	// Reported content
+	var reports []models.ContentReport
+	inSpace(h.db.Where("status = ?", models.ReportStatusOpen), "space_id").
+		Preload("Reporter").Order("created_at ASC").Find(&reports)
+	for _, report := range reports {
+		var item *moderationItem
+		if report.ReportableType == "thread" {
+			var t models.Thread
+			if err := h.db.Preload("Author").First(&t, report.ReportableID).Error; err != nil {
+				continue
+			}
+			item = addThread(t)
+		} else {
+			var r models.Reply
+			if err := h.db.Preload("Author").First(&r, report.ReportableID).Error; err != nil {
+				continue
+			}
+			var t models.Thread
+			h.db.Unscoped().First(&t, r.ThreadID)
+			item = addReply(r, t)
+		}
+		item.Reports = append(item.Reports, report)
+	}
+
+	queue := make([]*moderationItem, 0, len(items))
+	for _, item := range items {
+		queue = append(queue, item)
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return queueTime(queue[i]).Before(queueTime(queue[j]))
+	})
+	c.JSON(http.StatusOK, gin.H{"data": queue})
+}
+
+// queueTime is when an item entered the queue: its first report, or when it
+// was posted if it was held.
+func queueTime(item *moderationItem) time.Time {
+	if item.ModerationStatus != models.ModerationPending && len(item.Reports) > 0 {
+		return item.Reports[0].CreatedAt
+	}
+	return item.CreatedAt
+}
+
+// --- Content actions ---
+
+// ApproveContent publishes held or hidden content and dismisses its reports.
+func (h *CommunityHandler) ApproveContent(c *gin.Context) {
+	h.moderateContent(c, models.ModActionApprove)
+}
+
+// HideContent hides content from members and resolves its reports.
+func (h *CommunityHandler) HideContent(c *gin.Context) {
+	h.moderateContent(c, models.ModActionHide)
+}
+
+// DeleteContent deletes content and resolves its reports.
+func (h *CommunityHandler) DeleteContent(c *gin.Context) {
+	h.moderateContent(c, models.ModActionDelete)
+}
+
+func (h *CommunityHandler) moderateContent(c *gin.Context, action string) {
+	target, ok := h.loadModerationTarget(c)
+	if !ok {
+		return
+	}
+	mod, ok := h.moderatorFor(c, target.SpaceID)
+	if !ok {
+		return
+	}
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&input)
+
+	previous := target.status()
+	var closed int64
+	switch action {
+	case models.ModActionApprove:
+		h.setModerationStatus(target, models.ModerationApproved)
+		closed = h.closeReports(target.Type, target.id(), models.ReportStatusDismissed)
+	case models.ModActionHide:
+		h.setModerationStatus(target, models.ModerationHidden)
+		closed = h.closeReports(target.Type, target.id(), models.ReportStatusResolved)
+	case models.ModActionDelete:
+		if target.Reply != nil {
+			h.db.Delete(target.Reply)
+			if previous == models.ModerationApproved {
+				h.db.Model(&models.Thread{}).Where("id = ?", target.Reply.ThreadID).
+					UpdateColumn("reply_count", gorm.Expr("GREATEST(reply_count - 1, 0)"))
+			}
+		} else {
+			h.db.Delete(target.Thread)
+		}
+		closed = h.closeReports(target.Type, target.id(), models.ReportStatusResolved)
+	}
+
+	h.logModeration(target.SpaceID, action, target.Type, target.id(), mod, input.Reason, map[string]interface{}{
+		"previous_status": previous,
+		"reports_closed":  closed,
+	})
+
+	if action == models.ModActionDelete {
+		c.JSON(http.StatusOK, gin.H{"message": "Content deleted"})
+		return
+	}
+	if target.Reply != nil {
+		c.JSON(http.StatusOK, gin.H{"data": target.Reply})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": target.Thread})
+}
+
+// DismissReport closes a report without acting on the content.
+func (h *CommunityHandler) DismissReport(c *gin.Context) {
+	var report models.ContentReport
+	reportID, ok := paramID(c, "reportId")
+	if !ok || h.db.First(&report, reportID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		return
+	}
+	mod, ok := h.moderatorFor(c, report.SpaceID)
+	if !ok {
+		return
+	}
+	if report.Status != models.ReportStatusOpen {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Report is already closed"})
+		return
+	}
+	now := time.Now()
+	report.Status = models.ReportStatusDismissed
+	report.ResolvedAt = &now
+	h.db.Save(&report)
+	h.logModeration(report.SpaceID, models.ModActionDismiss, "report", report.ID, mod, "", map[string]interface{}{
+		"reportable_type": report.ReportableType,
+		"reportable_id":   report.ReportableID,
+	})
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// --- Member actions ---
+
+// MuteMember stops a member posting until the given time, or for the given
+// number of minutes.
+func (h *CommunityHandler) MuteMember(c *gin.Context) {
+	var input struct {
+		Until   *time.Time `json:"until"`
+		Minutes int        `json:"minutes"`
+		Reason  string     `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	until := input.Until
+	if until == nil && input.Minutes > 0 {
+		t := time.Now().Add(time.Duration(input.Minutes) * time.Minute)
+		until = &t
+	}
+	if until == nil || !until.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide a future until time or a number of minutes"})
+		return
+	}
+	h.sanctionMember(c, models.ModActionMute, map[string]interface{}{"muted_until": *until}, input.Reason)
+}
+
+// UnmuteMember lifts a member's mute.
+func (h *CommunityHandler) UnmuteMember(c *gin.Context) {
+	h.sanctionMember(c, models.ModActionUnmute, map[string]interface{}{"muted_until": nil}, "")
+}
+
+// BanMember bans a member from a space: they can no longer post, rejoin, or
+// read a private or paid space.
+func (h *CommunityHandler) BanMember(c *gin.Context) {
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&input)
+	h.sanctionMember(c, models.ModActionBan, map[string]interface{}{"banned_at": time.Now()}, input.Reason)
+}
+
+// UnbanMember lifts a member's ban.
+func (h *CommunityHandler) UnbanMember(c *gin.Context) {
+	h.sanctionMember(c, models.ModActionUnban, map[string]interface{}{"banned_at": nil}, "")
+}
+
+func (h *CommunityHandler) sanctionMember(c *gin.Context, action string, updates map[string]interface{}, reason string) {
+	var member models.CommunityMember
+	memberID, ok := paramID(c, "memberId")
+	if !ok || h.db.First(&member, memberID).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return
+	}
+	mod, ok := h.moderatorFor(c, member.SpaceID)
+	if !ok {
+		return
+	}
+	if !mod.siteAdmin {
+		if member.Role != models.MemberRoleMember {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only site admins can sanction space staff"})
+			return
+		}
+	}
+
+	h.db.Model(&member).Updates(updates)
+	metadata := map[string]interface{}{"contact_id": member.ContactID}
+	for k, v := range updates {
+		metadata[k] = v
+	}
+	h.logModeration(member.SpaceID, action, "member", member.ID, mod, reason, metadata)
+
+	h.db.Preload("Contact").First(&member, member.ID)
+	c.JSON(http.StatusOK, gin.H{"data": member})
+}
+
+// --- Audit trail ---
+
+// ModerationLogs lists moderation actions, newest first. Admins can filter by
+// ?space_id; moderators see their space (:id).
+func (h *CommunityHandler) ModerationLogs(c *gin.Context) {
+	spaceID := c.Param("id")
+	if spaceID != "" {
+		id, _ := strconv.Atoi(spaceID)
+		if _, ok := h.moderatorFor(c, uint(id)); !ok {
+			return
+		}
+	} else {
+		spaceID = c.Query("space_id")
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	q := h.db.Model(&models.ModerationLog{})
+	if spaceID != "" {
+		q = q.Where("space_id = ?", spaceID)
+	}
+	if action := c.Query("action"); action != "" {
+		q = q.Where("action = ?", action)
+	}
+	var total int64
+	q.Count(&total)
+
+	var logs []models.ModerationLog
+	q.Preload("ActorUser").Order("created_at DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&logs)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": logs,
+		"meta": gin.H{"total": total, "page": page, "page_size": pageSize, "pages": int(math.Ceil(float64(total) / float64(pageSize)))},
+	})
+}
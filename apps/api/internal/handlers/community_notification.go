@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// ===== Community notifications (member-facing, under /api/student/community) =====
+
+// StudentListNotifications lists the member's notifications, newest first.
+// Pass ?unread=true for unread ones only. meta.unread is the unread count.
+func (h *CommunityHandler) StudentListNotifications(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	if contact == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"data": []models.CommunityNotification{},
+			"meta": gin.H{"total": 0, "page": page, "page_size": pageSize, "pages": 0, "unread": 0},
+		})
+		return
+	}
+
+	var unread int64
+	h.db.Model(&models.CommunityNotification{}).Where("contact_id = ? AND read_at IS NULL", contact.ID).Count(&unread)
+
+	q := h.db.Model(&models.CommunityNotification{}).Where("contact_id = ?", contact.ID)
+	if c.Query("unread") == "true" {
+		q = q.Where("read_at IS NULL")
+	}
+	var total int64
+	q.Count(&total)
+
+	var notifications []models.CommunityNotification
+	q.Preload("Actor", memberAuthor).Order("created_at DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&notifications)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": notifications,
+		"meta": gin.H{
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+			"pages":     int(math.Ceil(float64(total) / float64(pageSize))),
+			"unread":    unread,
+		},
+	})
+}
+
+// StudentMarkNotificationRead marks one of the member's notifications read.
+func (h *CommunityHandler) StudentMarkNotificationRead(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	if contact == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	var notification models.CommunityNotification
+	if err := h.db.Where("id = ? AND contact_id = ?", c.Param("notificationId"), contact.ID).
+		First(&notification).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		h.db.Model(&notification).Update("read_at", now)
+		notification.ReadAt = &now
+	}
+	c.JSON(http.StatusOK, gin.H{"data": notification})
+}
+
+// StudentMarkAllNotificationsRead marks all the member's notifications read.
+func (h *CommunityHandler) StudentMarkAllNotificationsRead(c *gin.Context) {
+	contact := h.memberContact(c, false)
+	var updated int64
+	if contact != nil {
+		updated = h.db.Model(&models.CommunityNotification{}).
+			Where("contact_id = ? AND read_at IS NULL", contact.ID).
+			Update("read_at", time.Now()).RowsAffected
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "All notifications marked as read", "updated": updated})
+}
+
+// StudentGetNotificationPreferences returns the member's notification settings.
+func (h *CommunityHandler) StudentGetNotificationPreferences(c *gin.Context) {
+	contact := h.memberContact(c, true)
+	if contact == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load your profile"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": models.CommunityPreferencesFor(h.db, contact.ID)})
+}
+
+// StudentUpdateNotificationPreferences updates the member's notification
+// settings. Only the fields sent are changed.
+func (h *CommunityHandler) StudentUpdateNotificationPreferences(c *gin.Context) {
+	contact := h.memberContact(c, true)
+	if contact == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load your profile"})
+		return
+	}
+	var input struct {
+		EmailReplies       *bool   `json:"email_replies"`
+		EmailMentions      *bool   `json:"email_mentions"`
+		EmailAnnouncements *bool   `json:"email_announcements"`
+		AutoFollow         *bool   `json:"auto_follow"`
+		Digest             *string `json:"digest"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if input.EmailReplies != nil {
+		updates["email_replies"] = *input.EmailReplies
+	}
+	if input.EmailMentions != nil {
+		updates["email_mentions"] = *input.EmailMentions
+	}
+	if input.EmailAnnouncements != nil {
+		updates["email_announcements"] = *input.EmailAnnouncements
+	}
+	if input.AutoFollow != nil {
+		updates["auto_follow"] = *input.AutoFollow
+	}
+	if input.Digest != nil {
+		switch *input.Digest {
+		case models.DigestOff, models.DigestDaily, models.DigestWeekly:
+			updates["digest"] = *input.Digest
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Digest must be off, daily or weekly"})
+			return
+		}
+	}
+
+	pref := models.CommunityPreferencesFor(h.db, contact.ID)
+	if pref.ID == 0 {
+		if err := h.db.Create(&pref).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save preferences"})
+			return
+		}
+	}
+	if len(updates) > 0 {
+		h.db.Model(&pref).Updates(updates)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": models.CommunityPreferencesFor(h.db, contact.ID)})
+}
+
+// StudentFollowSpace notifies the member about new threads in a space.
+func (h *CommunityHandler) StudentFollowSpace(c *gin.Context) {
+	h.followSpace(c, true)
+}
+
+// StudentUnfollowSpace stops new-thread notifications for a space.
+func (h *CommunityHandler) StudentUnfollowSpace(c *gin.Context) {
+	h.followSpace(c, false)
+}
+
+func (h *CommunityHandler) followSpace(c *gin.Context, follow bool) {
+	contact := h.memberContact(c, true)
+	if contact == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load your profile"})
+		return
+	}
+	space, access, ok := h.memberSpace(c, contact)
+	if !ok {
+		return
+	}
+	if follow && !access.canRead {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Join this space to follow it"})
+		return
+	}
+	if follow {
+		services.FollowCommunity(h.db, contact.ID, models.FollowableSpace, space.ID, false)
+	} else {
+		services.UnfollowCommunity(h.db, contact.ID, models.FollowableSpace, space.ID)
+	}
+	c.JSON(http.StatusOK, gin.H{"following": follow})
+}
+
+// StudentFollowThread notifies the member about replies to a thread.
+func (h *CommunityHandler) StudentFollowThread(c *gin.Context) {
+	h.followThread(c, true)
+}
+
+// StudentUnfollowThread stops reply notifications for a thread.
+func (h *CommunityHandler) StudentUnfollowThread(c *gin.Context) {
+	h.followThread(c, false)
+}
+
+func (h *CommunityHandler) followThread(c *gin.Context, follow bool) {
+	contact := h.memberContact(c, true)
+	if contact == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load your profile"})
+		return
+	}
+	var thread models.Thread
+	if err := h.db.Where("id = ?", c.Param("threadId")).First(&thread).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	_, access, ok := h.threadSpace(c, &thread, contact)
+	if !ok {
+		return
+	}
+	if !canSee(thread.ModerationStatus, thread.AuthorID, contact, access) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	if follow {
+		services.FollowCommunity(h.db, contact.ID, models.FollowableThread, thread.ID, false)
+	} else {
+		services.UnfollowCommunity(h.db, contact.ID, models.FollowableThread, thread.ID)
+	}
+	c.JSON(http.StatusOK, gin.H{"following": follow})
+}
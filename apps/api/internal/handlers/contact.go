@@ -634,9 +634,9 @@ func (h *ContactHandler) SendEmail(c *gin.Context) {
 		if body.Tag != "" {
 			q = q.Where("id IN (SELECT contact_id FROM contact_tags ct JOIN tags t ON ct.tag_id = t.id WHERE t.name = ?)", body.Tag)
 		}
-		q.Find(&contacts)
+		models.ExcludeSuppressed(q).Find(&contacts)
 	} else {
-		h.DB.Where("id IN ?", body.ContactIDs).Find(&contacts)
+		models.ExcludeSuppressed(h.DB.Where("id IN ?", body.ContactIDs)).Find(&contacts)
 	}
 
 	if len(contacts) == 0 {
@@ -137,6 +137,7 @@ func (h *CourseHandler) DuplicateCourse(c *gin.Context) {
 		Currency:         original.Currency,
 		Status:           models.CourseStatusDraft,
 		AccessType:       original.AccessType,
+		DripMode:         original.DripMode,
 	}
 	h.DB.Create(&newCourse)
 
@@ -163,6 +164,7 @@ func (h *CourseHandler) DuplicateCourse(c *gin.Context) {
 				SortOrder:       lesson.SortOrder,
 				IsFreePreview:   lesson.IsFreePreview,
 				DripDelayDays:   lesson.DripDelayDays,
+				DripDate:        lesson.DripDate,
 			}
 			h.DB.Create(&newLesson)
 		}
@@ -726,6 +728,9 @@ func (h *CourseHandler) GetPublishedCourse(c *gin.Context) {
 	h.DB.Model(&models.CourseEnrollment{}).Where("course_id = ?", course.ID).Count(&count)
 	course.EnrollmentCount = count
 
+	// The curriculum is public; lesson content is for students and free previews
+	withholdLockedLessons(&course, models.CourseLessonAccess(&course, nil, nil, time.Now()))
+
 	c.JSON(http.StatusOK, gin.H{"data": course})
 }
 
@@ -849,12 +854,16 @@ func (h *CourseHandler) StudentGetCourses(c *gin.Context) {
 		Order("created_at DESC").
 		Find(&enrollments)
 
+	now := time.Now()
 	result := make([]gin.H, 0, len(enrollments))
 	for _, e := range enrollments {
+		access := models.CourseLessonAccess(&e.Course, &e, models.CompletedLessonIDs(e.LessonProgresses), now)
+		withholdLockedLessons(&e.Course, access)
 		result = append(result, gin.H{
 			"course":            e.Course,
 			"enrollment":        e,
 			"lesson_progresses": e.LessonProgresses,
+			"lesson_access":     access,
 		})
 	}
 	c.JSON(http.StatusOK, gin.H{"data": result})
@@ -881,9 +890,14 @@ func (h *CourseHandler) StudentGetCourse(c *gin.Context) {
 	}
 
 	// Find contact + enrollment
+	notEnrolled := func() {
+		access := models.CourseLessonAccess(&course, nil, nil, time.Now())
+		withholdLockedLessons(&course, access)
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{"course": course, "enrollment": nil, "lesson_progresses": []interface{}{}, "lesson_access": access}})
+	}
 	var contact models.Contact
 	if err := h.DB.Where("email = ? AND tenant_id = ?", u.Email, 1).First(&contact).Error; err != nil {
-		c.JSON(http.StatusOK, gin.H{"data": gin.H{"course": course, "enrollment": nil, "lesson_progresses": []interface{}{}}})
+		notEnrolled()
 		return
 	}
 
@@ -891,14 +905,19 @@ func (h *CourseHandler) StudentGetCourse(c *gin.Context) {
 	if err := h.DB.Where("contact_id = ? AND course_id = ?", contact.ID, courseID).
 		Preload("LessonProgresses").
 		First(&enrollment).Error; err != nil {
-		c.JSON(http.StatusOK, gin.H{"data": gin.H{"course": course, "enrollment": nil, "lesson_progresses": []interface{}{}}})
+		notEnrolled()
 		return
 	}
 
+	// Withhold lessons the drip schedule hasn't opened yet
+	access := models.CourseLessonAccess(&course, &enrollment, models.CompletedLessonIDs(enrollment.LessonProgresses), time.Now())
+	withholdLockedLessons(&course, access)
+
 	c.JSON(http.StatusOK, gin.H{"data": gin.H{
 		"course":            course,
 		"enrollment":        enrollment,
 		"lesson_progresses": enrollment.LessonProgresses,
+		"lesson_access":     access,
 	}})
 }
 
@@ -918,12 +937,40 @@ func (h *CourseHandler) StudentMarkLessonComplete(c *gin.Context) {
 
 	// Find enrollment
 	var enrollment models.CourseEnrollment
-	if err := h.DB.Where("contact_id = ? AND course_id = ?", contact.ID, courseID).First(&enrollment).Error; err != nil {
+	if err := h.DB.Where("contact_id = ? AND course_id = ?", contact.ID, courseID).
+		Preload("LessonProgresses").
+		First(&enrollment).Error; err != nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Not enrolled in this course"})
 		return
 	}
 
+	// The lesson must belong to the course and be unlocked
+	var course models.Course
+	h.DB.Where("id = ?", courseID).
+		Preload("Modules", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Preload("Modules.Lessons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		First(&course)
 	now := time.Now()
+	var lessonAccess *models.LessonAccess
+	for _, a := range models.CourseLessonAccess(&course, &enrollment, models.CompletedLessonIDs(enrollment.LessonProgresses), now) {
+		if a.LessonID == uint(lessonID) {
+			lessonAccess = &a
+			break
+		}
+	}
+	if lessonAccess == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lesson not found"})
+		return
+	}
+	if !lessonAccess.Unlocked {
+		c.JSON(http.StatusForbidden, gin.H{"error": "This lesson is still locked", "unlocks_at": lessonAccess.UnlocksAt})
+		return
+	}
+
 	var progress models.LessonProgress
 	result := h.DB.Where("enrollment_id = ? AND lesson_id = ?", enrollment.ID, lessonID).First(&progress)
 
@@ -987,6 +1034,27 @@ func (h *CourseHandler) CourseDashboard(c *gin.Context) {
 	}})
 }
 
+// withholdLockedLessons strips the content of lessons the student can't open
+// yet, leaving the title and metadata for the curriculum.
+func withholdLockedLessons(course *models.Course, access []models.LessonAccess) {
+	locked := map[uint]bool{}
+	for _, a := range access {
+		if !a.Unlocked {
+			locked[a.LessonID] = true
+		}
+	}
+	for i := range course.Modules {
+		for j := range course.Modules[i].Lessons {
+			lesson := &course.Modules[i].Lessons[j]
+			if locked[lesson.ID] {
+				lesson.Content = nil
+				lesson.VideoURL = ""
+				lesson.Quizzes = nil
+			}
+		}
+	}
+}
+
 func generateSlug(title string) string {
 	slug := strings.ToLower(title)
 	slug = strings.ReplaceAll(slug, " ", "-")
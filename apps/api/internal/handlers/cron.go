@@ -13,10 +13,7 @@ type CronHandler struct{}
 
 // ListTasks returns all registered cron tasks.
 func (h *CronHandler) ListTasks(c *gin.Context) {
-	tasks := cron.RegisteredTasks
-	if tasks == nil {
-		tasks = []cron.Task{}
-	}
+	tasks := cron.Tasks()
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": tasks,
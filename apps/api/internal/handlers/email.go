@@ -16,18 +16,23 @@ import (
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
+	"gritcms/apps/api/internal/config"
 	"gritcms/apps/api/internal/events"
 	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/mail"
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
 type EmailHandler struct {
-	DB   *gorm.DB
-	Jobs *jobs.Client
+	DB        *gorm.DB
+	Jobs      *jobs.Client
+	Sequences *services.SequenceTriggers
+	cfg       *config.Config
 }
 
-func NewEmailHandler(db *gorm.DB, jobClient *jobs.Client) *EmailHandler {
-	return &EmailHandler{DB: db, Jobs: jobClient}
+func NewEmailHandler(db *gorm.DB, jobClient *jobs.Client, sequences *services.SequenceTriggers, cfg *config.Config) *EmailHandler {
+	return &EmailHandler{DB: db, Jobs: jobClient, Sequences: sequences, cfg: cfg}
 }
 
 // ===== Email Lists =====
@@ -237,19 +242,62 @@ func (h *EmailHandler) ConfirmSubscription(c *gin.Context) {
 	sub.ConfirmToken = ""
 	h.DB.Save(&sub)
 
+	// Confirming proves ownership of the mailbox, so it overrides an earlier
+	// unsubscribe-from-everything. The public subscribe endpoint can't.
+	var contact models.Contact
+	if err := h.DB.First(&contact, sub.ContactID).Error; err == nil {
+		liftUnsubscribeAll(h.DB, contact.Email)
+	}
+
 	events.Emit(events.EmailSubscribed, sub)
 	c.JSON(http.StatusOK, gin.H{"message": "Subscription confirmed"})
 }
 
-// Unsubscribe removes a contact from an email list (public endpoint).
+// Unsubscribe removes a contact from an email list, or from all marketing
+// email (public endpoint).
+//
+// The List-Unsubscribe header on marketing email points here with the
+// contact's email token in the query string; mail clients implementing
+// RFC 8058 POST "List-Unsubscribe=One-Click" to it, which unsubscribes the
+// contact from everything. Clients that open the link instead get
+// UnsubscribePage, whose form posts back here.
 func (h *EmailHandler) Unsubscribe(c *gin.Context) {
+	if token := c.Query("token"); token != "" {
+		var contact models.Contact
+		if err := h.DB.Where("email_token = ?", token).First(&contact).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invalid unsubscribe link"})
+			return
+		}
+		unsubscribeAll(h.DB, &contact, "one_click")
+		if c.PostForm("confirm") != "" {
+			h.renderUnsubscribePage(c, http.StatusOK, unsubscribePageData{Done: true})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed successfully"})
+		return
+	}
+
 	var body struct {
 		Email  string `json:"email"`
 		ListID uint   `json:"list_id"`
-		Token  string `json:"token"` // alternative: unsubscribe via token
+		Token  string `json:"token"` // the subscription's token, or with all the contact's email token
+		All    bool   `json:"all"`   // unsubscribe from every list
 	}
 	c.ShouldBindJSON(&body)
 
+	if body.All {
+		// Unsubscribing from everything needs the token from one of the
+		// contact's emails, so nobody can do it to an address they don't own.
+		var contact models.Contact
+		if body.Token == "" || h.DB.Where("email_token = ?", body.Token).First(&contact).Error != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invalid unsubscribe link"})
+			return
+		}
+		unsubscribeAll(h.DB, &contact, "unsubscribe")
+		c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed successfully"})
+		return
+	}
+
 	var sub models.EmailSubscription
 
 	if body.Token != "" {
@@ -473,9 +521,16 @@ func (h *EmailHandler) CreateCampaign(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := normalizeCampaignABTest(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	body.TenantID = 1
 	body.Status = models.CampaignStatusDraft
 	body.Stats = datatypes.JSON([]byte(`{"sent":0,"delivered":0,"opened":0,"clicked":0,"bounced":0,"unsubscribed":0}`))
+	body.WinningVariant = ""
+	body.TestEndsAt = nil
+	body.VariantStats = nil
 	h.DB.Create(&body)
 	c.JSON(http.StatusCreated, gin.H{"data": body})
 }
@@ -487,14 +542,21 @@ func (h *EmailHandler) UpdateCampaign(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
 		return
 	}
-	if campaign.Status == models.CampaignStatusSent || campaign.Status == models.CampaignStatusSending {
+	if campaign.Status == models.CampaignStatusSent || campaign.Status == models.CampaignStatusSending ||
+		campaign.Status == models.CampaignStatusTesting {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot edit a sent or sending campaign"})
 		return
 	}
+	winner, testEndsAt, variantStats := campaign.WinningVariant, campaign.TestEndsAt, campaign.VariantStats
 	if err := c.ShouldBindJSON(&campaign); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := normalizeCampaignABTest(&campaign); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	campaign.WinningVariant, campaign.TestEndsAt, campaign.VariantStats = winner, testEndsAt, variantStats
 	h.DB.Save(&campaign)
 	c.JSON(http.StatusOK, gin.H{"data": campaign})
 }
@@ -514,6 +576,52 @@ func (h *EmailHandler) DeleteCampaign(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Campaign deleted"})
 }
 
+// normalizeCampaignABTest validates a campaign's A/B test settings, lettering
+// variants "A" to "D" and filling in defaults.
+func normalizeCampaignABTest(campaign *models.EmailCampaign) error {
+	if len(campaign.Variants) == 0 || string(campaign.Variants) == "null" {
+		campaign.Variants = nil
+		return nil
+	}
+
+	var variants []models.CampaignVariant
+	if err := json.Unmarshal(campaign.Variants, &variants); err != nil {
+		return fmt.Errorf("variants must be a list")
+	}
+	switch {
+	case len(variants) == 0:
+		campaign.Variants = nil
+		return nil
+	case len(variants) == 1 || len(variants) > 4:
+		return fmt.Errorf("an A/B test needs two to four variants")
+	}
+	for i := range variants {
+		variants[i].Key = string(rune('A' + i))
+		if variants[i].Subject == "" && variants[i].HTMLContent == "" && variants[i].TemplateID == nil {
+			return fmt.Errorf("variant %s needs a subject, content or template", variants[i].Key)
+		}
+	}
+	campaign.Variants, _ = json.Marshal(variants)
+
+	if campaign.TestPercent == 0 {
+		campaign.TestPercent = 20
+	}
+	if campaign.TestPercent < 1 || campaign.TestPercent > 100 {
+		return fmt.Errorf("test_percent must be between 1 and 100")
+	}
+	if campaign.TestWaitHours <= 0 {
+		campaign.TestWaitHours = 4
+	}
+	switch campaign.WinnerMetric {
+	case "":
+		campaign.WinnerMetric = models.WinnerMetricOpenRate
+	case models.WinnerMetricOpenRate, models.WinnerMetricClickRate:
+	default:
+		return fmt.Errorf("winner_metric must be open_rate or click_rate")
+	}
+	return nil
+}
+
 // ScheduleCampaign sets a campaign to be sent at a specific time or immediately.
 func (h *EmailHandler) ScheduleCampaign(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
@@ -575,33 +683,17 @@ func (h *EmailHandler) GetCampaignStats(c *gin.Context) {
 		return
 	}
 
-	// Count sends by status
-	type StatusCount struct {
-		Status string
-		Count  int64
-	}
-	var counts []StatusCount
-	h.DB.Model(&models.EmailSend{}).Select("status, count(*) as count").
-		Where("campaign_id = ?", id).Group("status").Find(&counts)
-
-	stats := models.CampaignStats{}
-	for _, sc := range counts {
-		switch sc.Status {
-		case models.SendStatusSent:
-			stats.Sent += int(sc.Count)
-		case models.SendStatusDelivered:
-			stats.Delivered += int(sc.Count)
-		case models.SendStatusOpened:
-			stats.Opened += int(sc.Count)
-		case models.SendStatusClicked:
-			stats.Clicked += int(sc.Count)
-		case models.SendStatusBounced:
-			stats.Bounced += int(sc.Count)
-		}
-	}
-	stats.Sent += stats.Delivered + stats.Opened + stats.Clicked // cumulative
+	var uniqueClickers int64
+	h.DB.Model(&models.EmailClickEvent{}).Where("campaign_id = ?", campaign.ID).
+		Distinct("contact_id").Count(&uniqueClickers)
 
-	c.JSON(http.StatusOK, gin.H{"data": stats})
+	stats := models.ComputeCampaignStats(h.DB, campaign.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data":            stats,
+		"variants":        models.ComputeVariantStats(h.DB, campaign.ID),
+		"links":           models.ComputeLinkStats(h.DB, campaign.ID),
+		"unique_clickers": uniqueClickers,
+	})
 }
 
 // ===== Email Sequences =====
@@ -634,6 +726,7 @@ func (h *EmailHandler) CreateSequence(c *gin.Context) {
 	}
 	body.TenantID = 1
 	h.DB.Create(&body)
+	h.Sequences.Reload()
 	c.JSON(http.StatusCreated, gin.H{"data": body})
 }
 
@@ -649,12 +742,14 @@ func (h *EmailHandler) UpdateSequence(c *gin.Context) {
 		return
 	}
 	h.DB.Save(&seq)
+	h.Sequences.Reload()
 	c.JSON(http.StatusOK, gin.H{"data": seq})
 }
 
 func (h *EmailHandler) DeleteSequence(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	h.DB.Delete(&models.EmailSequence{}, id)
+	h.Sequences.Reload()
 	c.JSON(http.StatusOK, gin.H{"message": "Sequence deleted"})
 }
 
@@ -706,32 +801,28 @@ func (h *EmailHandler) EnrollContact(c *gin.Context) {
 		return
 	}
 
-	// Get first step
-	var firstStep models.EmailSequenceStep
-	if err := h.DB.Where("sequence_id = ?", seqID).Order("sort_order ASC").First(&firstStep).Error; err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Sequence has no steps"})
+	var seq models.EmailSequence
+	if err := h.DB.First(&seq, seqID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sequence not found"})
 		return
 	}
 
-	now := time.Now()
-	nextSend := now.Add(time.Duration(firstStep.DelayDays)*24*time.Hour + time.Duration(firstStep.DelayHours)*time.Hour)
-
-	enrollment := models.EmailSequenceEnrollment{
-		TenantID:      1,
-		SequenceID:    uint(seqID),
-		ContactID:     body.ContactID,
-		CurrentStepID: &firstStep.ID,
-		Status:        models.EnrollmentStatusActive,
-		EnrolledAt:    now,
-		NextSendAt:    &nextSend,
+	var firstStep models.EmailSequenceStep
+	if err := h.DB.Where("sequence_id = ?", seqID).First(&firstStep).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Sequence has no steps"})
+		return
 	}
 
-	if err := h.DB.Create(&enrollment).Error; err != nil {
+	enrollment, err := services.EnrollInSequence(h.DB, seq, body.ContactID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enroll contact"})
 		return
 	}
+	if enrollment == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Contact is already enrolled in this sequence"})
+		return
+	}
 
-	events.Emit(events.EmailSequenceEnrolled, enrollment)
 	c.JSON(http.StatusCreated, gin.H{"data": enrollment})
 }
 
@@ -917,15 +1008,31 @@ func (h *EmailHandler) TrackOpen(c *gin.Context) {
 	c.Data(http.StatusOK, "image/gif", transparentPixel)
 }
 
-// TrackClick records a click and redirects to the target URL.
+// TrackClick records a click on a tracked link and redirects to its target.
+// Links are signed when rewritten at send time; anything else is rejected so
+// the endpoint can't be used as an open redirect.
 func (h *EmailHandler) TrackClick(c *gin.Context) {
 	sendID, _ := strconv.Atoi(c.Param("id"))
-	url := c.Query("url")
+	target := c.Query("url")
+	if sendID <= 0 || target == "" || !mail.VerifyClickSignature(h.cfg.TrackingSecret, uint(sendID), target, c.Query("sig")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tracking link"})
+		return
+	}
 
 	var send models.EmailSend
 	if err := h.DB.First(&send, sendID).Error; err == nil {
+		now := time.Now()
+		h.DB.Create(&models.EmailClickEvent{
+			TenantID:   send.TenantID,
+			SendID:     send.ID,
+			CampaignID: send.CampaignID,
+			ContactID:  send.ContactID,
+			URL:        target,
+			UserAgent:  c.Request.UserAgent(),
+			IPAddress:  c.ClientIP(),
+			ClickedAt:  now,
+		})
 		if send.ClickedAt == nil {
-			now := time.Now()
 			send.ClickedAt = &now
 			send.Status = models.SendStatusClicked
 			h.DB.Save(&send)
@@ -933,10 +1040,7 @@ func (h *EmailHandler) TrackClick(c *gin.Context) {
 		}
 	}
 
-	if url == "" {
-		url = "/"
-	}
-	c.Redirect(http.StatusTemporaryRedirect, url)
+	c.Redirect(http.StatusTemporaryRedirect, target)
 }
 
 // 1x1 transparent GIF pixel
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+)
+
+// ===== Email Preferences (public, token-authenticated) =====
+
+type preferenceList struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Subscribed  bool   `json:"subscribed"`
+}
+
+type preferenceTopic struct {
+	Topic string           `json:"topic"`
+	Lists []preferenceList `json:"lists"`
+}
+
+// GetPreferences returns the lists a contact can subscribe to, grouped by
+// topic, for the email preferences page. The token comes from the contact's
+// List-Unsubscribe link, so no login is needed.
+func (h *EmailHandler) GetPreferences(c *gin.Context) {
+	var contact models.Contact
+	if err := h.DB.Where("email_token = ?", c.Param("token")).First(&contact).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid preferences link"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": h.preferences(&contact)})
+}
+
+// UpdatePreferences sets which lists a contact is subscribed to, or
+// unsubscribes them from all marketing email.
+func (h *EmailHandler) UpdatePreferences(c *gin.Context) {
+	var contact models.Contact
+	if err := h.DB.Where("email_token = ?", c.Param("token")).First(&contact).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid preferences link"})
+		return
+	}
+
+	var body struct {
+		ListIDs        []uint `json:"list_ids"`
+		UnsubscribeAll bool   `json:"unsubscribe_all"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if body.UnsubscribeAll {
+		unsubscribeAll(h.DB, &contact, "preferences")
+		c.JSON(http.StatusOK, gin.H{"data": h.preferences(&contact)})
+		return
+	}
+
+	if len(body.ListIDs) > 0 {
+		liftUnsubscribeAll(h.DB, contact.Email)
+	}
+
+	wanted := map[uint]bool{}
+	for _, id := range body.ListIDs {
+		wanted[id] = true
+	}
+
+	var lists []models.EmailList
+	h.DB.Find(&lists)
+	var subs []models.EmailSubscription
+	h.DB.Where("contact_id = ?", contact.ID).Find(&subs)
+	byList := map[uint]*models.EmailSubscription{}
+	for i := range subs {
+		byList[subs[i].EmailListID] = &subs[i]
+	}
+
+	now := time.Now()
+	for _, list := range lists {
+		sub := byList[list.ID]
+		active := sub != nil && (sub.Status == models.SubStatusActive || sub.Status == models.SubStatusPending)
+
+		switch {
+		case wanted[list.ID] && sub == nil:
+			// The token proves ownership of the mailbox, so no double opt-in.
+			newSub := models.EmailSubscription{
+				TenantID:     1,
+				ContactID:    contact.ID,
+				EmailListID:  list.ID,
+				Status:       models.SubStatusActive,
+				Source:       "preferences",
+				SubscribedAt: &now,
+			}
+			h.DB.Create(&newSub)
+			events.Emit(events.EmailSubscribed, newSub)
+		case wanted[list.ID] && sub.Status != models.SubStatusActive:
+			sub.Status = models.SubStatusActive
+			sub.SubscribedAt = &now
+			sub.UnsubscribedAt = nil
+			sub.ConfirmToken = ""
+			h.DB.Save(sub)
+			events.Emit(events.EmailSubscribed, *sub)
+		case !wanted[list.ID] && active:
+			sub.Status = models.SubStatusUnsubscribed
+			sub.UnsubscribedAt = &now
+			h.DB.Save(sub)
+			events.Emit(events.EmailUnsubscribed, *sub)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": h.preferences(&contact)})
+}
+
+// preferences builds the preferences page payload for a contact.
+func (h *EmailHandler) preferences(contact *models.Contact) gin.H {
+	var lists []models.EmailList
+	h.DB.Order("topic ASC, name ASC").Find(&lists)
+
+	var activeIDs []uint
+	h.DB.Model(&models.EmailSubscription{}).
+		Where("contact_id = ? AND status = ?", contact.ID, models.SubStatusActive).
+		Pluck("email_list_id", &activeIDs)
+	active := map[uint]bool{}
+	for _, id := range activeIDs {
+		active[id] = true
+	}
+
+	topics := []preferenceTopic{}
+	for _, list := range lists {
+		if len(topics) == 0 || topics[len(topics)-1].Topic != list.Topic {
+			topics = append(topics, preferenceTopic{Topic: list.Topic})
+		}
+		t := &topics[len(topics)-1]
+		t.Lists = append(t.Lists, preferenceList{
+			ID:          list.ID,
+			Name:        list.Name,
+			Description: list.Description,
+			Subscribed:  active[list.ID],
+		})
+	}
+
+	suppression := models.FindSuppression(h.DB, contact.Email)
+	return gin.H{
+		"email":            contact.Email,
+		"first_name":       contact.FirstName,
+		"topics":           topics,
+		"unsubscribed_all": suppression != nil && suppression.Reason == models.SuppressionUnsubscribedAll,
+		"suppressed":       suppression != nil && suppression.Reason != models.SuppressionUnsubscribedAll,
+	}
+}
+
+// unsubscribeAll suppresses a contact's address for marketing email and ends
+// their subscriptions and sequence enrollments.
+func unsubscribeAll(db *gorm.DB, contact *models.Contact, source string) {
+	email := strings.ToLower(strings.TrimSpace(contact.Email))
+	if email != "" {
+		contactID := contact.ID
+		db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.EmailSuppression{
+			TenantID:  contact.TenantID,
+			Email:     email,
+			ContactID: &contactID,
+			Reason:    models.SuppressionUnsubscribedAll,
+			Source:    source,
+		})
+	}
+
+	now := time.Now()
+	var subs []models.EmailSubscription
+	db.Where("contact_id = ? AND status IN ?", contact.ID, []string{models.SubStatusActive, models.SubStatusPending}).Find(&subs)
+	for _, sub := range subs {
+		sub.Status = models.SubStatusUnsubscribed
+		sub.UnsubscribedAt = &now
+		db.Save(&sub)
+		events.Emit(events.EmailUnsubscribed, sub)
+	}
+
+	db.Model(&models.EmailSequenceEnrollment{}).
+		Where("contact_id = ? AND status = ?", contact.ID, models.EnrollmentStatusActive).
+		Updates(map[string]interface{}{
+			"status":       models.EnrollmentStatusCancelled,
+			"next_send_at": nil,
+		})
+}
+
+// liftUnsubscribeAll removes an unsubscribed-all suppression for an address
+// that has explicitly opted in again. Bounces and complaints stay suppressed.
+func liftUnsubscribeAll(db *gorm.DB, email string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return
+	}
+	db.Where("email = ? AND reason = ?", email, models.SuppressionUnsubscribedAll).Delete(&models.EmailSuppression{})
+}
+
+// unsubscribePage is shown to people who open the List-Unsubscribe link in a
+// browser. Unsubscribing takes a click, so link scanners that GET every URL
+// in an email don't unsubscribe anyone.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Unsubscribe · {{.SiteName}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",sans-serif;background:#f4f4f5;color:#18181b;margin:0;padding:48px 16px}
+main{max-width:420px;margin:0 auto;background:#fff;border-radius:12px;padding:32px;text-align:center}
+h1{font-size:20px;margin:0 0 12px}p{color:#52525b;font-size:14px;line-height:1.5}
+button{background:#18181b;color:#fff;border:0;border-radius:8px;padding:10px 20px;font-size:14px;cursor:pointer}
+</style>
+</head>
+<body>
+<main>
+{{if .Invalid}}
+<h1>Link not valid</h1>
+<p>This unsubscribe link is invalid or has expired.</p>
+{{else if .Done}}
+<h1>You've been unsubscribed</h1>
+<p>You won't receive marketing email from {{.SiteName}} any more.</p>
+{{else}}
+<h1>Unsubscribe</h1>
+<p>Stop all marketing email from {{.SiteName}} to {{.Email}}?</p>
+<form method="post">
+<input type="hidden" name="confirm" value="1">
+<button type="submit">Unsubscribe</button>
+</form>
+{{end}}
+</main>
+</body>
+</html>`))
+
+type unsubscribePageData struct {
+	SiteName string
+	Email    string
+	Done     bool
+	Invalid  bool
+}
+
+// UnsubscribePage serves the List-Unsubscribe link to browsers (public,
+// token-authenticated). Its form posts back to Unsubscribe.
+func (h *EmailHandler) UnsubscribePage(c *gin.Context) {
+	token := c.Query("token")
+	var contact models.Contact
+	if token == "" || h.DB.Where("email_token = ?", token).First(&contact).Error != nil {
+		h.renderUnsubscribePage(c, http.StatusNotFound, unsubscribePageData{Invalid: true})
+		return
+	}
+	h.renderUnsubscribePage(c, http.StatusOK, unsubscribePageData{Email: contact.Email})
+}
+
+func (h *EmailHandler) renderUnsubscribePage(c *gin.Context, status int, data unsubscribePageData) {
+	data.SiteName = models.GetSetting(h.DB, "site_name", "GritCMS")
+	var buf bytes.Buffer
+	if err := unsubscribePage.Execute(&buf, data); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// ===== Suppression List (admin) =====
+
+func (h *EmailHandler) ListSuppressions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	search := c.Query("search")
+	reason := c.Query("reason")
+
+	q := h.DB.Model(&models.EmailSuppression{}).Order("created_at DESC")
+	if search != "" {
+		like := "%" + strings.ToLower(search) + "%"
+		q = q.Where("email LIKE ? OR domain LIKE ?", like, like)
+	}
+	if reason != "" {
+		q = q.Where("reason = ?", reason)
+	}
+
+	var total int64
+	q.Count(&total)
+
+	var entries []models.EmailSuppression
+	q.Offset((page - 1) * pageSize).Limit(pageSize).Find(&entries)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"meta": gin.H{"total": total, "page": page, "page_size": pageSize, "pages": int(math.Ceil(float64(total) / float64(pageSize)))},
+	})
+}
+
+// CreateSuppression adds an address or a whole domain to the suppression list.
+func (h *EmailHandler) CreateSuppression(c *gin.Context) {
+	var body struct {
+		Email   string `json:"email"`
+		Domain  string `json:"domain"`
+		Reason  string `json:"reason"`
+		Details string `json:"details"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry := models.EmailSuppression{
+		TenantID: 1,
+		Email:    strings.ToLower(strings.TrimSpace(body.Email)),
+		Domain:   strings.ToLower(strings.TrimPrefix(strings.TrimSpace(body.Domain), "@")),
+		Reason:   body.Reason,
+		Source:   "admin",
+		Details:  body.Details,
+	}
+	if (entry.Email == "") == (entry.Domain == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either email or domain"})
+		return
+	}
+	if entry.Email != "" && !isValidEmail(entry.Email) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email address"})
+		return
+	}
+	switch entry.Reason {
+	case "":
+		entry.Reason = models.SuppressionManual
+	case models.SuppressionUnsubscribedAll, models.SuppressionBounced, models.SuppressionComplained, models.SuppressionManual:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason"})
+		return
+	}
+
+	if entry.Email != "" {
+		var contact models.Contact
+		if err := h.DB.Where("LOWER(email) = ?", entry.Email).First(&contact).Error; err == nil {
+			entry.ContactID = &contact.ID
+		}
+	}
+
+	if err := h.DB.Create(&entry).Error; err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Already on the suppression list"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": entry})
+}
+
+// DeleteSuppression removes an entry, allowing email to the address again.
+func (h *EmailHandler) DeleteSuppression(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.DB.Delete(&models.EmailSuppression{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete suppression"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Suppression removed"})
+}
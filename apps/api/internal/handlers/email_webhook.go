@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gritcms/apps/api/internal/config"
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+)
+
+// webhookTolerance is how far a signed webhook timestamp may drift from now.
+const webhookTolerance = 5 * time.Minute
+
+// EmailWebhookHandler ingests delivery notifications from the email provider.
+type EmailWebhookHandler struct {
+	DB  *gorm.DB
+	cfg *config.Config
+}
+
+func NewEmailWebhookHandler(db *gorm.DB, cfg *config.Config) *EmailWebhookHandler {
+	return &EmailWebhookHandler{DB: db, cfg: cfg}
+}
+
+// resendEvent is the envelope Resend posts for email.* webhook events.
+type resendEvent struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      struct {
+		EmailID string   `json:"email_id"`
+		To      []string `json:"to"`
+		Subject string   `json:"subject"`
+		Bounce  *struct {
+			Type    string `json:"type"`    // "Permanent", "Transient" or "Undetermined"
+			SubType string `json:"subType"` // e.g. "General", "Suppressed", "MailboxFull"
+			Message string `json:"message"`
+		} `json:"bounce,omitempty"`
+	} `json:"data"`
+}
+
+// ResendWebhook handles Resend's signed delivery, bounce, complaint and
+// delivery-delayed notifications.
+func (h *EmailWebhookHandler) ResendWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read body"})
+		return
+	}
+
+	if h.cfg.ResendWebhookSecret == "" {
+		log.Println("[webhook] RESEND_WEBHOOK_SECRET not set, rejecting Resend webhook")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Webhook not configured"})
+		return
+	}
+	if err := verifySvixSignature(h.cfg.ResendWebhookSecret, c.Request.Header, payload); err != nil {
+		log.Printf("[webhook] Resend signature verification failed: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid signature"})
+		return
+	}
+
+	var event resendEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	if event.Data.EmailID == "" {
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	var send models.EmailSend
+	if err := h.DB.Preload("Contact").Where("external_id = ?", event.Data.EmailID).First(&send).Error; err != nil {
+		// Not one of ours (e.g. a transactional email); acknowledge so it isn't retried.
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	switch event.Type {
+	case "email.delivered":
+		h.handleDelivered(&send)
+	case "email.delivery_delayed":
+		log.Printf("[webhook] Delivery delayed for send %d (%s)", send.ID, send.Contact.Email)
+	case "email.bounced":
+		h.handleBounced(&send, event)
+	case "email.complained":
+		h.handleComplained(&send)
+	}
+
+	if send.CampaignID != nil {
+		h.refreshCampaignStats(*send.CampaignID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
+
+func (h *EmailWebhookHandler) handleDelivered(send *models.EmailSend) {
+	// Only move forward: an open or click may have been recorded already.
+	h.DB.Model(&models.EmailSend{}).
+		Where("id = ? AND status IN ?", send.ID, []string{models.SendStatusQueued, models.SendStatusSent}).
+		Update("status", models.SendStatusDelivered)
+}
+
+func (h *EmailWebhookHandler) handleBounced(send *models.EmailSend, event resendEvent) {
+	now := time.Now()
+	send.Status = models.SendStatusBounced
+	send.BouncedAt = &now
+	h.DB.Model(send).Updates(map[string]interface{}{
+		"status":     models.SendStatusBounced,
+		"bounced_at": now,
+	})
+	events.Emit(events.EmailBounced, *send)
+
+	// Transient bounces (mailbox full, greylisting...) may succeed next time.
+	hard := event.Data.Bounce == nil || !strings.EqualFold(event.Data.Bounce.Type, "Transient")
+	if !hard {
+		log.Printf("[webhook] Soft bounce for send %d (%s)", send.ID, send.Contact.Email)
+		return
+	}
+
+	details := ""
+	if event.Data.Bounce != nil {
+		details = strings.TrimSpace(event.Data.Bounce.SubType + ": " + event.Data.Bounce.Message)
+	}
+	h.suppress(send, models.SuppressionBounced, models.SubStatusBounced, details)
+}
+
+func (h *EmailWebhookHandler) handleComplained(send *models.EmailSend) {
+	h.DB.Model(send).Update("status", models.SendStatusComplained)
+	h.suppress(send, models.SuppressionComplained, models.SubStatusComplained, "")
+}
+
+// suppress adds the send's address to the suppression list and flags the
+// contact's active subscriptions with subStatus.
+func (h *EmailWebhookHandler) suppress(send *models.EmailSend, reason, subStatus, details string) {
+	email := strings.ToLower(strings.TrimSpace(send.Contact.Email))
+	if email == "" {
+		return
+	}
+
+	contactID := send.ContactID
+	h.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.EmailSuppression{
+		TenantID:  send.TenantID,
+		Email:     email,
+		ContactID: &contactID,
+		Reason:    reason,
+		Source:    "resend",
+		Details:   details,
+	})
+
+	now := time.Now()
+	h.DB.Model(&models.EmailSubscription{}).
+		Where("contact_id = ? AND status IN ?", send.ContactID, []string{models.SubStatusActive, models.SubStatusPending}).
+		Updates(map[string]interface{}{
+			"status":          subStatus,
+			"unsubscribed_at": now,
+		})
+
+	// Stop any drip sequences still mailing this contact.
+	h.DB.Model(&models.EmailSequenceEnrollment{}).
+		Where("contact_id = ? AND status = ?", send.ContactID, models.EnrollmentStatusActive).
+		Updates(map[string]interface{}{
+			"status":       models.EnrollmentStatusCancelled,
+			"next_send_at": nil,
+		})
+
+	log.Printf("[webhook] Suppressed %s (%s)", email, reason)
+}
+
+func (h *EmailWebhookHandler) refreshCampaignStats(campaignID uint) {
+	statsJSON, _ := json.Marshal(models.ComputeCampaignStats(h.DB, campaignID))
+	variantsJSON, _ := json.Marshal(models.ComputeVariantStats(h.DB, campaignID))
+	h.DB.Model(&models.EmailCampaign{}).Where("id = ?", campaignID).Updates(map[string]interface{}{
+		"stats":         statsJSON,
+		"variant_stats": variantsJSON,
+	})
+}
+
+// verifySvixSignature checks the svix-id/svix-timestamp/svix-signature headers
+// Resend signs its webhooks with: an HMAC-SHA256 over "id.timestamp.body"
+// keyed with the base64 part of the "whsec_" secret.
+func verifySvixSignature(secret string, header http.Header, body []byte) error {
+	id := header.Get("svix-id")
+	timestamp := header.Get("svix-timestamp")
+	signatures := header.Get("svix-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return fmt.Errorf("missing signature headers")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp")
+	}
+	if d := time.Since(time.Unix(ts, 0)); d > webhookTolerance || d < -webhookTolerance {
+		return fmt.Errorf("timestamp outside tolerance")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return fmt.Errorf("invalid webhook secret: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	// The header holds space-separated "v1,<base64>" entries, one per active secret.
+	for _, sig := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(sig, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no matching signature")
+}
@@ -17,6 +17,7 @@ import (
 	"gritcms/apps/api/internal/config"
 	"gritcms/apps/api/internal/events"
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
 // PaymentHandler handles Stripe checkout and webhook endpoints.
@@ -38,11 +39,13 @@ func NewPaymentHandler(db *gorm.DB, cfg *config.Config) *PaymentHandler {
 // the client_secret for the frontend to complete payment via Stripe Elements.
 func (h *PaymentHandler) Checkout(c *gin.Context) {
 	var input struct {
-		Type       string `json:"type" binding:"required"` // "product" or "course"
+		Type       string `json:"type" binding:"required"` // "product", "course" or "booking"
 		ProductID  *uint  `json:"product_id"`
 		CourseID   *uint  `json:"course_id"`
 		PriceID    uint   `json:"price_id"`
 		CouponCode string `json:"coupon_code"`
+		// Pending paid appointment created by POST /api/book/:slug.
+		AppointmentID *uint `json:"appointment_id"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -69,6 +72,9 @@ func (h *PaymentHandler) Checkout(c *gin.Context) {
 		h.db.Save(&contact)
 	}
 
+	// Attribute the buyer to the affiliate whose link they followed, if any
+	recordReferralCookie(c, h.db, &contact)
+
 	// Resolve product/course and build order item
 	var subtotal float64
 	var currency string
@@ -76,6 +82,11 @@ func (h *PaymentHandler) Checkout(c *gin.Context) {
 	var orderItem models.OrderItem
 
 	switch input.Type {
+	case "booking":
+		// Booking orders are created with the appointment; coupons don't apply.
+		h.checkoutBooking(c, &contact, input.AppointmentID)
+		return
+
 	case "course":
 		if input.CourseID == nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "course_id is required"})
@@ -138,6 +149,11 @@ func (h *PaymentHandler) Checkout(c *gin.Context) {
 				return
 			}
 		}
+		if price.Type == models.PriceTypeSubscription {
+			// Recurring prices are billed by a Stripe subscription; coupons don't apply.
+			h.checkoutSubscription(c, &contact, &product, &price)
+			return
+		}
 		subtotal = price.Amount
 		currency = price.Currency
 		itemName = product.Name
@@ -151,7 +167,7 @@ func (h *PaymentHandler) Checkout(c *gin.Context) {
 		}
 
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be 'product' or 'course'"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be 'product', 'course' or 'booking'"})
 		return
 	}
 
@@ -213,6 +229,7 @@ func (h *PaymentHandler) Checkout(c *gin.Context) {
 		CouponID:        couponID,
 		Items:           []models.OrderItem{orderItem},
 	}
+	services.ApplyReferral(h.db, &order, &contact)
 
 	if err := h.db.Create(&order).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order"})
@@ -328,6 +345,12 @@ func (h *PaymentHandler) ConfirmCheckout(c *gin.Context) {
 		return
 	}
 
+	// Subscription orders are paid through the subscription's first invoice
+	if order.SubscriptionID != nil {
+		h.confirmSubscriptionCheckout(c, &order)
+		return
+	}
+
 	pi, err := paymentintent.Get(order.PaymentID, nil)
 	if err != nil {
 		log.Printf("[confirm] Failed to retrieve PI %s: %v", order.PaymentID, err)
@@ -384,6 +407,13 @@ func (h *PaymentHandler) StripeWebhook(c *gin.Context) {
 		h.handlePaymentSucceeded(event)
 	case "payment_intent.payment_failed":
 		h.handlePaymentFailed(event)
+	case "customer.subscription.created", "customer.subscription.updated", "customer.subscription.deleted",
+		"customer.subscription.paused", "customer.subscription.resumed":
+		h.handleSubscriptionEvent(event)
+	case "invoice.paid":
+		h.handleInvoicePaid(event)
+	case "invoice.payment_failed":
+		h.handleInvoicePaymentFailed(event)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"received": true})
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/paymentintent"
+
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// checkoutBooking takes payment for a paid booking. BookAppointment already
+// created the pending appointment and its order; this attaches a
+// PaymentIntent to the order, reusing the earlier one if the client retries.
+// The appointment is confirmed once the payment succeeds.
+func (h *PaymentHandler) checkoutBooking(c *gin.Context, contact *models.Contact, appointmentID *uint) {
+	if appointmentID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "appointment_id is required"})
+		return
+	}
+
+	var appt models.Appointment
+	if err := h.db.Preload("EventType").
+		Where("id = ? AND contact_id = ?", *appointmentID, contact.ID).
+		First(&appt).Error; err != nil || appt.OrderID == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		return
+	}
+	if appt.Status != models.AppointmentPending {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This appointment doesn't need payment"})
+		return
+	}
+	if appt.HoldExpiresAt == nil || appt.HoldExpiresAt.Before(time.Now()) {
+		c.JSON(http.StatusGone, gin.H{"error": "Your hold on this time has expired. Please pick a time again."})
+		return
+	}
+
+	var order models.Order
+	if err := h.db.First(&order, *appt.OrderID).Error; err != nil || order.Status != models.OrderStatusPending {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This appointment doesn't need payment"})
+		return
+	}
+	if order.ReferredByAccountID == nil {
+		services.ApplyReferral(h.db, &order, contact)
+		if order.ReferredByAccountID != nil {
+			h.db.Model(&order).Updates(map[string]interface{}{
+				"referred_by_account_id": order.ReferredByAccountID,
+				"referral_link_id":       order.ReferralLinkID,
+			})
+		}
+	}
+
+	currency := order.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	amountInCents := int64(math.Round(order.Total))
+
+	var pi *stripe.PaymentIntent
+	if order.PaymentID != "" {
+		existing, err := paymentintent.Get(order.PaymentID, nil)
+		if err == nil && existing.Status != stripe.PaymentIntentStatusCanceled {
+			pi = existing
+		}
+	}
+	if pi == nil {
+		itemName := "Appointment"
+		if appt.EventType != nil {
+			itemName = appt.EventType.Name
+		}
+		params := &stripe.PaymentIntentParams{
+			Amount:   stripe.Int64(amountInCents),
+			Currency: stripe.String(strings.ToLower(currency)),
+			AutomaticPaymentMethods: &stripe.PaymentIntentAutomaticPaymentMethodsParams{
+				Enabled: stripe.Bool(true),
+			},
+			Description:  stripe.String(itemName),
+			ReceiptEmail: stripe.String(contact.Email),
+			Metadata: map[string]string{
+				"order_id":       fmt.Sprintf("%d", order.ID),
+				"contact_id":     fmt.Sprintf("%d", contact.ID),
+				"appointment_id": fmt.Sprintf("%d", appt.ID),
+				"type":           "booking",
+			},
+		}
+		var err error
+		pi, err = paymentintent.New(params)
+		if err != nil {
+			log.Printf("[payment] Stripe PaymentIntent creation failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize payment"})
+			return
+		}
+		order.PaymentID = pi.ID
+		h.db.Model(&order).Update("payment_id", pi.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"client_secret":   pi.ClientSecret,
+		"order_id":        order.ID,
+		"order_number":    order.OrderNumber,
+		"appointment_id":  appt.ID,
+		"hold_expires_at": appt.HoldExpiresAt,
+		"amount":          amountInCents,
+		"currency":        currency,
+		"publishable_key": h.cfg.StripePublishableKey,
+	}})
+}
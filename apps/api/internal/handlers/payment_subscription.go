@@ -0,0 +1,436 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/customer"
+	"github.com/stripe/stripe-go/v82/invoice"
+	stripeprice "github.com/stripe/stripe-go/v82/price"
+	"github.com/stripe/stripe-go/v82/subscription"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// checkoutSubscription starts a Stripe subscription for a recurring price.
+// The subscription and a pending order for its first invoice are created
+// locally; the webhook keeps both in sync from then on. With a trial there is
+// nothing to pay yet, so the client confirms a SetupIntent instead of a
+// PaymentIntent to save the card for the first renewal.
+func (h *PaymentHandler) checkoutSubscription(c *gin.Context, contact *models.Contact, product *models.Product, price *models.Price) {
+	customerID, err := ensureStripeCustomer(h.db, contact)
+	if err != nil {
+		log.Printf("[payment] Stripe customer creation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize payment"})
+		return
+	}
+	priceID, err := ensureStripePrice(h.db, product, price)
+	if err != nil {
+		log.Printf("[payment] Stripe price creation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize payment"})
+		return
+	}
+
+	currency := price.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	dueNow := price.Amount
+	if price.TrialDays > 0 {
+		dueNow = 0
+	}
+
+	now := time.Now()
+	sub := models.Subscription{
+		TenantID:           1,
+		ContactID:          contact.ID,
+		ProductID:          product.ID,
+		PriceID:            price.ID,
+		Status:             models.SubscriptionIncomplete,
+		PaymentProvider:    "stripe",
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now,
+	}
+	if err := h.db.Create(&sub).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create subscription"})
+		return
+	}
+
+	order := models.Order{
+		TenantID:        1,
+		ContactID:       contact.ID,
+		OrderNumber:     generateOrderNumber(),
+		Status:          models.OrderStatusPending,
+		Subtotal:        dueNow,
+		Total:           dueNow,
+		Currency:        currency,
+		PaymentProvider: "stripe",
+		SubscriptionID:  &sub.ID,
+		Items: []models.OrderItem{{
+			TenantID:  1,
+			ProductID: &product.ID,
+			PriceID:   &price.ID,
+			Quantity:  1,
+			UnitPrice: dueNow,
+			Total:     dueNow,
+		}},
+	}
+	services.ApplyReferral(h.db, &order, contact)
+	if err := h.db.Create(&order).Error; err != nil {
+		h.db.Delete(&sub)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order"})
+		return
+	}
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(customerID),
+		Items: []*stripe.SubscriptionItemsParams{
+			{Price: stripe.String(priceID)},
+		},
+		PaymentBehavior: stripe.String("default_incomplete"),
+		PaymentSettings: &stripe.SubscriptionPaymentSettingsParams{
+			SaveDefaultPaymentMethod: stripe.String("on_subscription"),
+		},
+		Metadata: map[string]string{
+			"subscription_id": fmt.Sprintf("%d", sub.ID),
+			"order_id":        fmt.Sprintf("%d", order.ID),
+			"contact_id":      fmt.Sprintf("%d", contact.ID),
+		},
+	}
+	if price.TrialDays > 0 {
+		params.TrialPeriodDays = stripe.Int64(int64(price.TrialDays))
+	}
+	params.AddExpand("latest_invoice.confirmation_secret")
+	params.AddExpand("pending_setup_intent")
+
+	ss, err := subscription.New(params)
+	if err != nil {
+		log.Printf("[payment] Stripe subscription creation failed: %v", err)
+		h.db.Delete(&order)
+		h.db.Delete(&sub)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize payment"})
+		return
+	}
+
+	h.db.Model(&sub).Update("provider_subscription_id", ss.ID)
+	if ss.LatestInvoice != nil {
+		order.PaymentID = ss.LatestInvoice.ID
+		h.db.Model(&order).Update("payment_id", order.PaymentID)
+	}
+
+	intent, clientSecret := "payment", ""
+	if ss.PendingSetupIntent != nil {
+		intent, clientSecret = "setup", ss.PendingSetupIntent.ClientSecret
+	} else if ss.LatestInvoice != nil && ss.LatestInvoice.ConfirmationSecret != nil {
+		clientSecret = ss.LatestInvoice.ConfirmationSecret.ClientSecret
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"client_secret":   clientSecret,
+		"intent":          intent,
+		"order_id":        order.ID,
+		"order_number":    order.OrderNumber,
+		"subscription_id": sub.ID,
+		"amount":          int64(math.Round(dueNow)),
+		"currency":        currency,
+		"trial_days":      price.TrialDays,
+		"publishable_key": h.cfg.StripePublishableKey,
+	}})
+}
+
+// confirmSubscriptionCheckout fulfills a subscription's signup order once its
+// first invoice is paid (immediately, for a trial's zero-amount invoice).
+func (h *PaymentHandler) confirmSubscriptionCheckout(c *gin.Context, order *models.Order) {
+	inv, err := invoice.Get(order.PaymentID, nil)
+	if err != nil {
+		log.Printf("[confirm] Failed to retrieve invoice %s: %v", order.PaymentID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify payment"})
+		return
+	}
+	if inv.Status != stripe.InvoiceStatusPaid {
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{"status": string(inv.Status)}})
+		return
+	}
+
+	var sub models.Subscription
+	if err := h.db.First(&sub, *order.SubscriptionID).Error; err == nil && sub.ProviderSubscriptionID != "" {
+		if ss, err := subscription.Get(sub.ProviderSubscriptionID, nil); err == nil {
+			syncStripeSubscription(h.db, ss)
+		}
+	}
+
+	if markOrderPaid(h.db, order) {
+		fulfillOrder(h.db, order)
+		log.Printf("[confirm] Subscription order %d confirmed and fulfilled (invoice: %s)", order.ID, inv.ID)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"status": "paid"}})
+}
+
+// handleSubscriptionEvent syncs customer.subscription.* events.
+func (h *PaymentHandler) handleSubscriptionEvent(event stripe.Event) {
+	var ss stripe.Subscription
+	if err := json.Unmarshal(event.Data.Raw, &ss); err != nil {
+		log.Printf("[webhook] Failed to parse subscription: %v", err)
+		return
+	}
+	syncStripeSubscription(h.db, &ss)
+}
+
+// handleInvoicePaid records each paid subscription invoice as an order: the
+// signup order is marked paid, and renewals get a new paid order so revenue,
+// fulfillment and affiliate commissions see every charge.
+func (h *PaymentHandler) handleInvoicePaid(event stripe.Event) {
+	var inv stripe.Invoice
+	if err := json.Unmarshal(event.Data.Raw, &inv); err != nil {
+		log.Printf("[webhook] Failed to parse invoice: %v", err)
+		return
+	}
+	sub := h.syncInvoiceSubscription(&inv)
+	if sub == nil {
+		return
+	}
+
+	var order models.Order
+	if err := h.db.Preload("Items").Where("payment_id = ?", inv.ID).First(&order).Error; err == nil {
+		if markOrderPaid(h.db, &order) {
+			fulfillOrder(h.db, &order)
+			log.Printf("[webhook] Subscription order %d marked as paid (invoice: %s)", order.ID, inv.ID)
+		}
+	} else if inv.AmountPaid > 0 {
+		now := time.Now()
+		amount := float64(inv.AmountPaid)
+		order = models.Order{
+			TenantID:        sub.TenantID,
+			ContactID:       sub.ContactID,
+			OrderNumber:     generateOrderNumber(),
+			Status:          models.OrderStatusPaid,
+			Subtotal:        amount,
+			Total:           amount,
+			Currency:        strings.ToUpper(string(inv.Currency)),
+			PaymentProvider: "stripe",
+			PaymentID:       inv.ID,
+			SubscriptionID:  &sub.ID,
+			PaidAt:          &now,
+			Items: []models.OrderItem{{
+				TenantID:  sub.TenantID,
+				ProductID: &sub.ProductID,
+				PriceID:   &sub.PriceID,
+				Quantity:  1,
+				UnitPrice: amount,
+				Total:     amount,
+			}},
+		}
+		var contact models.Contact
+		if h.db.First(&contact, sub.ContactID).Error == nil {
+			services.ApplyReferral(h.db, &order, &contact)
+		}
+		if err := h.db.Create(&order).Error; err != nil {
+			log.Printf("[webhook] Failed to record renewal for subscription %d: %v", sub.ID, err)
+			return
+		}
+		fulfillOrder(h.db, &order)
+	}
+
+	if inv.BillingReason == stripe.InvoiceBillingReasonSubscriptionCycle {
+		events.Emit(events.SubscriptionRenewed, map[string]interface{}{
+			"subscription_id": sub.ID,
+			"contact_id":      sub.ContactID,
+			"product_id":      sub.ProductID,
+			"order_id":        order.ID,
+			"amount":          inv.AmountPaid,
+		})
+	}
+}
+
+// handleInvoicePaymentFailed refreshes the subscription, which Stripe moves to
+// past_due while it retries the charge.
+func (h *PaymentHandler) handleInvoicePaymentFailed(event stripe.Event) {
+	var inv stripe.Invoice
+	if err := json.Unmarshal(event.Data.Raw, &inv); err != nil {
+		log.Printf("[webhook] Failed to parse invoice: %v", err)
+		return
+	}
+	if sub := h.syncInvoiceSubscription(&inv); sub != nil {
+		log.Printf("[webhook] Payment failed for subscription %d (invoice: %s)", sub.ID, inv.ID)
+	}
+}
+
+// syncInvoiceSubscription re-reads the subscription an invoice bills for, so
+// its status and period reflect the payment.
+func (h *PaymentHandler) syncInvoiceSubscription(inv *stripe.Invoice) *models.Subscription {
+	if inv.Parent == nil || inv.Parent.SubscriptionDetails == nil || inv.Parent.SubscriptionDetails.Subscription == nil {
+		return nil
+	}
+	ss, err := subscription.Get(inv.Parent.SubscriptionDetails.Subscription.ID, nil)
+	if err != nil {
+		log.Printf("[webhook] Failed to retrieve subscription for invoice %s: %v", inv.ID, err)
+		return nil
+	}
+	return syncStripeSubscription(h.db, ss)
+}
+
+// syncStripeSubscription copies a Stripe subscription's status, billing period
+// and cancellation onto the local subscription and emits lifecycle events for
+// the transitions.
+func syncStripeSubscription(db *gorm.DB, ss *stripe.Subscription) *models.Subscription {
+	var sub models.Subscription
+	if err := db.Where("provider_subscription_id = ?", ss.ID).First(&sub).Error; err != nil {
+		// The webhook can arrive before checkout has stored the Stripe ID.
+		id := ss.Metadata["subscription_id"]
+		if id == "" || db.First(&sub, id).Error != nil {
+			return nil
+		}
+	}
+
+	prevStatus, prevCancelAtEnd := sub.Status, sub.CancelAtPeriodEnd
+
+	sub.ProviderSubscriptionID = ss.ID
+	sub.Status = stripeSubscriptionStatus(ss.Status)
+	sub.CancelAtPeriodEnd = ss.CancelAtPeriodEnd
+	if ss.Items != nil && len(ss.Items.Data) > 0 {
+		item := ss.Items.Data[0]
+		sub.CurrentPeriodStart = time.Unix(item.CurrentPeriodStart, 0)
+		sub.CurrentPeriodEnd = time.Unix(item.CurrentPeriodEnd, 0)
+	}
+	if ss.TrialEnd > 0 {
+		trialEnd := time.Unix(ss.TrialEnd, 0)
+		sub.TrialEndsAt = &trialEnd
+	}
+	if ss.CanceledAt > 0 && sub.CancelledAt == nil {
+		cancelledAt := time.Unix(ss.CanceledAt, 0)
+		sub.CancelledAt = &cancelledAt
+	}
+	db.Save(&sub)
+
+	payload := map[string]interface{}{
+		"subscription_id": sub.ID,
+		"contact_id":      sub.ContactID,
+		"product_id":      sub.ProductID,
+		"status":          sub.Status,
+	}
+	switch {
+	case prevStatus == models.SubscriptionIncomplete &&
+		(sub.Status == models.SubscriptionActive || sub.Status == models.SubscriptionTrialing):
+		events.Emit(events.SubscriptionCreated, payload)
+	case sub.Status == models.SubscriptionPastDue && prevStatus != models.SubscriptionPastDue:
+		events.Emit(events.SubscriptionPastDue, payload)
+	// A cancellation is announced once: when it's scheduled for the period
+	// end, or when the subscription ends without having been scheduled.
+	case sub.CancelAtPeriodEnd && !prevCancelAtEnd && sub.Status != models.SubscriptionCancelled,
+		sub.Status == models.SubscriptionCancelled && prevStatus != models.SubscriptionCancelled && !prevCancelAtEnd:
+		events.Emit(events.SubscriptionCancelled, payload)
+	}
+	return &sub
+}
+
+// stripeSubscriptionStatus maps a Stripe subscription status onto ours.
+func stripeSubscriptionStatus(status stripe.SubscriptionStatus) string {
+	switch status {
+	case stripe.SubscriptionStatusActive:
+		return models.SubscriptionActive
+	case stripe.SubscriptionStatusTrialing:
+		return models.SubscriptionTrialing
+	case stripe.SubscriptionStatusPastDue, stripe.SubscriptionStatusUnpaid:
+		return models.SubscriptionPastDue
+	case stripe.SubscriptionStatusPaused:
+		return models.SubscriptionPaused
+	case stripe.SubscriptionStatusCanceled, stripe.SubscriptionStatusIncompleteExpired:
+		return models.SubscriptionCancelled
+	default:
+		return models.SubscriptionIncomplete
+	}
+}
+
+// cancelStripeSubscription cancels a Stripe subscription now, or at the end
+// of the current billing period.
+func cancelStripeSubscription(id string, immediately bool) error {
+	if immediately {
+		_, err := subscription.Cancel(id, nil)
+		return err
+	}
+	_, err := subscription.Update(id, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	})
+	return err
+}
+
+// markOrderPaid moves a pending order to paid. It reports false if the order
+// was already handled, so concurrent confirmations fulfill it only once.
+func markOrderPaid(db *gorm.DB, order *models.Order) bool {
+	now := time.Now()
+	res := db.Model(&models.Order{}).
+		Where("id = ? AND status = ?", order.ID, models.OrderStatusPending).
+		Updates(map[string]interface{}{
+			"status":  models.OrderStatusPaid,
+			"paid_at": now,
+		})
+	if res.RowsAffected == 0 {
+		return false
+	}
+	order.Status = models.OrderStatusPaid
+	order.PaidAt = &now
+	return true
+}
+
+// ensureStripeCustomer returns the contact's Stripe customer, creating it on
+// first use.
+func ensureStripeCustomer(db *gorm.DB, contact *models.Contact) (string, error) {
+	if contact.StripeCustomerID != "" {
+		return contact.StripeCustomerID, nil
+	}
+	cust, err := customer.New(&stripe.CustomerParams{
+		Email:    stripe.String(contact.Email),
+		Name:     stripe.String(strings.TrimSpace(contact.FirstName + " " + contact.LastName)),
+		Metadata: map[string]string{"contact_id": fmt.Sprintf("%d", contact.ID)},
+	})
+	if err != nil {
+		return "", err
+	}
+	contact.StripeCustomerID = cust.ID
+	db.Model(contact).Update("stripe_customer_id", cust.ID)
+	return cust.ID, nil
+}
+
+// ensureStripePrice returns the Stripe recurring price for a subscription
+// price, creating it (and its Stripe product) on first use.
+func ensureStripePrice(db *gorm.DB, product *models.Product, price *models.Price) (string, error) {
+	if price.StripePriceID != "" {
+		return price.StripePriceID, nil
+	}
+	interval := price.Interval
+	if interval == "" {
+		interval = "month"
+	}
+	currency := price.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	sp, err := stripeprice.New(&stripe.PriceParams{
+		Currency:   stripe.String(strings.ToLower(currency)),
+		UnitAmount: stripe.Int64(int64(math.Round(price.Amount))),
+		Recurring: &stripe.PriceRecurringParams{
+			Interval: stripe.String(interval),
+		},
+		ProductData: &stripe.PriceProductDataParams{
+			Name:     stripe.String(product.Name),
+			Metadata: map[string]string{"product_id": fmt.Sprintf("%d", product.ID)},
+		},
+		Metadata: map[string]string{"price_id": fmt.Sprintf("%d", price.ID)},
+	})
+	if err != nil {
+		return "", err
+	}
+	price.StripePriceID = sp.ID
+	db.Model(price).Update("stripe_price_id", sp.ID)
+	return sp.ID, nil
+}
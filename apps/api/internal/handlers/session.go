@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// SessionHandler lists and revokes signed-in devices.
+type SessionHandler struct {
+	DB *gorm.DB
+}
+
+// ListMine returns the current user's active sessions, most recently used first.
+func (h *SessionHandler) ListMine(c *gin.Context) {
+	sessions := h.activeSessions(c.GetUint("user_id"))
+	current := c.GetUint("session_id")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// RevokeMine signs one of the current user's devices out.
+func (h *SessionHandler) RevokeMine(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var session models.UserSession
+	if err := h.DB.Where("id = ? AND user_id = ?", id, c.GetUint("user_id")).First(&session).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "Session not found",
+			},
+		})
+		return
+	}
+
+	if err := services.RevokeSession(h.DB, session.ID, models.SessionRevokedByUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to revoke session",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// RevokeOthers signs the current user out everywhere except this device.
+func (h *SessionHandler) RevokeOthers(c *gin.Context) {
+	result := h.DB.Model(&models.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", c.GetUint("user_id"), c.GetUint("session_id")).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": models.SessionRevokedByUser})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to revoke sessions",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    gin.H{"revoked": result.RowsAffected},
+		"message": "Signed out of all other devices",
+	})
+}
+
+// ListForUser returns a user's active sessions (admin only).
+func (h *SessionHandler) ListForUser(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	c.JSON(http.StatusOK, gin.H{"data": h.activeSessions(uint(id))})
+}
+
+// ForceLogout signs a user out on every device (admin only).
+func (h *SessionHandler) ForceLogout(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	var user models.User
+	if err != nil || h.DB.First(&user, id).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "User not found",
+			},
+		})
+		return
+	}
+
+	revoked, err := services.RevokeUserSessions(h.DB, user.ID, models.SessionRevokedByAdmin)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to revoke sessions",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    gin.H{"revoked": revoked},
+		"message": "User signed out of all devices",
+	})
+}
+
+func (h *SessionHandler) activeSessions(userID uint) []models.UserSession {
+	var sessions []models.UserSession
+	h.DB.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions)
+	return sessions
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/config"
+	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
+)
+
+// TwoFactorHandler manages TOTP two-factor authentication.
+type TwoFactorHandler struct {
+	DB     *gorm.DB
+	Config *config.Config
+}
+
+type twoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Status reports whether the current user has 2FA on and whether policy
+// requires it.
+func (h *TwoFactorHandler) Status(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var tf models.UserTwoFactor
+	enabled := h.DB.Where("user_id = ?", userID).First(&tf).Error == nil && tf.Enabled()
+
+	var remaining int64
+	if enabled {
+		h.DB.Model(&models.TwoFactorRecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&remaining)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"enabled":                  enabled,
+			"enabled_at":               tf.EnabledAt,
+			"recovery_codes_remaining": remaining,
+			"required":                 h.Config.RequireAdminTwoFactor && models.IsAdminRole(c.GetString("user_role")),
+		},
+	})
+}
+
+// Setup starts enrollment: it generates a new secret and returns it with the
+// otpauth:// URI to show as a QR code. 2FA stays off until Enable confirms a
+// code from the app.
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	user := c.MustGet("user").(models.User)
+
+	var tf models.UserTwoFactor
+	if err := h.DB.Where("user_id = ?", user.ID).First(&tf).Error; err == nil && tf.Enabled() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "ALREADY_ENABLED",
+				"message": "Two-factor authentication is already enabled",
+			},
+		})
+		return
+	}
+
+	secret, err := services.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to generate a secret",
+			},
+		})
+		return
+	}
+
+	tf.TenantID = user.TenantID
+	tf.UserID = user.ID
+	tf.Secret = secret
+	tf.LastUsedStep = 0
+	if err := h.DB.Save(&tf).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to start two-factor setup",
+			},
+		})
+		return
+	}
+
+	issuer := models.GetSetting(h.DB, "site_name", "GritCMS")
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"secret":      secret,
+			"otpauth_uri": services.TOTPURI(issuer, user.Email, secret),
+		},
+	})
+}
+
+// Enable confirms setup with a code from the authenticator app, turns 2FA on
+// and returns the recovery codes. They are only ever shown here.
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	var tf models.UserTwoFactor
+	if err := h.DB.Where("user_id = ?", userID).First(&tf).Error; err != nil || tf.Enabled() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "SETUP_NOT_STARTED",
+				"message": "Start two-factor setup first",
+			},
+		})
+		return
+	}
+
+	step, ok := services.ValidateTOTP(tf.Secret, req.Code, time.Now())
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_CODE",
+				"message": "That code didn't match. Check your device's clock and try again.",
+			},
+		})
+		return
+	}
+
+	now := time.Now()
+	if err := h.DB.Model(&tf).Updates(map[string]interface{}{
+		"enabled_at":      now,
+		"last_used_step":  step,
+		"failed_attempts": 0,
+		"locked_until":    nil,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to enable two-factor authentication",
+			},
+		})
+		return
+	}
+
+	codes, err := services.GenerateRecoveryCodes(h.DB, userID)
+	if err != nil {
+		log.Printf("[2FA] Failed to generate recovery codes for user %d: %v", userID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    gin.H{"recovery_codes": codes},
+		"message": "Two-factor authentication enabled",
+	})
+}
+
+// Disable turns 2FA off. It takes a current code, so a stolen session alone
+// can't remove the second factor.
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if !h.checkCode(c, userID) {
+		return
+	}
+
+	if err := h.reset(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to disable two-factor authentication",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes replaces the current user's recovery codes.
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if !h.checkCode(c, userID) {
+		return
+	}
+
+	codes, err := services.GenerateRecoveryCodes(h.DB, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to generate recovery codes",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    gin.H{"recovery_codes": codes},
+		"message": "Recovery codes regenerated",
+	})
+}
+
+// ResetForUser turns off 2FA for another user who has lost their device
+// (admin only). They are signed out everywhere and can enroll again.
+func (h *TwoFactorHandler) ResetForUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	var user models.User
+	if err != nil || h.DB.First(&user, id).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "User not found",
+			},
+		})
+		return
+	}
+
+	if err := h.reset(user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to reset two-factor authentication",
+			},
+		})
+		return
+	}
+	services.RevokeUserSessions(h.DB, user.ID, models.SessionRevokedByAdmin)
+
+	log.Printf("[2FA] User %d reset two-factor authentication for user %d", c.GetUint("user_id"), user.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset"})
+}
+
+// checkCode binds a code from the request and verifies it as the user's
+// second factor, writing the error response if it fails.
+func (h *TwoFactorHandler) checkCode(c *gin.Context, userID uint) bool {
+	var req twoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+	if err := services.VerifySecondFactor(h.DB, userID, req.Code); err != nil {
+		respondTwoFactorError(c, err)
+		return false
+	}
+	return true
+}
+
+func (h *TwoFactorHandler) reset(userID uint) error {
+	return h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCode{}).Error
+	})
+}
+
+// respondTwoFactorError writes the response for a failed second factor.
+func respondTwoFactorError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrTwoFactorLocked) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "TWO_FACTOR_LOCKED",
+				"message": "Too many incorrect codes. Please wait 15 minutes and try again.",
+			},
+		})
+		return
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"error": gin.H{
+			"code":    "INVALID_CODE",
+			"message": "Invalid authentication code",
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"log"
 	"math"
 	"net/http"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
 // UserHandler handles user management endpoints.
@@ -257,6 +259,13 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// A deactivated user is signed out everywhere straight away.
+	if req.Active != nil && !*req.Active {
+		if _, err := services.RevokeUserSessions(h.DB, user.ID, models.SessionRevokedDeactivated); err != nil {
+			log.Printf("[Users] Failed to revoke sessions for user %d: %v", user.ID, err)
+		}
+	}
+
 	// Reload to get updated values
 	h.DB.First(&user, id)
 
@@ -290,6 +299,7 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		})
 		return
 	}
+	services.RevokeUserSessions(h.DB, user.ID, models.SessionRevokedDeleted)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "User deleted successfully",
@@ -358,8 +368,10 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	if req.LastName != "" {
 		updates["last_name"] = req.LastName
 	}
-	if req.Email != "" {
+	if req.Email != "" && req.Email != user.Email {
+		// A new address has to be verified again.
 		updates["email"] = req.Email
+		updates["email_verified_at"] = nil
 	}
 	if req.Password != "" {
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -426,6 +438,7 @@ func (h *UserHandler) DeleteProfile(c *gin.Context) {
 		})
 		return
 	}
+	services.RevokeUserSessions(h.DB, user.ID, models.SessionRevokedDeleted)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Account deleted successfully",
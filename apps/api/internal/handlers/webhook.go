@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/models"
+)
+
+// WebhookHandler manages outbound webhook endpoints and their delivery log.
+type WebhookHandler struct {
+	DB   *gorm.DB
+	Jobs *jobs.Client
+}
+
+func NewWebhookHandler(db *gorm.DB, jobClient *jobs.Client) *WebhookHandler {
+	return &WebhookHandler{DB: db, Jobs: jobClient}
+}
+
+type webhookEndpointInput struct {
+	Name        *string   `json:"name"`
+	URL         *string   `json:"url"`
+	Description *string   `json:"description"`
+	Events      *[]string `json:"events"`
+	Active      *bool     `json:"active"`
+}
+
+// ListEvents returns the event names an endpoint can subscribe to.
+func (h *WebhookHandler) ListEvents(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": append([]string{models.WebhookAllEvents}, events.All...)})
+}
+
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	var endpoints []models.WebhookEndpoint
+	h.DB.Order("created_at DESC").Find(&endpoints)
+	c.JSON(http.StatusOK, gin.H{"data": endpoints})
+}
+
+func (h *WebhookHandler) GetEndpoint(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var endpoint models.WebhookEndpoint
+	if err := h.DB.First(&endpoint, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": endpoint})
+}
+
+// CreateEndpoint registers an endpoint and generates its signing secret.
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var input webhookEndpointInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Name == nil || *input.Name == "" || input.URL == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name and url are required"})
+		return
+	}
+
+	endpoint := models.WebhookEndpoint{
+		TenantID: 1,
+		Secret:   models.NewWebhookSecret(),
+		Active:   true,
+		Events:   datatypes.JSON("[]"),
+	}
+	if msg := applyWebhookEndpointInput(&endpoint, input); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if err := h.DB.Create(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook endpoint"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": endpoint})
+}
+
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var endpoint models.WebhookEndpoint
+	if err := h.DB.First(&endpoint, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+		return
+	}
+	var input webhookEndpointInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := applyWebhookEndpointInput(&endpoint, input); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if err := h.DB.Save(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook endpoint"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": endpoint})
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.DB.Delete(&models.WebhookEndpoint{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook endpoint"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook endpoint deleted"})
+}
+
+// RotateSecret replaces an endpoint's signing secret. Deliveries already
+// queued are signed with the new secret when they go out.
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var endpoint models.WebhookEndpoint
+	if err := h.DB.First(&endpoint, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+		return
+	}
+	endpoint.Secret = models.NewWebhookSecret()
+	h.DB.Model(&endpoint).Update("secret", endpoint.Secret)
+	c.JSON(http.StatusOK, gin.H{"data": endpoint})
+}
+
+// ListDeliveries lists the delivery log, newest first. Filter with
+// ?endpoint_id=, ?event= and ?status=.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize := 20
+	if page < 1 {
+		page = 1
+	}
+
+	var total int64
+	q := h.DB.Model(&models.WebhookDelivery{})
+
+	if eid := c.Query("endpoint_id"); eid != "" {
+		q = q.Where("endpoint_id = ?", eid)
+	}
+	if ev := c.Query("event"); ev != "" {
+		q = q.Where("event = ?", ev)
+	}
+	if st := c.Query("status"); st != "" {
+		q = q.Where("status = ?", st)
+	}
+
+	q.Count(&total)
+
+	var deliveries []models.WebhookDelivery
+	q.Omit("payload", "response_body").
+		Preload("Endpoint", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select("id", "name", "url")
+		}).
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&deliveries)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+		"meta": gin.H{
+			"total": total, "page": page, "page_size": pageSize,
+			"pages": int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	})
+}
+
+// GetDelivery returns a delivery with its payload and the response body.
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("deliveryId"))
+	var delivery models.WebhookDelivery
+	if err := h.DB.Preload("Endpoint", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "name", "url")
+	}).First(&delivery, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": delivery})
+}
+
+// Redeliver sends a delivery's payload to its endpoint again. The resend is
+// logged as a new delivery carrying the same event ID, so receivers can
+// de-duplicate.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	if h.Jobs == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Job queue not configured"})
+		return
+	}
+	id, _ := strconv.Atoi(c.Param("deliveryId"))
+	var original models.WebhookDelivery
+	if err := h.DB.First(&original, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+	var endpoint models.WebhookEndpoint
+	if err := h.DB.First(&endpoint, original.EndpointID).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The endpoint for this delivery was deleted"})
+		return
+	}
+	if !endpoint.Active {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Enable the endpoint before redelivering"})
+		return
+	}
+
+	delivery, err := jobs.QueueWebhookDelivery(h.DB, h.Jobs, endpoint.ID, original.EventID, original.Event, original.Payload)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue redelivery"})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"data": delivery})
+}
+
+// applyWebhookEndpointInput copies the fields that were sent onto endpoint
+// and returns a validation message, or "" if the input is valid.
+func applyWebhookEndpointInput(endpoint *models.WebhookEndpoint, input webhookEndpointInput) string {
+	if input.Name != nil {
+		if *input.Name == "" {
+			return "name is required"
+		}
+		endpoint.Name = *input.Name
+	}
+	if input.URL != nil {
+		u, err := url.Parse(*input.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "url must be an absolute http or https URL"
+		}
+		endpoint.URL = *input.URL
+	}
+	if input.Description != nil {
+		endpoint.Description = *input.Description
+	}
+	if input.Active != nil {
+		endpoint.Active = *input.Active
+	}
+	if input.Events != nil {
+		known := map[string]bool{models.WebhookAllEvents: true}
+		for _, e := range events.All {
+			known[e] = true
+		}
+		names := []string{}
+		for _, e := range *input.Events {
+			if !known[e] {
+				return "Unknown event: " + e
+			}
+			names = append(names, e)
+		}
+		raw, _ := json.Marshal(names)
+		endpoint.Events = datatypes.JSON(raw)
+	}
+	return ""
+}
@@ -1,23 +1,29 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
+	"gritcms/apps/api/internal/jobs"
 	"gritcms/apps/api/internal/models"
+	"gritcms/apps/api/internal/services"
 )
 
 type WorkflowHandler struct {
-	DB *gorm.DB
+	DB       *gorm.DB
+	Jobs     *jobs.Client
+	Triggers *services.WorkflowTriggers
 }
 
-func NewWorkflowHandler(db *gorm.DB) *WorkflowHandler {
-	return &WorkflowHandler{DB: db}
+func NewWorkflowHandler(db *gorm.DB, jobClient *jobs.Client, triggers *services.WorkflowTriggers) *WorkflowHandler {
+	return &WorkflowHandler{DB: db, Jobs: jobClient, Triggers: triggers}
 }
 
 // ---------- Workflows CRUD ----------
@@ -81,10 +87,17 @@ func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
 	if body.Status == "" {
 		body.Status = models.WorkflowStatusDraft
 	}
+	if body.TriggerType == models.TriggerSchedule {
+		if err := validateWorkflowSchedule(body.TriggerConfig, body.Status); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 	if err := h.DB.Create(&body).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create workflow"})
 		return
 	}
+	h.Triggers.Reload()
 	c.JSON(http.StatusCreated, gin.H{"data": body})
 }
 
@@ -101,7 +114,31 @@ func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
 		return
 	}
 	sanitizeUpdates(body)
+	delete(body, "last_run_at")
+
+	triggerType := workflow.TriggerType
+	if v, ok := body["trigger_type"].(string); ok {
+		triggerType = v
+	}
+	triggerConfig := workflow.TriggerConfig
+	if v, ok := body["trigger_config"]; ok {
+		raw, _ := json.Marshal(v)
+		triggerConfig = datatypes.JSON(raw)
+		body["trigger_config"] = triggerConfig
+	}
+	status := workflow.Status
+	if v, ok := body["status"].(string); ok {
+		status = v
+	}
+	if triggerType == models.TriggerSchedule {
+		if err := validateWorkflowSchedule(triggerConfig, status); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	h.DB.Model(&workflow).Updates(body)
+	h.Triggers.Reload()
 	h.DB.Preload("Actions", func(db *gorm.DB) *gorm.DB {
 		return db.Order("sort_order ASC")
 	}).First(&workflow, id)
@@ -114,9 +151,23 @@ func (h *WorkflowHandler) DeleteWorkflow(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete workflow"})
 		return
 	}
+	h.Triggers.Reload()
 	c.JSON(http.StatusOK, gin.H{"message": "Workflow deleted"})
 }
 
+// validateWorkflowSchedule checks a schedule trigger's cron expression, and that
+// it has an audience before the workflow can be active.
+func validateWorkflowSchedule(triggerConfig datatypes.JSON, status string) error {
+	cfg, _, err := jobs.ParseWorkflowSchedule(triggerConfig)
+	if err != nil {
+		return err
+	}
+	if status == models.WorkflowStatusActive && !cfg.HasAudience() {
+		return fmt.Errorf("a scheduled workflow needs a segment_id, tag_id or tag audience")
+	}
+	return nil
+}
+
 // ---------- Actions ----------
 
 func (h *WorkflowHandler) CreateAction(c *gin.Context) {
@@ -249,19 +300,22 @@ func (h *WorkflowHandler) TriggerWorkflow(c *gin.Context) {
 		return
 	}
 
-	now := time.Now()
-	exec := models.WorkflowExecution{
-		TenantID:     1,
-		WorkflowID:   uint(workflowID),
-		ContactID:    body.ContactID,
-		TriggerEvent: "manual",
-		Status:       models.ExecutionRunning,
-		StartedAt:    now,
+	var contact models.Contact
+	if err := h.DB.First(&contact, body.ContactID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+		return
+	}
+
+	if h.Jobs == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Job queue not configured"})
+		return
 	}
-	h.DB.Create(&exec)
 
-	// Increment execution count
-	h.DB.Model(&workflow).UpdateColumn("execution_count", gorm.Expr("execution_count + 1"))
+	exec, err := jobs.StartWorkflow(h.DB, h.Jobs, workflow, contact.ID, "manual")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start workflow"})
+		return
+	}
 
 	c.JSON(http.StatusCreated, gin.H{"data": exec})
 }
@@ -29,7 +29,7 @@ func (s *GoogleCalendarService) GetOAuthConfig() *oauth2.Config {
 		ClientID:     s.ClientID,
 		ClientSecret: s.ClientSecret,
 		RedirectURL:  s.RedirectURL,
-		Scopes:       []string{gcal.CalendarEventsScope},
+		Scopes:       []string{gcal.CalendarEventsScope, gcal.CalendarFreebusyScope},
 		Endpoint:     google.Endpoint,
 	}
 }
@@ -229,6 +229,56 @@ func (s *GoogleCalendarService) DeleteEvent(googleEventID string) error {
 	return nil
 }
 
+// BusyPeriod is a span of time a calendar is busy.
+type BusyPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// BusyTimes returns when a Google calendar is busy between from and to. An
+// empty calendarID checks the connected account's default calendar.
+func (s *GoogleCalendarService) BusyTimes(calendarID string, from, to time.Time) ([]BusyPeriod, error) {
+	srv, err := s.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if calendarID == "" {
+		calendarID = s.getSetting("google_calendar_id")
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	resp, err := srv.Freebusy.Query(&gcal.FreeBusyRequest{
+		TimeMin: from.Format(time.RFC3339),
+		TimeMax: to.Format(time.RFC3339),
+		Items:   []*gcal.FreeBusyRequestItem{{Id: calendarID}},
+	}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query free/busy: %w", err)
+	}
+
+	cal, ok := resp.Calendars[calendarID]
+	if !ok {
+		return nil, nil
+	}
+	if len(cal.Errors) > 0 {
+		return nil, fmt.Errorf("free/busy for %s: %s", calendarID, cal.Errors[0].Reason)
+	}
+
+	var busy []BusyPeriod
+	for _, p := range cal.Busy {
+		start, err1 := time.Parse(time.RFC3339, p.Start)
+		end, err2 := time.Parse(time.RFC3339, p.End)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		busy = append(busy, BusyPeriod{Start: start, End: end})
+	}
+	return busy, nil
+}
+
 func (s *GoogleCalendarService) getSetting(key string) string {
 	var setting models.Setting
 	if err := s.DB.Where("tenant_id = ? AND key = ?", 1, key).First(&setting).Error; err != nil {
@@ -0,0 +1,379 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/mail"
+	"gritcms/apps/api/internal/models"
+)
+
+// Booking email kinds, sent as BookingEmailPayload.Kind.
+const (
+	BookingEmailConfirmed   = "confirmed"
+	BookingEmailRescheduled = "rescheduled"
+	BookingEmailCancelled   = "cancelled"
+)
+
+// ScheduleAppointmentReminders replaces an appointment's scheduled reminders
+// with one per offset on its event type. Offsets that have already passed
+// are skipped.
+func ScheduleAppointmentReminders(db *gorm.DB, client *Client, appt *models.Appointment, et *models.BookingEventType) error {
+	if client == nil {
+		return fmt.Errorf("job queue not configured")
+	}
+	CancelAppointmentReminders(db, client, appt.ID, models.ReminderKindReminder)
+
+	now := time.Now()
+	for _, offset := range et.ReminderMinutes() {
+		sendAt := appt.StartAt.Add(-time.Duration(offset) * time.Minute)
+		if !sendAt.After(now) {
+			continue
+		}
+		if err := scheduleReminder(db, client, appt, models.ReminderKindReminder, offset, sendAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScheduleAppointmentFollowUp queues the event type's follow-up email for a
+// completed appointment, if it has one.
+func ScheduleAppointmentFollowUp(db *gorm.DB, client *Client, appt *models.Appointment, et *models.BookingEventType) error {
+	if !et.FollowUpEnabled {
+		return nil
+	}
+	if client == nil {
+		return fmt.Errorf("job queue not configured")
+	}
+	CancelAppointmentReminders(db, client, appt.ID, models.ReminderKindFollowUp)
+
+	delay := et.FollowUpDelayMinutes
+	if delay < 0 {
+		delay = 0
+	}
+	sendAt := time.Now().Add(time.Duration(delay) * time.Minute)
+	return scheduleReminder(db, client, appt, models.ReminderKindFollowUp, delay, sendAt)
+}
+
+// CancelAppointmentReminders cancels an appointment's scheduled reminders of
+// the given kind, or of every kind when kind is empty.
+func CancelAppointmentReminders(db *gorm.DB, client *Client, appointmentID uint, kind string) {
+	q := db.Where("appointment_id = ? AND status = ?", appointmentID, models.ReminderScheduled)
+	if kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+	var reminders []models.AppointmentReminder
+	q.Find(&reminders)
+
+	for _, r := range reminders {
+		db.Model(&models.AppointmentReminder{}).
+			Where("id = ? AND status = ?", r.ID, models.ReminderScheduled).
+			Update("status", models.ReminderCancelled)
+		// The worker skips cancelled rows anyway; deleting the task just
+		// keeps the queue tidy.
+		if client != nil && r.TaskID != "" {
+			if err := client.DeleteTask(r.TaskID); err != nil {
+				log.Printf("Failed to delete reminder task %s: %v", r.TaskID, err)
+			}
+		}
+	}
+}
+
+func scheduleReminder(db *gorm.DB, client *Client, appt *models.Appointment, kind string, offset int, sendAt time.Time) error {
+	reminder := models.AppointmentReminder{
+		TenantID:      appt.TenantID,
+		AppointmentID: appt.ID,
+		Kind:          kind,
+		OffsetMinutes: offset,
+		SendAt:        sendAt,
+		Status:        models.ReminderScheduled,
+	}
+	if err := db.Create(&reminder).Error; err != nil {
+		return fmt.Errorf("creating appointment reminder: %w", err)
+	}
+
+	taskID, err := client.EnqueueBookingReminder(reminder.ID, sendAt)
+	if err != nil {
+		db.Model(&reminder).Update("status", models.ReminderCancelled)
+		return err
+	}
+	db.Model(&reminder).Update("task_id", taskID)
+	return nil
+}
+
+func handleBookingEmail(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Mailer == nil {
+			return fmt.Errorf("mailer not configured")
+		}
+
+		var payload BookingEmailPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshaling booking email payload: %w", err)
+		}
+
+		appt, err := loadBookedAppointment(deps.DB, payload.AppointmentID)
+		if err != nil {
+			log.Printf("Skipping booking email for appointment %d: %v", payload.AppointmentID, err)
+			return nil
+		}
+
+		b := newBookingMail(deps, appt)
+		var title, message string
+		switch payload.Kind {
+		case BookingEmailConfirmed:
+			title = fmt.Sprintf("Your %s is confirmed", b.eventName)
+			message = fmt.Sprintf("Hi %s, you're booked for %s on %s. The calendar invite is attached.", b.greeting, b.eventName, b.when)
+		case BookingEmailRescheduled:
+			title = fmt.Sprintf("Your %s has been rescheduled", b.eventName)
+			message = fmt.Sprintf("Hi %s, your %s is now on %s. The updated calendar invite is attached.", b.greeting, b.eventName, b.when)
+		case BookingEmailCancelled:
+			title = fmt.Sprintf("Your %s has been cancelled", b.eventName)
+			message = fmt.Sprintf("Hi %s, your %s on %s has been cancelled.", b.greeting, b.eventName, b.when)
+		default:
+			return fmt.Errorf("unknown booking email kind %q", payload.Kind)
+		}
+		return b.send(ctx, title, message, payload.Kind != BookingEmailCancelled, true)
+	}
+}
+
+func handleBookingReminder(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Mailer == nil {
+			return fmt.Errorf("mailer not configured")
+		}
+
+		var payload BookingReminderPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshaling booking reminder payload: %w", err)
+		}
+
+		var reminder models.AppointmentReminder
+		if err := deps.DB.First(&reminder, payload.ReminderID).Error; err != nil || reminder.Status != models.ReminderScheduled {
+			return nil
+		}
+		appt, err := loadBookedAppointment(deps.DB, reminder.AppointmentID)
+		wantStatus := models.AppointmentConfirmed
+		if reminder.Kind == models.ReminderKindFollowUp {
+			wantStatus = models.AppointmentCompleted
+		}
+		if err != nil || appt.Status != wantStatus {
+			deps.DB.Model(&reminder).Update("status", models.ReminderCancelled)
+			return nil
+		}
+
+		// Claim the reminder so a duplicate delivery doesn't send it twice.
+		now := time.Now()
+		res := deps.DB.Model(&models.AppointmentReminder{}).
+			Where("id = ? AND status = ?", reminder.ID, models.ReminderScheduled).
+			Updates(map[string]interface{}{"status": models.ReminderSent, "sent_at": now})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return nil
+		}
+
+		b := newBookingMail(deps, appt)
+		if reminder.Kind == models.ReminderKindFollowUp {
+			et := appt.EventType
+			title := et.FollowUpSubject
+			if title == "" {
+				title = fmt.Sprintf("Thanks for your %s", b.eventName)
+			}
+			message := et.FollowUpMessage
+			if message == "" {
+				message = fmt.Sprintf("Hi %s, thanks for joining your %s on %s. We hope it was useful.", b.greeting, b.eventName, b.when)
+			}
+			err = b.send(ctx, title, message, false, false)
+		} else {
+			title := fmt.Sprintf("Reminder: your %s is %s", b.eventName, reminderLead(reminder.OffsetMinutes))
+			message := fmt.Sprintf("Hi %s, this is a reminder that your %s is on %s.", b.greeting, b.eventName, b.when)
+			err = b.send(ctx, title, message, true, true)
+		}
+		if err != nil {
+			// Release the claim so the retry can send it.
+			deps.DB.Model(&reminder).Updates(map[string]interface{}{"status": models.ReminderScheduled, "sent_at": nil})
+			return err
+		}
+		return nil
+	}
+}
+
+// handleBookingReleaseHolds cancels paid bookings whose payment hold ran out,
+// freeing their slots, and fails their unpaid orders.
+func handleBookingReleaseHolds(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+
+		var expired []models.Appointment
+		deps.DB.Where("status = ? AND hold_expires_at < ?", models.AppointmentPending, time.Now()).Find(&expired)
+
+		released := 0
+		for _, appt := range expired {
+			res := deps.DB.Model(&models.Appointment{}).
+				Where("id = ? AND status = ?", appt.ID, models.AppointmentPending).
+				Update("status", models.AppointmentCancelled)
+			if res.Error != nil || res.RowsAffected == 0 {
+				continue
+			}
+			if appt.OrderID != nil {
+				deps.DB.Model(&models.Order{}).
+					Where("id = ? AND status = ?", *appt.OrderID, models.OrderStatusPending).
+					Update("status", models.OrderStatusFailed)
+			}
+			released++
+		}
+
+		if released > 0 {
+			log.Printf("Released %d unpaid booking holds", released)
+		}
+		return nil
+	}
+}
+
+// loadBookedAppointment loads an appointment with its event type, calendar
+// and contact.
+func loadBookedAppointment(db *gorm.DB, id uint) (*models.Appointment, error) {
+	var appt models.Appointment
+	if err := db.Preload("EventType.Calendar").Preload("Contact").First(&appt, id).Error; err != nil {
+		return nil, err
+	}
+	if appt.EventType == nil || appt.Contact == nil {
+		return nil, fmt.Errorf("appointment %d is missing its event type or contact", id)
+	}
+	if appt.Contact.Email == "" {
+		return nil, fmt.Errorf("contact %d has no email", appt.ContactID)
+	}
+	return &appt, nil
+}
+
+// reminderLead describes how far ahead a reminder goes out, e.g. "in 1 hour".
+func reminderLead(minutes int) string {
+	switch {
+	case minutes%1440 == 0 && minutes >= 1440:
+		if minutes == 1440 {
+			return "tomorrow"
+		}
+		return fmt.Sprintf("in %d days", minutes/1440)
+	case minutes%60 == 0:
+		if minutes == 60 {
+			return "in 1 hour"
+		}
+		return fmt.Sprintf("in %d hours", minutes/60)
+	}
+	return fmt.Sprintf("in %d minutes", minutes)
+}
+
+// bookingMail holds what every appointment email shares.
+type bookingMail struct {
+	deps      WorkerDeps
+	appt      *models.Appointment
+	siteName  string
+	siteURL   string
+	eventName string
+	greeting  string
+	when      string
+}
+
+func newBookingMail(deps WorkerDeps, appt *models.Appointment) *bookingMail {
+	loc := time.UTC
+	if appt.EventType.Calendar != nil && appt.EventType.Calendar.Timezone != "" {
+		if l, err := time.LoadLocation(appt.EventType.Calendar.Timezone); err == nil {
+			loc = l
+		}
+	}
+	greeting := appt.Contact.FirstName
+	if greeting == "" {
+		greeting = "there"
+	}
+	return &bookingMail{
+		deps:      deps,
+		appt:      appt,
+		siteName:  models.GetSetting(deps.DB, "site_name", "GritCMS"),
+		siteURL:   strings.TrimRight(models.GetSetting(deps.DB, "site_url", ""), "/"),
+		eventName: appt.EventType.Name,
+		greeting:  greeting,
+		when:      appt.StartAt.In(loc).Format("Monday, January 2, 2006 at 3:04 PM MST"),
+	}
+}
+
+// send emails the contact. withJoin adds the meeting link as the button and
+// withInvite attaches the .ics invite (a cancellation once the appointment is
+// cancelled).
+func (b *bookingMail) send(ctx context.Context, title, message string, withJoin, withInvite bool) error {
+	data := map[string]interface{}{
+		"AppName": b.siteName,
+		"Year":    time.Now().Year(),
+		"Title":   title,
+		"Message": message,
+	}
+	if withJoin && b.appt.MeetingURL != "" {
+		data["ActionURL"] = b.appt.MeetingURL
+		data["ActionText"] = "Join meeting"
+	}
+
+	opts := mail.SendOptions{
+		To:       b.appt.Contact.Email,
+		Subject:  title,
+		Template: "notification",
+		Data:     data,
+	}
+	if withInvite {
+		opts.Attachments = []mail.Attachment{b.calendarEvent().Attachment()}
+	}
+
+	err := b.deps.Mailer.Send(ctx, opts)
+	if errors.Is(err, mail.ErrSuppressed) {
+		log.Printf("Skipping booking email to suppressed address %s", opts.To)
+		return nil
+	}
+	return err
+}
+
+func (b *bookingMail) calendarEvent() mail.CalendarEvent {
+	host := "gritcms"
+	if u, err := url.Parse(b.siteURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	organizerName, organizerEmail := b.siteName, b.deps.Mailer.From()
+	if addr, err := netmail.ParseAddress(organizerEmail); err == nil {
+		organizerEmail = addr.Address
+		if addr.Name != "" {
+			organizerName = addr.Name
+		}
+	}
+
+	contact := b.appt.Contact
+	return mail.CalendarEvent{
+		UID:            fmt.Sprintf("appointment-%d@%s", b.appt.ID, host),
+		Sequence:       b.appt.Sequence,
+		Summary:        fmt.Sprintf("%s with %s", b.eventName, b.siteName),
+		Description:    b.appt.EventType.Description,
+		Location:       b.appt.MeetingURL,
+		URL:            b.appt.MeetingURL,
+		Start:          b.appt.StartAt,
+		End:            b.appt.EndAt,
+		OrganizerName:  organizerName,
+		OrganizerEmail: organizerEmail,
+		AttendeeName:   strings.TrimSpace(contact.FirstName + " " + contact.LastName),
+		AttendeeEmail:  contact.Email,
+		Cancelled:      b.appt.Status == models.AppointmentCancelled,
+	}
+}
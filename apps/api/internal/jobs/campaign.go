@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"encoding/json"
+	"log"
+	"math/rand"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+)
+
+// campaignContent is the subject and body sent to one recipient, tagged with
+// the A/B variant it came from.
+type campaignContent struct {
+	Variant string
+	Subject string
+	HTML    string
+}
+
+// campaignVariants resolves a campaign's A/B variants, filling gaps from the
+// campaign's own subject and content. It returns nil unless the campaign has
+// at least two usable variants.
+func campaignVariants(db *gorm.DB, campaign models.EmailCampaign, subject, htmlContent string) []campaignContent {
+	if campaign.Variants == nil {
+		return nil
+	}
+	var variants []models.CampaignVariant
+	if err := json.Unmarshal(campaign.Variants, &variants); err != nil {
+		return nil
+	}
+
+	var result []campaignContent
+	for _, v := range variants {
+		content := campaignContent{Variant: v.Key, Subject: v.Subject, HTML: v.HTMLContent}
+		if v.TemplateID != nil && (content.HTML == "" || content.Subject == "") {
+			var tmpl models.EmailTemplate
+			if err := db.First(&tmpl, *v.TemplateID).Error; err == nil {
+				if content.HTML == "" {
+					content.HTML = tmpl.HTMLContent
+				}
+				if content.Subject == "" {
+					content.Subject = tmpl.Subject
+				}
+			}
+		}
+		if content.Subject == "" {
+			content.Subject = subject
+		}
+		if content.HTML == "" {
+			content.HTML = htmlContent
+		}
+		if content.Variant == "" || content.HTML == "" {
+			continue
+		}
+		result = append(result, content)
+	}
+	if len(result) < 2 {
+		return nil
+	}
+	return result
+}
+
+// abTestSample picks a random percent of contacts, at least one per variant.
+func abTestSample(contacts []models.Contact, percent, variants int) []models.Contact {
+	if percent <= 0 || percent > 100 {
+		percent = 20
+	}
+	n := (len(contacts)*percent + 99) / 100
+	if n < variants {
+		n = variants
+	}
+	if n > len(contacts) {
+		n = len(contacts)
+	}
+	rand.Shuffle(len(contacts), func(i, j int) {
+		contacts[i], contacts[j] = contacts[j], contacts[i]
+	})
+	return contacts[:n]
+}
+
+// pickCampaignWinners chooses the best variant of every A/B test whose wait
+// window has ended and queues the campaign to send it to the rest of the audience.
+func pickCampaignWinners(deps WorkerDeps) {
+	var campaigns []models.EmailCampaign
+	deps.DB.Where("status = ? AND test_ends_at <= ?", models.CampaignStatusTesting, time.Now()).Find(&campaigns)
+
+	for _, campaign := range campaigns {
+		stats := models.ComputeVariantStats(deps.DB, campaign.ID)
+
+		var variants []models.CampaignVariant
+		_ = json.Unmarshal(campaign.Variants, &variants)
+
+		// Ties go to the earlier variant.
+		winner := ""
+		best := -1.0
+		for _, v := range variants {
+			vs, ok := stats[v.Key]
+			if !ok {
+				continue
+			}
+			rate := vs.OpenRate
+			if campaign.WinnerMetric == models.WinnerMetricClickRate {
+				rate = vs.ClickRate
+			}
+			if rate > best {
+				winner, best = v.Key, rate
+			}
+		}
+		if winner == "" && len(variants) > 0 {
+			winner, best = variants[0].Key, 0
+		}
+
+		statsJSON, _ := json.Marshal(stats)
+		res := deps.DB.Model(&models.EmailCampaign{}).
+			Where("id = ? AND status = ?", campaign.ID, models.CampaignStatusTesting).
+			Updates(map[string]interface{}{
+				"status":          models.CampaignStatusSending,
+				"winning_variant": winner,
+				"variant_stats":   statsJSON,
+			})
+		if res.RowsAffected == 0 {
+			continue // picked by another worker
+		}
+
+		log.Printf("Campaign %d A/B test finished, variant %s wins on %s (%.1f%%)",
+			campaign.ID, winner, campaign.WinnerMetric, best*100)
+
+		if deps.Jobs != nil {
+			if err := deps.Jobs.EnqueueCampaignProcess(campaign.ID); err != nil {
+				log.Printf("Failed to enqueue campaign %d: %v", campaign.ID, err)
+			}
+		}
+	}
+}
@@ -2,7 +2,9 @@ package jobs
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 )
@@ -14,11 +16,21 @@ const (
 	TypeTokensCleanup   = "tokens:cleanup"
 	TypeCampaignProcess        = "campaign:process"
 	TypeCampaignCheckScheduled = "campaign:check-scheduled"
+	TypeWorkflowRun            = "workflow:run"
+	TypeWorkflowCheckScheduled = "workflow:check-scheduled"
+	TypeSequenceProcess        = "sequence:process"
+	TypeCourseDripNotify       = "course:drip-notify"
+	TypeCommunityDigest        = "community:digest"
+	TypeBookingEmail           = "booking:email"
+	TypeBookingReminder        = "booking:reminder"
+	TypeBookingReleaseHolds    = "booking:release-holds"
+	TypeWebhookDeliver         = "webhook:deliver"
 )
 
 // Client wraps asynq.Client for enqueuing background jobs.
 type Client struct {
-	client *asynq.Client
+	client    *asynq.Client
+	inspector *asynq.Inspector
 }
 
 // NewClient creates a new job queue client connected to Redis.
@@ -29,14 +41,25 @@ func NewClient(redisURL string) (*Client, error) {
 	}
 
 	client := asynq.NewClient(redisOpt)
-	return &Client{client: client}, nil
+	return &Client{client: client, inspector: asynq.NewInspector(redisOpt)}, nil
 }
 
 // Close shuts down the client connection.
 func (c *Client) Close() error {
+	c.inspector.Close()
 	return c.client.Close()
 }
 
+// DeleteTask removes a scheduled or pending task from the default queue.
+// It is a no-op if the task already ran.
+func (c *Client) DeleteTask(taskID string) error {
+	err := c.inspector.DeleteTask("default", taskID)
+	if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) && !errors.Is(err, asynq.ErrQueueNotFound) {
+		return fmt.Errorf("deleting task %s: %w", taskID, err)
+	}
+	return nil
+}
+
 // EmailPayload holds the data for an email send job.
 type EmailPayload struct {
 	To       string                 `json:"to"`
@@ -111,6 +134,34 @@ func (c *Client) EnqueueCampaignProcess(campaignID uint) error {
 	return nil
 }
 
+// WorkflowPayload holds the data for a workflow execution job.
+// Resumed is set when the job was scheduled to honor the current step's delay,
+// so the worker runs that step instead of scheduling it again.
+type WorkflowPayload struct {
+	ExecutionID uint `json:"execution_id"`
+	Resumed     bool `json:"resumed"`
+}
+
+// EnqueueWorkflowRun enqueues a workflow execution job, optionally delayed.
+func (c *Client) EnqueueWorkflowRun(executionID uint, delay time.Duration, resumed bool) error {
+	payload, err := json.Marshal(WorkflowPayload{ExecutionID: executionID, Resumed: resumed})
+	if err != nil {
+		return fmt.Errorf("marshaling workflow payload: %w", err)
+	}
+
+	opts := []asynq.Option{asynq.MaxRetry(0), asynq.Queue("default")}
+	if delay > 0 {
+		opts = append(opts, asynq.ProcessIn(delay))
+	}
+
+	task := asynq.NewTask(TypeWorkflowRun, payload)
+	_, err = c.client.Enqueue(task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueuing workflow job: %w", err)
+	}
+	return nil
+}
+
 // EnqueueTokensCleanup enqueues a token cleanup job.
 func (c *Client) EnqueueTokensCleanup() error {
 	task := asynq.NewTask(TypeTokensCleanup, nil)
@@ -120,3 +171,68 @@ func (c *Client) EnqueueTokensCleanup() error {
 	}
 	return nil
 }
+
+// BookingEmailPayload holds the data for an appointment confirmation,
+// reschedule or cancellation email job.
+type BookingEmailPayload struct {
+	AppointmentID uint   `json:"appointment_id"`
+	Kind          string `json:"kind"` // confirmed, rescheduled, cancelled
+}
+
+// EnqueueBookingEmail enqueues an appointment email with its .ics invite.
+func (c *Client) EnqueueBookingEmail(appointmentID uint, kind string) error {
+	payload, err := json.Marshal(BookingEmailPayload{AppointmentID: appointmentID, Kind: kind})
+	if err != nil {
+		return fmt.Errorf("marshaling booking email payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeBookingEmail, payload)
+	_, err = c.client.Enqueue(task, asynq.MaxRetry(3), asynq.Queue("critical"))
+	if err != nil {
+		return fmt.Errorf("enqueuing booking email job: %w", err)
+	}
+	return nil
+}
+
+// BookingReminderPayload holds the data for a reminder or follow-up job.
+type BookingReminderPayload struct {
+	ReminderID uint `json:"reminder_id"`
+}
+
+// EnqueueBookingReminder schedules a reminder or follow-up email for sendAt
+// and returns the task ID, which DeleteTask takes to cancel it.
+func (c *Client) EnqueueBookingReminder(reminderID uint, sendAt time.Time) (string, error) {
+	payload, err := json.Marshal(BookingReminderPayload{ReminderID: reminderID})
+	if err != nil {
+		return "", fmt.Errorf("marshaling booking reminder payload: %w", err)
+	}
+
+	taskID := fmt.Sprintf("booking-reminder-%d", reminderID)
+	task := asynq.NewTask(TypeBookingReminder, payload)
+	_, err = c.client.Enqueue(task, asynq.TaskID(taskID), asynq.ProcessAt(sendAt), asynq.MaxRetry(3), asynq.Queue("default"))
+	if err != nil {
+		return "", fmt.Errorf("enqueuing booking reminder job: %w", err)
+	}
+	return taskID, nil
+}
+
+// WebhookDeliveryPayload holds the data for an outbound webhook delivery job.
+type WebhookDeliveryPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// EnqueueWebhookDelivery enqueues an outbound webhook delivery. Failed sends
+// are retried with exponential backoff (see webhookRetryDelay).
+func (c *Client) EnqueueWebhookDelivery(deliveryID uint) error {
+	payload, err := json.Marshal(WebhookDeliveryPayload{DeliveryID: deliveryID})
+	if err != nil {
+		return fmt.Errorf("marshaling webhook delivery payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeWebhookDeliver, payload)
+	_, err = c.client.Enqueue(task, asynq.MaxRetry(WebhookMaxRetry), asynq.Queue("default"))
+	if err != nil {
+		return fmt.Errorf("enqueuing webhook delivery job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"gritcms/apps/api/internal/mail"
+	"gritcms/apps/api/internal/models"
+)
+
+// digestItemLimit caps how many notifications a digest email lists.
+const digestItemLimit = 15
+
+// handleCommunityDigest emails each contact a summary of the unread community
+// notifications that weren't emailed on their own, daily or weekly as their
+// preferences ask.
+func handleCommunityDigest(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Mailer == nil {
+			return fmt.Errorf("mailer not configured")
+		}
+
+		now := time.Now()
+		var contactIDs []uint
+		deps.DB.Model(&models.CommunityNotification{}).
+			Where("read_at IS NULL AND emailed_at IS NULL AND digested_at IS NULL AND created_at > ?", now.AddDate(0, 0, -7)).
+			Distinct("contact_id").Pluck("contact_id", &contactIDs)
+
+		siteName := models.GetSetting(deps.DB, "site_name", "GritCMS")
+		siteURL := strings.TrimRight(models.GetSetting(deps.DB, "site_url", ""), "/")
+
+		sent := 0
+		for _, contactID := range contactIDs {
+			pref := models.CommunityPreferencesFor(deps.DB, contactID)
+			period := digestPeriod(pref.Digest)
+			// An hour's slack so a run that starts a little early still counts.
+			if period == 0 || (pref.LastDigestAt != nil && now.Sub(*pref.LastDigestAt) < period-time.Hour) {
+				continue
+			}
+
+			var notifications []models.CommunityNotification
+			deps.DB.Where("contact_id = ? AND read_at IS NULL AND emailed_at IS NULL AND digested_at IS NULL AND created_at > ?",
+				contactID, now.Add(-period)).
+				Order("created_at DESC").Find(&notifications)
+			if len(notifications) == 0 {
+				continue
+			}
+
+			// Claim the period so an overlapping run doesn't send it twice.
+			if pref.ID == 0 {
+				deps.DB.Where("contact_id = ?", contactID).FirstOrCreate(&pref)
+			}
+			q := deps.DB.Model(&models.CommunityNotificationPreference{}).Where("id = ?", pref.ID)
+			if pref.LastDigestAt == nil {
+				q = q.Where("last_digest_at IS NULL")
+			} else {
+				q = q.Where("last_digest_at = ?", *pref.LastDigestAt)
+			}
+			if res := q.Update("last_digest_at", now); res.Error != nil || res.RowsAffected == 0 {
+				continue
+			}
+
+			var contact models.Contact
+			if err := deps.DB.First(&contact, contactID).Error; err != nil || contact.Email == "" {
+				continue
+			}
+			if err := sendCommunityDigest(ctx, deps, &contact, pref.Digest, notifications, siteName, siteURL); err != nil {
+				log.Printf("Community digest for contact %d: %v", contactID, err)
+				continue
+			}
+
+			ids := make([]uint, len(notifications))
+			for i, n := range notifications {
+				ids[i] = n.ID
+			}
+			deps.DB.Model(&models.CommunityNotification{}).Where("id IN ?", ids).Update("digested_at", now)
+			sent++
+		}
+
+		if sent > 0 {
+			log.Printf("Sent %d community digests", sent)
+		}
+		return nil
+	}
+}
+
+// digestPeriod returns how often a digest frequency sends, or 0 for off.
+func digestPeriod(frequency string) time.Duration {
+	switch frequency {
+	case models.DigestDaily:
+		return 24 * time.Hour
+	case models.DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+func sendCommunityDigest(ctx context.Context, deps WorkerDeps, contact *models.Contact, frequency string,
+	notifications []models.CommunityNotification, siteName, siteURL string) error {
+	items := make([]map[string]interface{}, 0, digestItemLimit)
+	for i, n := range notifications {
+		if i == digestItemLimit {
+			break
+		}
+		items = append(items, map[string]interface{}{
+			"Title": n.Title,
+			"Body":  n.Body,
+			"URL":   n.URL,
+		})
+	}
+
+	when := "today"
+	if frequency == models.DigestWeekly {
+		when = "this week"
+	}
+	subject := fmt.Sprintf("%d new updates in the %s community", len(notifications), siteName)
+	if len(notifications) == 1 {
+		subject = fmt.Sprintf("1 new update in the %s community", siteName)
+	}
+
+	greeting := contact.FirstName
+	if greeting == "" {
+		greeting = "there"
+	}
+
+	err := deps.Mailer.Send(ctx, mail.SendOptions{
+		To:       contact.Email,
+		Subject:  subject,
+		Template: "community-digest",
+		Data: map[string]interface{}{
+			"AppName":    siteName,
+			"Year":       time.Now().Year(),
+			"Title":      fmt.Sprintf("Here's what you missed %s", when),
+			"Message":    fmt.Sprintf("Hi %s, there's new activity in the discussions you follow.", greeting),
+			"Items":      items,
+			"More":       len(notifications) - len(items),
+			"ActionURL":  siteURL + "/community",
+			"ActionText": "Open the community",
+		},
+	})
+	if errors.Is(err, mail.ErrSuppressed) {
+		return nil
+	}
+	return err
+}
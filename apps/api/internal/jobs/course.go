@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/mail"
+	"gritcms/apps/api/internal/models"
+)
+
+// handleCourseDripNotify emails students about lessons their drip schedule
+// opened since the last run.
+func handleCourseDripNotify(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Mailer == nil {
+			return fmt.Errorf("mailer not configured")
+		}
+
+		var courses []models.Course
+		deps.DB.Where("status = ?", models.CourseStatusPublished).
+			Preload("Modules", func(db *gorm.DB) *gorm.DB {
+				return db.Order("sort_order ASC")
+			}).
+			Preload("Modules.Lessons", func(db *gorm.DB) *gorm.DB {
+				return db.Order("sort_order ASC")
+			}).
+			Find(&courses)
+
+		siteName := models.GetSetting(deps.DB, "site_name", "GritCMS")
+		siteURL := strings.TrimRight(models.GetSetting(deps.DB, "site_url", ""), "/")
+
+		now := time.Now()
+		sent := 0
+		for i := range courses {
+			course := &courses[i]
+			if !hasDrip(course) {
+				continue
+			}
+
+			var enrollments []models.CourseEnrollment
+			deps.DB.Where("course_id = ? AND status = ?", course.ID, models.EnrollStatusActive).
+				Preload("Contact").
+				Preload("LessonProgresses").
+				Find(&enrollments)
+
+			for j := range enrollments {
+				enrollment := &enrollments[j]
+				lessons := newlyUnlockedLessons(course, enrollment, now)
+				if len(lessons) == 0 {
+					continue
+				}
+
+				// Claim the window so an overlapping run doesn't email twice.
+				q := deps.DB.Model(&models.CourseEnrollment{}).Where("id = ?", enrollment.ID)
+				if enrollment.DripNotifiedAt == nil {
+					q = q.Where("drip_notified_at IS NULL")
+				} else {
+					q = q.Where("drip_notified_at = ?", *enrollment.DripNotifiedAt)
+				}
+				if res := q.Update("drip_notified_at", now); res.Error != nil || res.RowsAffected == 0 {
+					continue
+				}
+
+				if err := sendDripEmail(ctx, deps, course, enrollment, lessons, siteName, siteURL); err != nil {
+					log.Printf("Drip notification for enrollment %d: %v", enrollment.ID, err)
+					continue
+				}
+				sent++
+			}
+		}
+
+		if sent > 0 {
+			log.Printf("Sent %d lesson unlock notifications", sent)
+		}
+		return nil
+	}
+}
+
+// hasDrip reports whether any of the course's lessons unlock on a schedule.
+func hasDrip(course *models.Course) bool {
+	for _, mod := range course.Modules {
+		for _, lesson := range mod.Lessons {
+			if lesson.DripDelayDays > 0 || (course.DripMode == models.DripModeDate && lesson.DripDate != nil) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newlyUnlockedLessons returns the lessons whose drip date passed since the
+// enrollment was last notified and that the student can now open. Lessons
+// open from the start of the enrollment are never announced.
+func newlyUnlockedLessons(course *models.Course, enrollment *models.CourseEnrollment, now time.Time) []models.Lesson {
+	since := enrollment.EnrolledAt
+	if enrollment.DripNotifiedAt != nil {
+		since = *enrollment.DripNotifiedAt
+	}
+
+	completed := models.CompletedLessonIDs(enrollment.LessonProgresses)
+	unlocked := map[uint]bool{}
+	for _, a := range models.CourseLessonAccess(course, enrollment, completed, now) {
+		unlocked[a.LessonID] = a.Unlocked
+	}
+
+	var lessons []models.Lesson
+	for _, mod := range course.Modules {
+		for i := range mod.Lessons {
+			lesson := &mod.Lessons[i]
+			if lesson.IsFreePreview || completed[lesson.ID] || !unlocked[lesson.ID] {
+				continue
+			}
+			at := models.LessonUnlockAt(course, lesson, enrollment.EnrolledAt)
+			if at != nil && at.After(since) && !at.After(now) {
+				lessons = append(lessons, *lesson)
+			}
+		}
+	}
+	return lessons
+}
+
+func sendDripEmail(ctx context.Context, deps WorkerDeps, course *models.Course, enrollment *models.CourseEnrollment, lessons []models.Lesson, siteName, siteURL string) error {
+	contact := enrollment.Contact
+	if contact.Email == "" {
+		return nil
+	}
+
+	subject := fmt.Sprintf("New lesson available: %s", lessons[0].Title)
+	message := fmt.Sprintf("\"%s\" is now unlocked in %s.", lessons[0].Title, course.Title)
+	if len(lessons) > 1 {
+		subject = fmt.Sprintf("%d new lessons available in %s", len(lessons), course.Title)
+		titles := make([]string, len(lessons))
+		for i, l := range lessons {
+			titles[i] = l.Title
+		}
+		message = fmt.Sprintf("New lessons are unlocked in %s: %s.", course.Title, strings.Join(titles, ", "))
+	}
+
+	err := deps.Mailer.Send(ctx, mail.SendOptions{
+		To:       contact.Email,
+		Subject:  subject,
+		Template: "notification",
+		Data: map[string]interface{}{
+			"AppName":    siteName,
+			"Year":       time.Now().Year(),
+			"Title":      subject,
+			"Message":    message,
+			"ActionURL":  siteURL + "/learn/" + course.Slug,
+			"ActionText": "Continue learning",
+		},
+	})
+	if errors.Is(err, mail.ErrSuppressed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/mail"
+	"gritcms/apps/api/internal/models"
+)
+
+// sequenceRetryDelay is how long a failed sequence step waits before it is retried.
+const sequenceRetryDelay = time.Hour
+
+func handleSequenceProcess(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Mailer == nil {
+			return fmt.Errorf("mailer not configured")
+		}
+
+		// Due enrollments in active sequences; paused sequences keep their place.
+		now := time.Now()
+		var enrollments []models.EmailSequenceEnrollment
+		deps.DB.Joins("JOIN email_sequences ON email_sequences.id = email_sequence_enrollments.sequence_id AND email_sequences.deleted_at IS NULL").
+			Where("email_sequence_enrollments.status = ? AND email_sequence_enrollments.next_send_at <= ? AND email_sequence_enrollments.current_step_id IS NOT NULL",
+				models.EnrollmentStatusActive, now).
+			Where("email_sequences.status = ?", models.SequenceStatusActive).
+			Preload("Contact").
+			Order("email_sequence_enrollments.next_send_at ASC").
+			Limit(500).
+			Find(&enrollments)
+
+		if len(enrollments) == 0 {
+			return nil
+		}
+
+		log.Printf("Processing %d due sequence enrollments", len(enrollments))
+
+		for _, enrollment := range enrollments {
+			// Claim the enrollment so an overlapping run doesn't send the step twice.
+			res := deps.DB.Model(&models.EmailSequenceEnrollment{}).
+				Where("id = ? AND next_send_at = ?", enrollment.ID, enrollment.NextSendAt).
+				Update("next_send_at", nil)
+			if res.Error != nil || res.RowsAffected == 0 {
+				continue
+			}
+
+			if err := processSequenceStep(ctx, deps, &enrollment); err != nil {
+				log.Printf("Sequence enrollment %d: %v", enrollment.ID, err)
+				retryAt := time.Now().Add(sequenceRetryDelay)
+				deps.DB.Model(&enrollment).Update("next_send_at", retryAt)
+			}
+		}
+
+		return nil
+	}
+}
+
+// processSequenceStep sends the enrollment's current step and moves it to the next one.
+func processSequenceStep(ctx context.Context, deps WorkerDeps, enrollment *models.EmailSequenceEnrollment) error {
+	var step models.EmailSequenceStep
+	if err := deps.DB.Unscoped().Preload("Template").First(&step, *enrollment.CurrentStepID).Error; err != nil {
+		return completeEnrollment(deps.DB, enrollment)
+	}
+	if step.DeletedAt.Valid {
+		// The step was removed after scheduling; continue after where it was.
+		return advanceSequence(deps.DB, enrollment, &step)
+	}
+
+	contact := enrollment.Contact
+	if contact.ID == 0 || contact.Email == "" {
+		log.Printf("Sequence enrollment %d: contact has no email, skipping step %d", enrollment.ID, step.ID)
+		return advanceSequence(deps.DB, enrollment, &step)
+	}
+	if models.IsEmailSuppressed(deps.DB, contact.Email) {
+		log.Printf("Sequence enrollment %d: %s is suppressed, skipping step %d", enrollment.ID, contact.Email, step.ID)
+		return advanceSequence(deps.DB, enrollment, &step)
+	}
+
+	subject := step.Subject
+	htmlContent := step.HTMLContent
+	if step.Template != nil {
+		if htmlContent == "" {
+			htmlContent = step.Template.HTMLContent
+		}
+		if subject == "" {
+			subject = step.Template.Subject
+		}
+	}
+	if htmlContent == "" && step.TextContent != "" {
+		htmlContent = "<p>" + html.EscapeString(step.TextContent) + "</p>"
+	}
+	if htmlContent == "" || subject == "" {
+		log.Printf("Sequence step %d has no subject or content, skipping", step.ID)
+		return advanceSequence(deps.DB, enrollment, &step)
+	}
+
+	subject = renderMergeTags(subject, contact)
+	htmlContent = renderMergeTags(htmlContent, contact)
+
+	now := time.Now()
+	send := models.EmailSend{
+		TenantID:       enrollment.TenantID,
+		ContactID:      contact.ID,
+		SequenceStepID: &step.ID,
+		Subject:        subject,
+		Status:         models.SendStatusQueued,
+		SentAt:         &now,
+	}
+	deps.DB.Create(&send)
+
+	messageID, err := deps.Mailer.SendCampaignEmail(ctx, mail.CampaignEmailOptions{
+		To:       contact.Email,
+		Subject:  subject,
+		HTMLBody: trackLinks(deps, send.ID, htmlContent),
+		Headers:  unsubscribeHeaders(deps, &contact),
+	})
+	if err != nil {
+		deps.DB.Model(&send).Update("status", models.SendStatusFailed)
+		return fmt.Errorf("sending step %d to %s: %w", step.ID, contact.Email, err)
+	}
+	deps.DB.Model(&send).Updates(map[string]interface{}{
+		"status":      models.SendStatusSent,
+		"external_id": messageID,
+	})
+
+	events.Emit(events.EmailSequenceStepSent, map[string]interface{}{
+		"contact_id":  contact.ID,
+		"sequence_id": enrollment.SequenceID,
+		"step_id":     step.ID,
+		"send_id":     send.ID,
+	})
+
+	return advanceSequence(deps.DB, enrollment, &step)
+}
+
+// advanceSequence points the enrollment at the step after current, scheduled by
+// that step's delay, or completes it when current was the last step.
+func advanceSequence(db *gorm.DB, enrollment *models.EmailSequenceEnrollment, current *models.EmailSequenceStep) error {
+	var next models.EmailSequenceStep
+	if err := db.Where("sequence_id = ?", enrollment.SequenceID).
+		Where("sort_order > ? OR (sort_order = ? AND id > ?)", current.SortOrder, current.SortOrder, current.ID).
+		Order("sort_order ASC, id ASC").First(&next).Error; err != nil {
+		return completeEnrollment(db, enrollment)
+	}
+
+	nextSend := time.Now().Add(time.Duration(next.DelayDays)*24*time.Hour + time.Duration(next.DelayHours)*time.Hour)
+	enrollment.CurrentStepID = &next.ID
+	enrollment.NextSendAt = &nextSend
+	if err := db.Model(enrollment).Updates(map[string]interface{}{
+		"current_step_id": next.ID,
+		"next_send_at":    nextSend,
+	}).Error; err != nil {
+		return fmt.Errorf("advancing enrollment: %w", err)
+	}
+	return nil
+}
+
+// completeEnrollment marks an enrollment as having finished its sequence.
+func completeEnrollment(db *gorm.DB, enrollment *models.EmailSequenceEnrollment) error {
+	now := time.Now()
+	enrollment.Status = models.EnrollmentStatusCompleted
+	enrollment.CompletedAt = &now
+	enrollment.NextSendAt = nil
+	if err := db.Model(enrollment).Updates(map[string]interface{}{
+		"status":       models.EnrollmentStatusCompleted,
+		"completed_at": now,
+		"next_send_at": nil,
+	}).Error; err != nil {
+		return fmt.Errorf("completing enrollment: %w", err)
+	}
+	events.Emit(events.EmailSequenceCompleted, *enrollment)
+	return nil
+}
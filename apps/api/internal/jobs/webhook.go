@@ -0,0 +1,181 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+)
+
+// WebhookMaxRetry is how many times a failed delivery is retried. With
+// webhookRetryDelay that spreads attempts over roughly eight hours.
+const WebhookMaxRetry = 10
+
+// Headers sent with every outbound webhook.
+const (
+	WebhookSignatureHeader = "X-Grit-Signature"
+	WebhookEventHeader     = "X-Grit-Event"
+	WebhookDeliveryHeader  = "X-Grit-Delivery"
+)
+
+// maxWebhookResponseBody caps how much of a response is kept in the log.
+const maxWebhookResponseBody = 2048
+
+// SignWebhook returns the X-Grit-Signature header value for a body sent at
+// timestamp: "t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">". Receivers
+// recompute v1 with the endpoint's secret and reject stale timestamps.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// QueueWebhookDelivery records a pending delivery of a payload to an endpoint
+// and enqueues it.
+func QueueWebhookDelivery(db *gorm.DB, client *Client, endpointID uint, eventID, event string, payload []byte) (*models.WebhookDelivery, error) {
+	delivery := models.WebhookDelivery{
+		TenantID:   1,
+		EndpointID: endpointID,
+		EventID:    eventID,
+		Event:      event,
+		Payload:    payload,
+		Status:     models.DeliveryPending,
+	}
+	if err := db.Create(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("creating webhook delivery: %w", err)
+	}
+	if err := client.EnqueueWebhookDelivery(delivery.ID); err != nil {
+		db.Model(&delivery).Updates(map[string]interface{}{"status": models.DeliveryFailed, "error": err.Error()})
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// retryDelay backs webhook deliveries off exponentially and leaves every
+// other task on asynq's default.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() == TypeWebhookDeliver {
+		return webhookRetryDelay(n)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
+
+// webhookRetryDelay waits 30s before the first retry and doubles each time,
+// up to four hours.
+func webhookRetryDelay(n int) time.Duration {
+	d := 30 * time.Second
+	for i := 0; i < n && d < 4*time.Hour; i++ {
+		d *= 2
+	}
+	if d > 4*time.Hour {
+		d = 4 * time.Hour
+	}
+	return d
+}
+
+func handleWebhookDeliver(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		var payload WebhookDeliveryPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshaling webhook delivery payload: %w", err)
+		}
+
+		var delivery models.WebhookDelivery
+		if err := deps.DB.First(&delivery, payload.DeliveryID).Error; err != nil {
+			return fmt.Errorf("loading webhook delivery %d: %w: %v", payload.DeliveryID, asynq.SkipRetry, err)
+		}
+		if delivery.Status == models.DeliverySucceeded {
+			return nil
+		}
+
+		var endpoint models.WebhookEndpoint
+		if err := deps.DB.First(&endpoint, delivery.EndpointID).Error; err != nil || !endpoint.Active {
+			deps.DB.Model(&delivery).Updates(map[string]interface{}{
+				"status": models.DeliveryFailed,
+				"error":  "Endpoint was deleted or disabled",
+			})
+			return nil
+		}
+
+		code, body, duration, sendErr := sendWebhook(ctx, endpoint, delivery)
+
+		updates := map[string]interface{}{
+			"attempts":      gorm.Expr("attempts + 1"),
+			"response_code": code,
+			"response_body": body,
+			"duration_ms":   duration.Milliseconds(),
+			"error":         "",
+		}
+		if sendErr == nil {
+			now := time.Now()
+			updates["status"] = models.DeliverySucceeded
+			updates["delivered_at"] = now
+			deps.DB.Model(&delivery).Updates(updates)
+			return nil
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		updates["error"] = sendErr.Error()
+		updates["status"] = models.DeliveryRetrying
+		if retried >= maxRetry {
+			updates["status"] = models.DeliveryFailed
+		}
+		deps.DB.Model(&delivery).Updates(updates)
+
+		log.Printf("[webhooks] Delivery %d of %q to %s failed (attempt %d): %v",
+			delivery.ID, delivery.Event, endpoint.URL, retried+1, sendErr)
+		return sendErr
+	}
+}
+
+// sendWebhook POSTs a delivery's payload to its endpoint. Any non-2xx
+// response is an error.
+func sendWebhook(ctx context.Context, endpoint models.WebhookEndpoint, delivery models.WebhookDelivery) (int, string, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GritCMS-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(endpoint.Secret, time.Now(), body))
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		return 0, "", duration, fmt.Errorf("calling webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	// Postgres text columns take neither invalid UTF-8 nor NUL bytes.
+	respBody := strings.ReplaceAll(strings.ToValidUTF8(string(raw), ""), "\x00", "")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, respBody, duration, fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, respBody, duration, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -25,6 +26,9 @@ type WorkerDeps struct {
 	Storage *storage.Storage
 	Cache   *cache.Cache
 	Jobs    *Client
+	AppURL  string // public API base URL, used for unsubscribe and tracking links
+
+	TrackingSecret string // signs click-tracking links
 }
 
 // StartWorker starts the asynq worker server in a goroutine.
@@ -42,6 +46,7 @@ func StartWorker(redisURL string, deps WorkerDeps) (func(), error) {
 			"critical": 3,
 			"low":      1,
 		},
+		RetryDelayFunc: retryDelay,
 	})
 
 	mux := asynq.NewServeMux()
@@ -50,6 +55,15 @@ func StartWorker(redisURL string, deps WorkerDeps) (func(), error) {
 	mux.HandleFunc(TypeTokensCleanup, handleTokensCleanup(deps))
 	mux.HandleFunc(TypeCampaignProcess, handleCampaignProcess(deps))
 	mux.HandleFunc(TypeCampaignCheckScheduled, handleCampaignCheckScheduled(deps))
+	mux.HandleFunc(TypeWorkflowRun, handleWorkflowRun(deps))
+	mux.HandleFunc(TypeWorkflowCheckScheduled, handleWorkflowCheckScheduled(deps))
+	mux.HandleFunc(TypeSequenceProcess, handleSequenceProcess(deps))
+	mux.HandleFunc(TypeCourseDripNotify, handleCourseDripNotify(deps))
+	mux.HandleFunc(TypeCommunityDigest, handleCommunityDigest(deps))
+	mux.HandleFunc(TypeBookingEmail, handleBookingEmail(deps))
+	mux.HandleFunc(TypeBookingReminder, handleBookingReminder(deps))
+	mux.HandleFunc(TypeBookingReleaseHolds, handleBookingReleaseHolds(deps))
+	mux.HandleFunc(TypeWebhookDeliver, handleWebhookDeliver(deps))
 
 	go func() {
 		if err := srv.Run(mux); err != nil {
@@ -75,12 +89,17 @@ func handleEmailSend(deps WorkerDeps) func(ctx context.Context, task *asynq.Task
 
 		log.Printf("Sending email to %s: %s", payload.To, payload.Subject)
 
-		return deps.Mailer.Send(ctx, mail.SendOptions{
+		err := deps.Mailer.Send(ctx, mail.SendOptions{
 			To:       payload.To,
 			Subject:  payload.Subject,
 			Template: payload.Template,
 			Data:     payload.Data,
 		})
+		if errors.Is(err, mail.ErrSuppressed) {
+			log.Printf("Skipping email to suppressed address %s", payload.To)
+			return nil
+		}
+		return err
 	}
 }
 
@@ -140,8 +159,28 @@ func handleTokensCleanup(deps WorkerDeps) func(ctx context.Context, task *asynq.
 		if result.Error != nil {
 			return fmt.Errorf("cleaning up deleted users: %w", result.Error)
 		}
+		removed := result.RowsAffected
 
-		log.Printf("Token cleanup complete, removed %d records", result.RowsAffected)
+		// Sessions are kept for a week after they end so the profile and
+		// admin screens can still explain why a device was signed out.
+		cutoff := time.Now().Add(-7 * 24 * time.Hour)
+		result = deps.DB.Exec("DELETE FROM refresh_tokens WHERE session_id IN (SELECT id FROM user_sessions WHERE expires_at < ? OR revoked_at < ?)", cutoff, cutoff)
+		if result.Error != nil {
+			return fmt.Errorf("cleaning up refresh tokens: %w", result.Error)
+		}
+		removed += result.RowsAffected
+		result = deps.DB.Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff).Delete(&models.UserSession{})
+		if result.Error != nil {
+			return fmt.Errorf("cleaning up sessions: %w", result.Error)
+		}
+		removed += result.RowsAffected
+		result = deps.DB.Where("expires_at < ?", time.Now()).Delete(&models.PasswordResetToken{})
+		if result.Error != nil {
+			return fmt.Errorf("cleaning up password reset tokens: %w", result.Error)
+		}
+		removed += result.RowsAffected
+
+		log.Printf("Token cleanup complete, removed %d records", removed)
 		return nil
 	}
 }
@@ -165,8 +204,9 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 			return fmt.Errorf("loading campaign %d: %w", payload.CampaignID, err)
 		}
 
-		// Skip if already sent or cancelled
-		if campaign.Status == models.CampaignStatusSent || campaign.Status == models.CampaignStatusCancelled {
+		// Skip if already sent or cancelled, or waiting on an A/B test
+		if campaign.Status == models.CampaignStatusSent || campaign.Status == models.CampaignStatusCancelled ||
+			campaign.Status == models.CampaignStatusTesting {
 			log.Printf("Campaign %d already %s, skipping", payload.CampaignID, campaign.Status)
 			return nil
 		}
@@ -182,17 +222,31 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 		if campaign.Template != nil && campaign.Template.HTMLContent != "" {
 			htmlContent = campaign.Template.HTMLContent
 		}
-		if htmlContent == "" {
-			deps.DB.Model(&campaign).Update("status", models.CampaignStatusSent)
-			log.Printf("Campaign %d has no HTML content, marked as sent", payload.CampaignID)
-			return nil
-		}
 
 		subject := campaign.Subject
 		if subject == "" && campaign.Template != nil {
 			subject = campaign.Template.Subject
 		}
 
+		// A/B test: the first run sends every variant to a sample of the
+		// audience; the run after the winner is picked sends it to the rest.
+		variants := campaignVariants(deps.DB, campaign, subject, htmlContent)
+		testing := len(variants) > 0 && campaign.WinningVariant == ""
+		content := campaignContent{Subject: subject, HTML: htmlContent}
+		if len(variants) > 0 && !testing {
+			for _, v := range variants {
+				if v.Variant == campaign.WinningVariant {
+					content = v
+				}
+			}
+		}
+
+		if content.HTML == "" && !testing {
+			deps.DB.Model(&campaign).Update("status", models.CampaignStatusSent)
+			log.Printf("Campaign %d has no HTML content, marked as sent", payload.CampaignID)
+			return nil
+		}
+
 		// Build "from" string
 		from := ""
 		if campaign.FromName != "" && campaign.FromEmail != "" {
@@ -254,20 +308,23 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 			return nil
 		}
 
-		// Load contacts
+		// Load contacts, skipping suppressed addresses and anyone this
+		// campaign already reached (A/B test sample, or an earlier attempt)
 		ids := make([]uint, 0, len(recipientIDs))
 		for id := range recipientIDs {
 			ids = append(ids, id)
 		}
 		var contacts []models.Contact
-		deps.DB.Where("id IN ?", ids).Find(&contacts)
+		models.ExcludeSuppressed(deps.DB.Where("id IN ?", ids)).
+			Where("id NOT IN (SELECT contact_id FROM email_sends WHERE campaign_id = ?)", campaign.ID).
+			Find(&contacts)
 
 		// Send to each contact
 		sentCount := 0
 		failedCount := 0
-		for _, contact := range contacts {
+		sendTo := func(contact models.Contact, content campaignContent) {
 			if contact.Email == "" {
-				continue
+				return
 			}
 
 			// Create EmailSend record
@@ -276,7 +333,8 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 				TenantID:   1,
 				ContactID:  contact.ID,
 				CampaignID: &campaign.ID,
-				Subject:    subject,
+				Variant:    content.Variant,
+				Subject:    content.Subject,
 				Status:     models.SendStatusQueued,
 				SentAt:     &now,
 			}
@@ -287,8 +345,9 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 				From:     from,
 				ReplyTo:  campaign.ReplyTo,
 				To:       contact.Email,
-				Subject:  subject,
-				HTMLBody: htmlContent,
+				Subject:  content.Subject,
+				HTMLBody: trackLinks(deps, send.ID, content.HTML),
+				Headers:  unsubscribeHeaders(deps, &contact),
 			})
 
 			if err != nil {
@@ -297,7 +356,7 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 					"status": models.SendStatusFailed,
 				})
 				failedCount++
-				continue
+				return
 			}
 
 			deps.DB.Model(&send).Updates(map[string]interface{}{
@@ -307,22 +366,83 @@ func handleCampaignProcess(deps WorkerDeps) func(ctx context.Context, task *asyn
 			sentCount++
 		}
 
-		// Update campaign stats and status
-		stats := models.CampaignStats{
-			Sent:    sentCount,
-			Bounced: failedCount,
+		if testing {
+			sample := abTestSample(contacts, campaign.TestPercent, len(variants))
+			for i, contact := range sample {
+				sendTo(contact, variants[i%len(variants)])
+			}
+
+			wait := time.Duration(campaign.TestWaitHours) * time.Hour
+			if wait <= 0 {
+				wait = 4 * time.Hour
+			}
+			statsJSON, _ := json.Marshal(models.ComputeCampaignStats(deps.DB, campaign.ID))
+			variantsJSON, _ := json.Marshal(models.ComputeVariantStats(deps.DB, campaign.ID))
+			deps.DB.Model(&campaign).Updates(map[string]interface{}{
+				"status":        models.CampaignStatusTesting,
+				"test_ends_at":  time.Now().Add(wait),
+				"stats":         statsJSON,
+				"variant_stats": variantsJSON,
+			})
+
+			log.Printf("Campaign %d A/B test started: %d variants to %d of %d contacts, %d failed",
+				payload.CampaignID, len(variants), len(sample), len(contacts), failedCount)
+			return nil
 		}
-		statsJSON, _ := json.Marshal(stats)
-		deps.DB.Model(&campaign).Updates(map[string]interface{}{
+
+		for _, contact := range contacts {
+			sendTo(contact, content)
+		}
+
+		// Update campaign stats and status
+		updates := map[string]interface{}{
 			"status": models.CampaignStatusSent,
-			"stats":  statsJSON,
-		})
+		}
+		if len(variants) > 0 {
+			// Include the test sample's sends in the totals.
+			statsJSON, _ := json.Marshal(models.ComputeCampaignStats(deps.DB, campaign.ID))
+			variantsJSON, _ := json.Marshal(models.ComputeVariantStats(deps.DB, campaign.ID))
+			updates["stats"] = statsJSON
+			updates["variant_stats"] = variantsJSON
+		} else {
+			stats := models.CampaignStats{
+				Sent:    sentCount,
+				Bounced: failedCount,
+			}
+			statsJSON, _ := json.Marshal(stats)
+			updates["stats"] = statsJSON
+		}
+		deps.DB.Model(&campaign).Updates(updates)
 
 		log.Printf("Campaign %d complete: %d sent, %d failed", payload.CampaignID, sentCount, failedCount)
 		return nil
 	}
 }
 
+// unsubscribeHeaders returns RFC 8058 one-click List-Unsubscribe headers for
+// a marketing email to contact, or nil when no public URL is configured.
+func unsubscribeHeaders(deps WorkerDeps, contact *models.Contact) map[string]string {
+	if deps.AppURL == "" {
+		return nil
+	}
+	url := strings.TrimRight(deps.AppURL, "/") + "/api/email/unsubscribe?token=" + contact.EnsureEmailToken(deps.DB)
+	return map[string]string{
+		"List-Unsubscribe":      "<" + url + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+// trackLinks rewrites the links in a send's HTML into signed click-tracking
+// URLs, or returns it unchanged when tracking isn't configured.
+func trackLinks(deps WorkerDeps, sendID uint, htmlBody string) string {
+	if deps.AppURL == "" || deps.TrackingSecret == "" || sendID == 0 {
+		return htmlBody
+	}
+	return mail.RewriteLinks(htmlBody, func(target string) string {
+		return mail.ClickTrackingURL(deps.AppURL, deps.TrackingSecret, sendID, target)
+	})
+}
+
 // resolveSegmentContacts returns contact IDs matching a segment's rules.
 func resolveSegmentContacts(db *gorm.DB, seg models.Segment) []uint {
 	q := db.Model(&models.Contact{}).Select("id").Where("tenant_id = ?", 1)
@@ -337,41 +457,7 @@ func resolveSegmentContacts(db *gorm.DB, seg models.Segment) []uint {
 	}
 
 	for _, rule := range ruleGroup.Rules {
-		switch rule.Field {
-		case "email":
-			switch rule.Operator {
-			case "contains":
-				q = q.Where("email ILIKE ?", "%"+rule.Value+"%")
-			case "equals":
-				q = q.Where("email = ?", rule.Value)
-			case "ends_with":
-				q = q.Where("email ILIKE ?", "%"+rule.Value)
-			}
-		case "first_name", "last_name":
-			switch rule.Operator {
-			case "contains":
-				q = q.Where(fmt.Sprintf("%s ILIKE ?", rule.Field), "%"+rule.Value+"%")
-			case "equals":
-				q = q.Where(fmt.Sprintf("%s = ?", rule.Field), rule.Value)
-			}
-		case "source":
-			q = q.Where("source = ?", rule.Value)
-		case "country":
-			q = q.Where("country = ?", rule.Value)
-		case "tag":
-			switch rule.Operator {
-			case "has_tag":
-				q = q.Where("id IN (SELECT contact_id FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?)", rule.Value)
-			case "has_no_tag":
-				q = q.Where("id NOT IN (SELECT contact_id FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?)", rule.Value)
-			}
-		case "subscribed_to_list":
-			q = q.Where("id IN (SELECT contact_id FROM email_subscriptions WHERE email_list_id = ? AND status = 'active')", rule.Value)
-		case "created_after":
-			q = q.Where("created_at >= ?", rule.Value)
-		case "created_before":
-			q = q.Where("created_at <= ?", rule.Value)
-		}
+		q = applySegmentRule(q, rule)
 	}
 
 	var contactIDs []uint
@@ -379,12 +465,55 @@ func resolveSegmentContacts(db *gorm.DB, seg models.Segment) []uint {
 	return contactIDs
 }
 
+// applySegmentRule narrows a contacts query by a single segment rule.
+func applySegmentRule(q *gorm.DB, rule models.SegmentRule) *gorm.DB {
+	switch rule.Field {
+	case "email":
+		switch rule.Operator {
+		case "contains":
+			q = q.Where("email ILIKE ?", "%"+rule.Value+"%")
+		case "equals":
+			q = q.Where("email = ?", rule.Value)
+		case "ends_with":
+			q = q.Where("email ILIKE ?", "%"+rule.Value)
+		}
+	case "first_name", "last_name":
+		switch rule.Operator {
+		case "contains":
+			q = q.Where(fmt.Sprintf("%s ILIKE ?", rule.Field), "%"+rule.Value+"%")
+		case "equals":
+			q = q.Where(fmt.Sprintf("%s = ?", rule.Field), rule.Value)
+		}
+	case "source":
+		q = q.Where("source = ?", rule.Value)
+	case "country":
+		q = q.Where("country = ?", rule.Value)
+	case "tag":
+		switch rule.Operator {
+		case "has_tag":
+			q = q.Where("id IN (SELECT contact_id FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?)", rule.Value)
+		case "has_no_tag":
+			q = q.Where("id NOT IN (SELECT contact_id FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?)", rule.Value)
+		}
+	case "subscribed_to_list":
+		q = q.Where("id IN (SELECT contact_id FROM email_subscriptions WHERE email_list_id = ? AND status = 'active')", rule.Value)
+	case "created_after":
+		q = q.Where("created_at >= ?", rule.Value)
+	case "created_before":
+		q = q.Where("created_at <= ?", rule.Value)
+	}
+	return q
+}
+
 func handleCampaignCheckScheduled(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
 	return func(ctx context.Context, task *asynq.Task) error {
 		if deps.DB == nil {
 			return fmt.Errorf("database not configured")
 		}
 
+		// Finish A/B tests whose wait window is over
+		pickCampaignWinners(deps)
+
 		// Find campaigns that are scheduled and due
 		var campaigns []models.EmailCampaign
 		deps.DB.Where("status = ? AND scheduled_at <= ?", models.CampaignStatusScheduled, time.Now()).Find(&campaigns)
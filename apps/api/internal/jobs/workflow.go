@@ -0,0 +1,735 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/mail"
+	"gritcms/apps/api/internal/models"
+)
+
+// StartWorkflow creates a running execution of a workflow for a contact and
+// enqueues its first step. trigger is recorded as the execution's TriggerEvent.
+func StartWorkflow(db *gorm.DB, client *Client, workflow models.Workflow, contactID uint, trigger string) (*models.WorkflowExecution, error) {
+	if client == nil {
+		return nil, fmt.Errorf("job queue not configured")
+	}
+
+	exec := models.WorkflowExecution{
+		TenantID:     workflow.TenantID,
+		WorkflowID:   workflow.ID,
+		ContactID:    contactID,
+		TriggerEvent: trigger,
+		Status:       models.ExecutionRunning,
+		Log:          datatypes.JSON([]byte("[]")),
+		StartedAt:    time.Now(),
+	}
+	if err := db.Create(&exec).Error; err != nil {
+		return nil, fmt.Errorf("creating workflow execution: %w", err)
+	}
+
+	db.Model(&models.Workflow{}).Where("id = ?", workflow.ID).
+		UpdateColumn("execution_count", gorm.Expr("execution_count + 1"))
+
+	if err := client.EnqueueWorkflowRun(exec.ID, 0, false); err != nil {
+		finishExecution(db, &exec, nil, models.ExecutionFailed)
+		return &exec, err
+	}
+	return &exec, nil
+}
+
+func handleWorkflowRun(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+
+		var payload WorkflowPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("unmarshaling workflow payload: %w", err)
+		}
+
+		var exec models.WorkflowExecution
+		if err := deps.DB.First(&exec, payload.ExecutionID).Error; err != nil {
+			return fmt.Errorf("loading workflow execution %d: %w", payload.ExecutionID, err)
+		}
+		if exec.Status != models.ExecutionRunning {
+			return nil
+		}
+
+		run := &workflowRun{deps: deps, exec: &exec}
+		run.execute(ctx, payload.Resumed)
+		return nil
+	}
+}
+
+// ParseWorkflowSchedule decodes and validates a schedule workflow's TriggerConfig.
+func ParseWorkflowSchedule(raw datatypes.JSON) (models.WorkflowSchedule, cron.Schedule, error) {
+	var cfg models.WorkflowSchedule
+	if raw != nil {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return cfg, nil, fmt.Errorf("invalid trigger config: %w", err)
+		}
+	}
+	if cfg.Cron == "" {
+		return cfg, nil, fmt.Errorf("cron expression is required")
+	}
+	schedule, err := cron.ParseStandard(cfg.Cron)
+	if err != nil {
+		return cfg, nil, fmt.Errorf("invalid cron expression %q: %w", cfg.Cron, err)
+	}
+	return cfg, schedule, nil
+}
+
+func handleWorkflowCheckScheduled(deps WorkerDeps) func(ctx context.Context, task *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		if deps.DB == nil {
+			return fmt.Errorf("database not configured")
+		}
+		if deps.Jobs == nil {
+			return fmt.Errorf("job queue not configured")
+		}
+
+		var workflows []models.Workflow
+		deps.DB.Where("trigger_type = ? AND status = ?", models.TriggerSchedule, models.WorkflowStatusActive).Find(&workflows)
+
+		now := time.Now()
+		for _, wf := range workflows {
+			cfg, schedule, err := ParseWorkflowSchedule(wf.TriggerConfig)
+			if err != nil || !cfg.HasAudience() {
+				continue
+			}
+
+			// Never fire retroactively for a freshly activated workflow.
+			from := wf.UpdatedAt
+			if wf.LastRunAt != nil {
+				from = *wf.LastRunAt
+			}
+			due := schedule.Next(from)
+			if due.After(now) {
+				continue
+			}
+
+			// Claim this run so overlapping checks don't fire it twice.
+			res := deps.DB.Model(&models.Workflow{}).
+				Where("id = ? AND (last_run_at IS NULL OR last_run_at < ?)", wf.ID, due).
+				Update("last_run_at", now)
+			if res.Error != nil || res.RowsAffected == 0 {
+				continue
+			}
+
+			contactIDs := scheduleAudience(deps.DB, cfg)
+			log.Printf("Running scheduled workflow %d for %d contacts", wf.ID, len(contactIDs))
+			for _, contactID := range contactIDs {
+				if _, err := StartWorkflow(deps.DB, deps.Jobs, wf, contactID, "schedule"); err != nil {
+					log.Printf("Failed to start workflow %d for contact %d: %v", wf.ID, contactID, err)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// scheduleAudience returns the contacts a scheduled workflow runs for.
+func scheduleAudience(db *gorm.DB, cfg models.WorkflowSchedule) []uint {
+	var contactIDs []uint
+	switch {
+	case cfg.SegmentID > 0:
+		var seg models.Segment
+		if err := db.First(&seg, cfg.SegmentID).Error; err != nil {
+			return nil
+		}
+		contactIDs = resolveSegmentContacts(db, seg)
+	case cfg.TagID > 0:
+		db.Raw("SELECT DISTINCT contact_id FROM contact_tags WHERE tag_id = ?", cfg.TagID).Scan(&contactIDs)
+	case cfg.Tag != "":
+		db.Raw("SELECT DISTINCT ct.contact_id FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name = ?", cfg.Tag).Scan(&contactIDs)
+	}
+	return contactIDs
+}
+
+// workflowRun walks the ordered actions of one execution, starting at CurrentStep.
+type workflowRun struct {
+	deps    WorkerDeps
+	exec    *models.WorkflowExecution
+	contact models.Contact
+	log     []models.WorkflowLogEntry
+}
+
+func (r *workflowRun) execute(ctx context.Context, resumed bool) {
+	db := r.deps.DB
+	if r.exec.Log != nil {
+		_ = json.Unmarshal(r.exec.Log, &r.log)
+	}
+
+	if err := db.Preload("Tags").First(&r.contact, r.exec.ContactID).Error; err != nil {
+		r.append(r.exec.CurrentStep, models.WorkflowAction{}, "failed", "Contact not found")
+		finishExecution(db, r.exec, r.log, models.ExecutionFailed)
+		return
+	}
+
+	var actions []models.WorkflowAction
+	db.Where("workflow_id = ?", r.exec.WorkflowID).Order("sort_order ASC").Find(&actions)
+
+	step := r.exec.CurrentStep
+	for step < len(actions) {
+		action := actions[step]
+
+		// Honor the per-step delay by re-enqueuing ourselves for later.
+		if action.DelaySeconds > 0 && !resumed {
+			delay := time.Duration(action.DelaySeconds) * time.Second
+			r.append(step, action, "waiting", fmt.Sprintf("Delayed %s", delay))
+			r.schedule(step, delay, true)
+			return
+		}
+		resumed = false
+
+		next, status, msg, err := r.runAction(ctx, step, action, len(actions))
+		if err != nil {
+			r.append(step, action, "failed", err.Error())
+			r.exec.CurrentStep = step
+			finishExecution(db, r.exec, r.log, models.ExecutionFailed)
+			log.Printf("Workflow execution %d failed at step %d (%s): %v", r.exec.ID, step, action.Type, err)
+			return
+		}
+		r.append(step, action, status, msg)
+
+		// A wait action pauses the run until its duration has passed.
+		if action.Type == models.ActionWait {
+			if d := parseWaitDuration(action.Config); d > 0 {
+				r.schedule(next, d, false)
+				return
+			}
+		}
+
+		step = next
+	}
+
+	r.exec.CurrentStep = step
+	finishExecution(db, r.exec, r.log, models.ExecutionCompleted)
+}
+
+// schedule persists progress and enqueues the run to continue at step after delay.
+func (r *workflowRun) schedule(step int, delay time.Duration, resumed bool) {
+	r.exec.CurrentStep = step
+	r.save()
+
+	if r.deps.Jobs == nil {
+		r.append(step, models.WorkflowAction{}, "failed", "Job queue not configured")
+		finishExecution(r.deps.DB, r.exec, r.log, models.ExecutionFailed)
+		return
+	}
+	if err := r.deps.Jobs.EnqueueWorkflowRun(r.exec.ID, delay, resumed); err != nil {
+		r.append(step, models.WorkflowAction{}, "failed", err.Error())
+		finishExecution(r.deps.DB, r.exec, r.log, models.ExecutionFailed)
+	}
+}
+
+func (r *workflowRun) append(step int, action models.WorkflowAction, status, message string) {
+	r.log = append(r.log, models.WorkflowLogEntry{
+		Step:     step,
+		ActionID: action.ID,
+		Type:     action.Type,
+		Status:   status,
+		Message:  message,
+		At:       time.Now(),
+	})
+}
+
+func (r *workflowRun) save() {
+	logJSON, _ := json.Marshal(r.log)
+	r.exec.Log = datatypes.JSON(logJSON)
+	r.deps.DB.Model(r.exec).Updates(map[string]interface{}{
+		"current_step": r.exec.CurrentStep,
+		"log":          r.exec.Log,
+	})
+}
+
+// finishExecution marks an execution completed or failed and stores its log.
+func finishExecution(db *gorm.DB, exec *models.WorkflowExecution, entries []models.WorkflowLogEntry, status string) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":       status,
+		"current_step": exec.CurrentStep,
+		"completed_at": now,
+	}
+	if entries != nil {
+		logJSON, _ := json.Marshal(entries)
+		exec.Log = datatypes.JSON(logJSON)
+		updates["log"] = exec.Log
+	}
+	exec.Status = status
+	exec.CompletedAt = &now
+	db.Model(exec).Updates(updates)
+}
+
+// runAction executes a single action and returns the index of the next step
+// along with the log status and message for this one.
+func (r *workflowRun) runAction(ctx context.Context, step int, action models.WorkflowAction, total int) (int, string, string, error) {
+	next := step + 1
+
+	if action.Type == models.ActionCondition {
+		cond := parseCondition(action.Config)
+		if cond.Field == "" {
+			cond = parseCondition(action.Condition)
+		}
+		if r.matches(cond) {
+			return next, "completed", "Condition matched", nil
+		}
+		if cond.ElseStep == nil {
+			return total, "completed", "Condition not matched, ending workflow", nil
+		}
+		// Only forward jumps are allowed so a run can never loop.
+		if *cond.ElseStep <= step || *cond.ElseStep > total {
+			return 0, "", "", fmt.Errorf("invalid else_step %d", *cond.ElseStep)
+		}
+		return *cond.ElseStep, "completed", fmt.Sprintf("Condition not matched, jumping to step %d", *cond.ElseStep), nil
+	}
+
+	if cond := parseCondition(action.Condition); cond.Field != "" && !r.matches(cond) {
+		return next, "skipped", "Condition not matched", nil
+	}
+
+	cfg := map[string]interface{}{}
+	if action.Config != nil {
+		_ = json.Unmarshal(action.Config, &cfg)
+	}
+
+	var msg string
+	var err error
+	switch action.Type {
+	case models.ActionSendEmail:
+		msg, err = r.sendEmail(ctx, cfg)
+	case models.ActionAddTag:
+		msg, err = r.addTag(cfg)
+	case models.ActionRemoveTag:
+		msg, err = r.removeTag(cfg)
+	case models.ActionEnrollCourse:
+		msg, err = r.enrollCourse(cfg)
+	case models.ActionAddToList:
+		msg, err = r.addToList(cfg)
+	case models.ActionRemoveFromList:
+		msg, err = r.removeFromList(cfg)
+	case models.ActionWait:
+		msg = fmt.Sprintf("Waiting %s", parseWaitDuration(action.Config))
+	case models.ActionWebhook:
+		msg, err = r.callWebhook(ctx, cfg)
+	case models.ActionUpdateContact:
+		msg, err = r.updateContact(cfg)
+	case models.ActionCreateNote:
+		msg, err = r.createNote(cfg)
+	default:
+		err = fmt.Errorf("unknown action type %q", action.Type)
+	}
+	if err != nil {
+		return 0, "", "", err
+	}
+	return next, "completed", msg, nil
+}
+
+// matches reports whether the execution's contact satisfies a condition.
+func (r *workflowRun) matches(cond models.WorkflowCondition) bool {
+	q := r.deps.DB.Model(&models.Contact{}).Where("id = ?", r.contact.ID)
+	q = applySegmentRule(q, models.SegmentRule{Field: cond.Field, Operator: cond.Operator, Value: cond.Value})
+	var count int64
+	q.Count(&count)
+	return count > 0
+}
+
+// ---------- Actions ----------
+
+func (r *workflowRun) sendEmail(ctx context.Context, cfg map[string]interface{}) (string, error) {
+	if r.deps.Mailer == nil {
+		return "", fmt.Errorf("mailer not configured")
+	}
+	if r.contact.Email == "" {
+		return "Contact has no email, skipped", nil
+	}
+	if models.IsEmailSuppressed(r.deps.DB, r.contact.Email) {
+		return "Email address is suppressed, skipped", nil
+	}
+
+	subject := configString(cfg, "subject")
+	htmlContent := configString(cfg, "html_content")
+	if templateID := configUint(cfg, "template_id"); templateID > 0 {
+		var tmpl models.EmailTemplate
+		if err := r.deps.DB.First(&tmpl, templateID).Error; err != nil {
+			return "", fmt.Errorf("email template %d not found", templateID)
+		}
+		if htmlContent == "" {
+			htmlContent = tmpl.HTMLContent
+		}
+		if subject == "" {
+			subject = tmpl.Subject
+		}
+	}
+	if htmlContent == "" || subject == "" {
+		return "", fmt.Errorf("email subject and content are required")
+	}
+
+	subject = renderMergeTags(subject, r.contact)
+	htmlContent = renderMergeTags(htmlContent, r.contact)
+
+	now := time.Now()
+	send := models.EmailSend{
+		TenantID:  r.contact.TenantID,
+		ContactID: r.contact.ID,
+		Subject:   subject,
+		Status:    models.SendStatusQueued,
+		SentAt:    &now,
+	}
+	r.deps.DB.Create(&send)
+
+	messageID, err := r.deps.Mailer.SendCampaignEmail(ctx, mail.CampaignEmailOptions{
+		From:     configString(cfg, "from"),
+		ReplyTo:  configString(cfg, "reply_to"),
+		To:       r.contact.Email,
+		Subject:  subject,
+		HTMLBody: trackLinks(r.deps, send.ID, htmlContent),
+		Headers:  unsubscribeHeaders(r.deps, &r.contact),
+	})
+	if err != nil {
+		r.deps.DB.Model(&send).Update("status", models.SendStatusFailed)
+		return "", fmt.Errorf("sending email: %w", err)
+	}
+	r.deps.DB.Model(&send).Updates(map[string]interface{}{
+		"status":      models.SendStatusSent,
+		"external_id": messageID,
+	})
+	return fmt.Sprintf("Sent \"%s\" to %s", subject, r.contact.Email), nil
+}
+
+func (r *workflowRun) addTag(cfg map[string]interface{}) (string, error) {
+	name := configString(cfg, "tag_name", "tag")
+	if name == "" {
+		return "", fmt.Errorf("tag_name is required")
+	}
+	var tag models.Tag
+	if err := r.deps.DB.Where("tenant_id = ? AND name = ?", r.contact.TenantID, name).
+		FirstOrCreate(&tag, models.Tag{TenantID: r.contact.TenantID, Name: name}).Error; err != nil {
+		return "", fmt.Errorf("creating tag: %w", err)
+	}
+	// Only a new tag emits contact.tagged; re-tagging would otherwise retrigger
+	// any workflow started by that event, including this one.
+	var tagged int64
+	r.deps.DB.Table("contact_tags").Where("contact_id = ? AND tag_id = ?", r.contact.ID, tag.ID).Count(&tagged)
+	if tagged > 0 {
+		return fmt.Sprintf("Contact already has tag \"%s\"", name), nil
+	}
+	if err := r.deps.DB.Model(&r.contact).Association("Tags").Append(&tag); err != nil {
+		return "", fmt.Errorf("tagging contact: %w", err)
+	}
+	events.Emit(events.ContactTagged, map[string]interface{}{
+		"contact_id": r.contact.ID,
+		"tag_id":     tag.ID,
+		"tag_name":   tag.Name,
+	})
+	return fmt.Sprintf("Added tag \"%s\"", name), nil
+}
+
+func (r *workflowRun) removeTag(cfg map[string]interface{}) (string, error) {
+	name := configString(cfg, "tag_name", "tag")
+	if name == "" {
+		return "", fmt.Errorf("tag_name is required")
+	}
+	var tag models.Tag
+	if err := r.deps.DB.Where("tenant_id = ? AND name = ?", r.contact.TenantID, name).First(&tag).Error; err != nil {
+		return fmt.Sprintf("Tag \"%s\" does not exist", name), nil
+	}
+	if err := r.deps.DB.Model(&r.contact).Association("Tags").Delete(&tag); err != nil {
+		return "", fmt.Errorf("removing tag: %w", err)
+	}
+	return fmt.Sprintf("Removed tag \"%s\"", name), nil
+}
+
+func (r *workflowRun) enrollCourse(cfg map[string]interface{}) (string, error) {
+	courseID := configUint(cfg, "course_id")
+	if courseID == 0 {
+		return "", fmt.Errorf("course_id is required")
+	}
+	var course models.Course
+	if err := r.deps.DB.First(&course, courseID).Error; err != nil {
+		return "", fmt.Errorf("course %d not found", courseID)
+	}
+
+	var existing models.CourseEnrollment
+	if err := r.deps.DB.Where("contact_id = ? AND course_id = ?", r.contact.ID, courseID).First(&existing).Error; err == nil {
+		return fmt.Sprintf("Already enrolled in \"%s\"", course.Title), nil
+	}
+
+	enrollment := models.CourseEnrollment{
+		TenantID:   r.contact.TenantID,
+		ContactID:  r.contact.ID,
+		CourseID:   courseID,
+		Status:     models.EnrollStatusActive,
+		EnrolledAt: time.Now(),
+		Source:     "workflow",
+	}
+	if err := r.deps.DB.Create(&enrollment).Error; err != nil {
+		return "", fmt.Errorf("enrolling contact: %w", err)
+	}
+	events.Emit(events.CourseEnrolled, enrollment)
+	return fmt.Sprintf("Enrolled in \"%s\"", course.Title), nil
+}
+
+func (r *workflowRun) addToList(cfg map[string]interface{}) (string, error) {
+	listID := configUint(cfg, "list_id")
+	if listID == 0 {
+		return "", fmt.Errorf("list_id is required")
+	}
+
+	now := time.Now()
+	var sub models.EmailSubscription
+	if err := r.deps.DB.Where("contact_id = ? AND email_list_id = ?", r.contact.ID, listID).First(&sub).Error; err == nil {
+		if sub.Status == models.SubStatusActive {
+			return "Already subscribed", nil
+		}
+		sub.Status = models.SubStatusActive
+		sub.SubscribedAt = &now
+		sub.UnsubscribedAt = nil
+		r.deps.DB.Save(&sub)
+	} else {
+		sub = models.EmailSubscription{
+			TenantID:     r.contact.TenantID,
+			ContactID:    r.contact.ID,
+			EmailListID:  listID,
+			Status:       models.SubStatusActive,
+			Source:       "workflow",
+			SubscribedAt: &now,
+		}
+		if err := r.deps.DB.Create(&sub).Error; err != nil {
+			return "", fmt.Errorf("subscribing contact: %w", err)
+		}
+	}
+	events.Emit(events.EmailSubscribed, sub)
+	return fmt.Sprintf("Subscribed to list #%d", listID), nil
+}
+
+func (r *workflowRun) removeFromList(cfg map[string]interface{}) (string, error) {
+	listID := configUint(cfg, "list_id")
+	if listID == 0 {
+		return "", fmt.Errorf("list_id is required")
+	}
+
+	var sub models.EmailSubscription
+	if err := r.deps.DB.Where("contact_id = ? AND email_list_id = ?", r.contact.ID, listID).First(&sub).Error; err != nil {
+		return "Not subscribed", nil
+	}
+	now := time.Now()
+	sub.Status = models.SubStatusUnsubscribed
+	sub.UnsubscribedAt = &now
+	r.deps.DB.Save(&sub)
+	events.Emit(events.EmailUnsubscribed, sub)
+	return fmt.Sprintf("Unsubscribed from list #%d", listID), nil
+}
+
+func (r *workflowRun) callWebhook(ctx context.Context, cfg map[string]interface{}) (string, error) {
+	url := configString(cfg, "url")
+	if url == "" {
+		return "", fmt.Errorf("url is required")
+	}
+	method := strings.ToUpper(configString(cfg, "method"))
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"workflow_id":  r.exec.WorkflowID,
+		"execution_id": r.exec.ID,
+		"trigger":      r.exec.TriggerEvent,
+		"contact":      r.contact,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling webhook body: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if headers, ok := cfg["headers"].(map[string]interface{}); ok {
+		for k, v := range headers {
+			if s, ok := v.(string); ok {
+				req.Header.Set(k, s)
+			}
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("%s %s returned %d", method, url, resp.StatusCode), nil
+}
+
+// updatableContactFields are the contact columns update_contact may set directly;
+// any other field name is written to custom_fields.
+var updatableContactFields = map[string]bool{
+	"first_name": true, "last_name": true, "phone": true,
+	"country": true, "city": true, "source": true,
+}
+
+func (r *workflowRun) updateContact(cfg map[string]interface{}) (string, error) {
+	fields := map[string]interface{}{}
+	if f, ok := cfg["fields"].(map[string]interface{}); ok {
+		fields = f
+	} else if name := configString(cfg, "field"); name != "" {
+		fields[name] = cfg["value"]
+	}
+	if len(fields) == 0 {
+		return "", fmt.Errorf("field is required")
+	}
+
+	updates := map[string]interface{}{}
+	custom := map[string]interface{}{}
+	if r.contact.CustomFields != nil {
+		_ = json.Unmarshal(r.contact.CustomFields, &custom)
+	}
+	customChanged := false
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		names = append(names, name)
+		if updatableContactFields[name] {
+			updates[name] = fmt.Sprint(value)
+			continue
+		}
+		custom[name] = value
+		customChanged = true
+	}
+	if customChanged {
+		customJSON, _ := json.Marshal(custom)
+		updates["custom_fields"] = datatypes.JSON(customJSON)
+	}
+
+	if err := r.deps.DB.Model(&r.contact).Updates(updates).Error; err != nil {
+		return "", fmt.Errorf("updating contact: %w", err)
+	}
+	events.Emit(events.ContactUpdated, r.contact)
+	return fmt.Sprintf("Updated %s", strings.Join(names, ", ")), nil
+}
+
+func (r *workflowRun) createNote(cfg map[string]interface{}) (string, error) {
+	title := configString(cfg, "title")
+	body := configString(cfg, "body", "content")
+	details := title
+	if body != "" {
+		if details != "" {
+			details += ": "
+		}
+		details += body
+	}
+	if details == "" {
+		return "", fmt.Errorf("title or body is required")
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"workflow_id":  r.exec.WorkflowID,
+		"execution_id": r.exec.ID,
+	})
+	activity := models.ContactActivity{
+		TenantID:  r.contact.TenantID,
+		ContactID: r.contact.ID,
+		Module:    "workflows",
+		Action:    "note",
+		Details:   renderMergeTags(details, r.contact),
+		Metadata:  datatypes.JSON(metadata),
+	}
+	if err := r.deps.DB.Create(&activity).Error; err != nil {
+		return "", fmt.Errorf("creating note: %w", err)
+	}
+	return "Note added", nil
+}
+
+// ---------- Helpers ----------
+
+func parseCondition(raw datatypes.JSON) models.WorkflowCondition {
+	var cond models.WorkflowCondition
+	if raw != nil {
+		_ = json.Unmarshal(raw, &cond)
+	}
+	return cond
+}
+
+// parseWaitDuration reads a wait action's config. It accepts {"seconds": N},
+// {"minutes": N}, {"hours": N}, {"days": N} or {"duration": "1h30m" | "3d"}.
+func parseWaitDuration(raw datatypes.JSON) time.Duration {
+	cfg := map[string]interface{}{}
+	if raw != nil {
+		_ = json.Unmarshal(raw, &cfg)
+	}
+
+	if s := configString(cfg, "duration"); s != "" {
+		if strings.HasSuffix(s, "d") {
+			if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+				return time.Duration(days) * 24 * time.Hour
+			}
+		}
+		if d, err := time.ParseDuration(s); err == nil {
+			return d
+		}
+	}
+
+	var d time.Duration
+	d += time.Duration(configUint(cfg, "seconds")) * time.Second
+	d += time.Duration(configUint(cfg, "minutes")) * time.Minute
+	d += time.Duration(configUint(cfg, "hours")) * time.Hour
+	d += time.Duration(configUint(cfg, "days")) * 24 * time.Hour
+	return d
+}
+
+// configString returns the first non-empty string value among keys.
+func configString(cfg map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := cfg[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// configUint reads a numeric config value, accepting JSON numbers and numeric strings.
+func configUint(cfg map[string]interface{}, key string) uint {
+	switch v := cfg[key].(type) {
+	case float64:
+		if v > 0 {
+			return uint(v)
+		}
+	case string:
+		if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return uint(n)
+		}
+	}
+	return 0
+}
+
+// renderMergeTags replaces {{first_name}}-style placeholders with contact data.
+func renderMergeTags(s string, contact models.Contact) string {
+	return strings.NewReplacer(
+		"{{first_name}}", contact.FirstName,
+		"{{last_name}}", contact.LastName,
+		"{{full_name}}", contact.FullName(),
+		"{{email}}", contact.Email,
+	).Replace(s)
+}
@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileTransport writes each message to disk instead of sending it, for local
+// development and tests. Flat mode writes <dir>/<timestamp>-<n>.eml; maildir
+// mode delivers into <dir>/new so any Maildir-aware client can read it.
+type FileTransport struct {
+	dir     string
+	maildir bool
+	seq     atomic.Uint64
+}
+
+// NewFileTransport creates the output directory and returns a file transport.
+func NewFileTransport(dir string, maildir bool) (*FileTransport, error) {
+	if dir == "" {
+		dir = "tmp/mail"
+	}
+	subdirs := []string{""}
+	if maildir {
+		subdirs = []string{"tmp", "new", "cur"}
+	}
+	for _, sub := range subdirs {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("creating mail directory: %w", err)
+		}
+	}
+	return &FileTransport{dir: dir, maildir: maildir}, nil
+}
+
+// Send writes the message and returns its Message-ID.
+func (t *FileTransport) Send(ctx context.Context, msg Message) (string, error) {
+	messageID := newMessageID(msg.From)
+	data, err := buildMIME(msg, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	host, _ := os.Hostname()
+	host = strings.NewReplacer("/", "_", ":", "_").Replace(host)
+	name := fmt.Sprintf("%d.%d_%d.%s", time.Now().Unix(), os.Getpid(), t.seq.Add(1), host)
+
+	if !t.maildir {
+		path := filepath.Join(t.dir, name+".eml")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return "", fmt.Errorf("writing %s: %w", path, err)
+		}
+		return messageID, nil
+	}
+
+	// Maildir delivery: write to tmp, then atomically move into new.
+	tmpPath := filepath.Join(t.dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(t.dir, "new", name)); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("delivering to maildir: %w", err)
+	}
+	return messageID, nil
+}
@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarEvent describes a single event for an iCalendar (.ics) invite.
+type CalendarEvent struct {
+	UID            string // stable across updates so calendars replace the event
+	Sequence       int    // bump on every change
+	Summary        string
+	Description    string
+	Location       string
+	URL            string
+	Start          time.Time
+	End            time.Time
+	OrganizerName  string
+	OrganizerEmail string
+	AttendeeName   string
+	AttendeeEmail  string
+	Cancelled      bool
+}
+
+// Method returns the iTIP method for the event: CANCEL or REQUEST.
+func (e CalendarEvent) Method() string {
+	if e.Cancelled {
+		return "CANCEL"
+	}
+	return "REQUEST"
+}
+
+// ICS renders the event as an RFC 5545 calendar.
+func (e CalendarEvent) ICS() []byte {
+	var b strings.Builder
+	line := func(s string) {
+		// Fold lines longer than 75 octets, without splitting a character.
+		for len(s) > 75 {
+			cut := 75
+			for cut > 1 && !utf8.RuneStart(s[cut]) {
+				cut--
+			}
+			b.WriteString(s[:cut] + "\r\n")
+			s = " " + s[cut:]
+		}
+		b.WriteString(s + "\r\n")
+	}
+	stamp := func(t time.Time) string { return t.UTC().Format("20060102T150405Z") }
+
+	status := "CONFIRMED"
+	if e.Cancelled {
+		status = "CANCELLED"
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//GritCMS//Booking//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + e.Method())
+	line("BEGIN:VEVENT")
+	line("UID:" + e.UID)
+	line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	line("DTSTAMP:" + stamp(time.Now()))
+	line("DTSTART:" + stamp(e.Start))
+	line("DTEND:" + stamp(e.End))
+	line("SUMMARY:" + icsEscape(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION:" + icsEscape(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION:" + icsEscape(e.Location))
+	}
+	if e.URL != "" {
+		line("URL:" + e.URL)
+	}
+	if e.OrganizerEmail != "" {
+		line(fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", icsEscape(e.OrganizerName), e.OrganizerEmail))
+	}
+	if e.AttendeeEmail != "" {
+		line(fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:%s", icsEscape(e.AttendeeName), e.AttendeeEmail))
+	}
+	line("STATUS:" + status)
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// Attachment returns the event as an invite.ics attachment.
+func (e CalendarEvent) Attachment() Attachment {
+	return Attachment{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=utf-8; method=" + e.Method(),
+		Content:     e.ICS(),
+	}
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
@@ -3,27 +3,49 @@ package mail
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
-	"net/http"
-	"time"
 )
 
-// Mailer sends emails via the Resend API.
+// ErrSuppressed is returned instead of sending to an address on the
+// suppression list.
+var ErrSuppressed = errors.New("recipient is on the suppression list")
+
+// SuppressionCheck reports whether an address must not be mailed. transactional
+// is true for Send and SendRaw (account and receipt emails), which contacts
+// who merely opted out of marketing should still receive.
+type SuppressionCheck func(email string, transactional bool) bool
+
+// Mailer renders and sends emails through a Transport (Resend, SMTP or files).
 type Mailer struct {
-	apiKey string
-	from   string
-	client *http.Client
+	transport  Transport
+	from       string
+	suppressed SuppressionCheck
 }
 
-// New creates a new Mailer instance.
+// New creates a Mailer that sends via the Resend API.
 func New(apiKey, from string) *Mailer {
-	return &Mailer{
-		apiKey: apiKey,
-		from:   from,
-		client: &http.Client{Timeout: 10 * time.Second},
-	}
+	return NewWithTransport(NewResendTransport(apiKey), from)
+}
+
+// NewWithTransport creates a Mailer that sends via the given transport.
+func NewWithTransport(transport Transport, from string) *Mailer {
+	return &Mailer{transport: transport, from: from}
+}
+
+// SetSuppressionCheck makes every send consult check before reaching the transport.
+func (m *Mailer) SetSuppressionCheck(check SuppressionCheck) {
+	m.suppressed = check
+}
+
+// From returns the sender address emails go out from.
+func (m *Mailer) From() string {
+	return m.from
+}
+
+func (m *Mailer) isSuppressed(email string, transactional bool) bool {
+	return m.suppressed != nil && m.suppressed(email, transactional)
 }
 
 // SendOptions configures an email to send.
@@ -32,87 +54,45 @@ type SendOptions struct {
 	Subject  string
 	Template string
 	Data     map[string]interface{}
+
+	Attachments []Attachment
 }
 
-// Send renders a template and sends the email via Resend.
+// Send renders a template and sends the email.
 func (m *Mailer) Send(ctx context.Context, opts SendOptions) error {
+	if m.isSuppressed(opts.To, true) {
+		return ErrSuppressed
+	}
+
 	// Render the email template
 	htmlBody, err := m.renderTemplate(opts.Template, opts.Data)
 	if err != nil {
 		return fmt.Errorf("rendering template %q: %w", opts.Template, err)
 	}
 
-	// Build Resend API payload
-	payload := map[string]interface{}{
-		"from":    m.from,
-		"to":      []string{opts.To},
-		"subject": opts.Subject,
-		"html":    htmlBody,
-	}
-
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshaling email payload: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+m.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := m.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("sending email: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		var errResp map[string]interface{}
-		_ = json.NewDecoder(resp.Body).Decode(&errResp)
-		return fmt.Errorf("resend API error (%d): %v", resp.StatusCode, errResp)
-	}
-
-	return nil
+	_, err = m.transport.Send(ctx, Message{
+		From:        m.from,
+		To:          opts.To,
+		Subject:     opts.Subject,
+		HTMLBody:    htmlBody,
+		Attachments: opts.Attachments,
+	})
+	return err
 }
 
 // SendRaw sends an email with raw HTML content (no template rendering).
 func (m *Mailer) SendRaw(ctx context.Context, to, subject, htmlBody string) error {
-	payload := map[string]interface{}{
-		"from":    m.from,
-		"to":      []string{to},
-		"subject": subject,
-		"html":    htmlBody,
+	if m.isSuppressed(to, true) {
+		return ErrSuppressed
 	}
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshaling email payload: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+m.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := m.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("sending email: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		var errResp map[string]interface{}
-		_ = json.NewDecoder(resp.Body).Decode(&errResp)
-		return fmt.Errorf("resend API error (%d): %v", resp.StatusCode, errResp)
-	}
-
-	return nil
+	_, err := m.transport.Send(ctx, Message{
+		From:     m.from,
+		To:       to,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+	})
+	return err
 }
 
 // CampaignEmailOptions configures a campaign email with custom from/reply-to.
@@ -122,52 +102,31 @@ type CampaignEmailOptions struct {
 	To       string
 	Subject  string
 	HTMLBody string
+	Headers  map[string]string
 }
 
-// SendCampaignEmail sends a campaign email with custom from/reply-to and returns the Resend message ID.
+// SendCampaignEmail sends a campaign email with custom from/reply-to and returns the transport's message ID.
 func (m *Mailer) SendCampaignEmail(ctx context.Context, opts CampaignEmailOptions) (string, error) {
+	if m.isSuppressed(opts.To, false) {
+		return "", ErrSuppressed
+	}
+
 	from := opts.From
 	if from == "" {
 		from = m.from
 	}
 
-	payload := map[string]interface{}{
-		"from":    from,
-		"to":      []string{opts.To},
-		"subject": opts.Subject,
-		"html":    opts.HTMLBody,
-	}
-	if opts.ReplyTo != "" {
-		payload["reply_to"] = opts.ReplyTo
-	}
-
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return "", fmt.Errorf("marshaling campaign email payload: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.resend.com/emails", bytes.NewReader(body))
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+m.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := m.client.Do(req)
+	messageID, err := m.transport.Send(ctx, Message{
+		From:     from,
+		ReplyTo:  opts.ReplyTo,
+		To:       opts.To,
+		Subject:  opts.Subject,
+		HTMLBody: opts.HTMLBody,
+		Headers:  opts.Headers,
+	})
 	if err != nil {
 		return "", fmt.Errorf("sending campaign email: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	_ = json.NewDecoder(resp.Body).Decode(&result)
-
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("resend API error (%d): %v", resp.StatusCode, result)
-	}
-
-	messageID, _ := result["id"].(string)
 	return messageID, nil
 }
 
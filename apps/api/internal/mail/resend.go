@@ -0,0 +1,81 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ResendTransport sends email through the Resend API.
+type ResendTransport struct {
+	apiKey string
+	client *http.Client
+}
+
+// NewResendTransport creates a Resend transport.
+func NewResendTransport(apiKey string) *ResendTransport {
+	return &ResendTransport{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send posts the message to Resend and returns the Resend message ID.
+func (t *ResendTransport) Send(ctx context.Context, msg Message) (string, error) {
+	payload := map[string]interface{}{
+		"from":    msg.From,
+		"to":      []string{msg.To},
+		"subject": msg.Subject,
+		"html":    msg.HTMLBody,
+	}
+	if msg.ReplyTo != "" {
+		payload["reply_to"] = msg.ReplyTo
+	}
+	if len(msg.Headers) > 0 {
+		payload["headers"] = msg.Headers
+	}
+	if len(msg.Attachments) > 0 {
+		attachments := make([]map[string]string, len(msg.Attachments))
+		for i, a := range msg.Attachments {
+			attachments[i] = map[string]string{
+				"filename":     a.Filename,
+				"content":      base64.StdEncoding.EncodeToString(a.Content),
+				"content_type": a.ContentType,
+			}
+		}
+		payload["attachments"] = attachments
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshaling email payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.resend.com/emails", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sending email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("resend API error (%d): %v", resp.StatusCode, result)
+	}
+
+	messageID, _ := result["id"].(string)
+	return messageID, nil
+}
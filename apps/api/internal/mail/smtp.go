@@ -0,0 +1,136 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"gritcms/apps/api/internal/config"
+)
+
+// SMTPTransport sends email through an SMTP server. Encryption is "starttls"
+// (upgrade a plain connection, the default), "tls" (implicit TLS, usually
+// port 465) or "none".
+type SMTPTransport struct {
+	host       string
+	port       string
+	username   string
+	password   string
+	encryption string
+}
+
+// NewSMTPTransport creates an SMTP transport.
+func NewSMTPTransport(cfg config.MailConfig) *SMTPTransport {
+	port := cfg.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+	encryption := cfg.SMTPEncryption
+	if encryption == "" {
+		encryption = "starttls"
+	}
+	return &SMTPTransport{
+		host:       cfg.SMTPHost,
+		port:       port,
+		username:   cfg.SMTPUsername,
+		password:   cfg.SMTPPassword,
+		encryption: encryption,
+	}
+}
+
+// Send delivers the message and returns its Message-ID.
+func (t *SMTPTransport) Send(ctx context.Context, msg Message) (string, error) {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid from address %q: %w", msg.From, err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return "", fmt.Errorf("invalid to address %q: %w", msg.To, err)
+	}
+
+	messageID := newMessageID(msg.From)
+	data, err := buildMIME(msg, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := t.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return "", fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return "", fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return "", fmt.Errorf("smtp RCPT TO: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return "", fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("smtp DATA: %w", err)
+	}
+	_ = client.Quit()
+
+	return messageID, nil
+}
+
+// dial connects to the server and negotiates TLS according to t.encryption.
+func (t *SMTPTransport) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(t.host, t.port)
+	tlsConfig := &tls.Config{ServerName: t.host}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	var conn net.Conn
+	var err error
+	if t.encryption == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connecting to smtp server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(30 * time.Second)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("smtp handshake: %w", err)
+	}
+
+	if t.encryption == "starttls" {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("smtp STARTTLS: %w", err)
+		}
+	}
+
+	return client, nil
+}
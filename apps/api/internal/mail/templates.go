@@ -4,8 +4,10 @@ package mail
 var EmailTemplates = map[string]string{
 	"welcome":            welcomeTemplate,
 	"password-reset":     passwordResetTemplate,
+	"password-changed":   passwordChangedTemplate,
 	"email-verification": emailVerificationTemplate,
 	"notification":       notificationTemplate,
+	"community-digest":   communityDigestTemplate,
 }
 
 const baseLayout = `<!DOCTYPE html>
@@ -94,11 +96,46 @@ const passwordResetTemplate = `<!DOCTYPE html>
     <div class="card">
       <div class="logo">{{.AppName}}</div>
       <h1>Reset Your Password</h1>
-      <p>We received a request to reset your password. Click the button below to set a new one:</p>
+      <p>Hi{{if .Name}} {{.Name}}{{end}}, we received a request to reset your password. Click the button below to set a new one:</p>
       <p style="text-align: center; margin-top: 24px;">
         <a href="{{.ResetURL}}" class="btn">Reset Password</a>
       </p>
-      <p>This link expires in 1 hour. If you didn't request this, you can safely ignore this email.</p>
+      <p>This link expires in {{or .Expiry "1 hour"}} and can only be used once. Resetting your password signs you out on every device.</p>
+      <p>If you didn't request this, you can safely ignore this email &mdash; your password won't change.</p>
+    </div>
+    <div class="footer">
+      <p>&copy; {{.Year}} {{.AppName}}. All rights reserved.</p>
+    </div>
+  </div>
+</body>
+</html>`
+
+const passwordChangedTemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <style>
+    body { margin: 0; padding: 0; background-color: #0a0a0f; color: #e8e8f0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; }
+    .container { max-width: 600px; margin: 0 auto; padding: 40px 20px; }
+    .card { background-color: #111118; border: 1px solid #2a2a3a; border-radius: 12px; padding: 32px; }
+    .logo { text-align: center; margin-bottom: 24px; font-size: 24px; font-weight: 700; color: #6c5ce7; }
+    h1 { font-size: 20px; margin: 0 0 16px; color: #e8e8f0; }
+    p { font-size: 14px; line-height: 1.6; color: #9090a8; margin: 0 0 16px; }
+    .btn { display: inline-block; background-color: #6c5ce7; color: #ffffff; text-decoration: none; padding: 12px 24px; border-radius: 8px; font-weight: 600; font-size: 14px; }
+    .footer { text-align: center; margin-top: 24px; font-size: 12px; color: #606078; }
+  </style>
+</head>
+<body>
+  <div class="container">
+    <div class="card">
+      <div class="logo">{{.AppName}}</div>
+      <h1>Your Password Was Changed</h1>
+      <p>Hi{{if .Name}} {{.Name}}{{end}}, the password for your account was just reset, and you have been signed out on every device.</p>
+      <p style="text-align: center; margin-top: 24px;">
+        <a href="{{.LoginURL}}" class="btn">Sign In</a>
+      </p>
+      <p>If you didn't do this, reset your password again right away and contact support.</p>
     </div>
     <div class="footer">
       <p>&copy; {{.Year}} {{.AppName}}. All rights reserved.</p>
@@ -128,10 +165,11 @@ const emailVerificationTemplate = `<!DOCTYPE html>
     <div class="card">
       <div class="logo">{{.AppName}}</div>
       <h1>Verify Your Email</h1>
-      <p>Please verify your email address by clicking the button below:</p>
+      <p>Hi{{if .Name}} {{.Name}}{{end}}, please confirm this is your email address by clicking the button below:</p>
       <p style="text-align: center; margin-top: 24px;">
         <a href="{{.VerifyURL}}" class="btn">Verify Email</a>
       </p>
+      <p>This link expires in {{or .Expiry "48 hours"}}. You can request a new one from your profile at any time.</p>
       <p>If you didn't create an account, you can safely ignore this email.</p>
     </div>
     <div class="footer">
@@ -175,3 +213,49 @@ const notificationTemplate = `<!DOCTYPE html>
   </div>
 </body>
 </html>`
+
+const communityDigestTemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <style>
+    body { margin: 0; padding: 0; background-color: #0a0a0f; color: #e8e8f0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; }
+    .container { max-width: 600px; margin: 0 auto; padding: 40px 20px; }
+    .card { background-color: #111118; border: 1px solid #2a2a3a; border-radius: 12px; padding: 32px; }
+    .logo { text-align: center; margin-bottom: 24px; font-size: 24px; font-weight: 700; color: #6c5ce7; }
+    h1 { font-size: 20px; margin: 0 0 16px; color: #e8e8f0; }
+    p { font-size: 14px; line-height: 1.6; color: #9090a8; margin: 0 0 16px; }
+    .item { border-top: 1px solid #2a2a3a; padding: 16px 0; }
+    .item a { color: #e8e8f0; font-size: 15px; font-weight: 600; text-decoration: none; }
+    .item p { margin: 6px 0 0; }
+    .btn { display: inline-block; background-color: #6c5ce7; color: #ffffff; text-decoration: none; padding: 12px 24px; border-radius: 8px; font-weight: 600; font-size: 14px; }
+    .footer { text-align: center; margin-top: 24px; font-size: 12px; color: #606078; }
+  </style>
+</head>
+<body>
+  <div class="container">
+    <div class="card">
+      <div class="logo">{{.AppName}}</div>
+      <h1>{{.Title}}</h1>
+      <p>{{.Message}}</p>
+      {{range .Items}}
+      <div class="item">
+        <a href="{{.URL}}">{{.Title}}</a>
+        {{if .Body}}<p>{{.Body}}</p>{{end}}
+      </div>
+      {{end}}
+      {{if .More}}<p>And {{.More}} more.</p>{{end}}
+      {{if .ActionURL}}
+      <p style="text-align: center; margin-top: 24px;">
+        <a href="{{.ActionURL}}" class="btn">{{.ActionText}}</a>
+      </p>
+      {{end}}
+    </div>
+    <div class="footer">
+      <p>You get this digest because of your community notification settings.</p>
+      <p>&copy; {{.Year}} {{.AppName}}. All rights reserved.</p>
+    </div>
+  </div>
+</body>
+</html>`
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hrefPattern matches the href attribute of an anchor tag, single or double quoted.
+var hrefPattern = regexp.MustCompile(`(?i)(<a\s[^>]*?\bhref\s*=\s*)("([^"]*)"|'([^']*)')`)
+
+// ClickSignature signs a click-tracking redirect so the tracker only follows
+// links that were rewritten at send time.
+func ClickSignature(secret string, sendID uint, target string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d\n%s", sendID, target)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyClickSignature reports whether sig was produced by ClickSignature.
+func VerifyClickSignature(secret string, sendID uint, target, sig string) bool {
+	return hmac.Equal([]byte(ClickSignature(secret, sendID, target)), []byte(sig))
+}
+
+// ClickTrackingURL returns the signed tracking URL that redirects to target.
+func ClickTrackingURL(baseURL, secret string, sendID uint, target string) string {
+	q := url.Values{}
+	q.Set("url", target)
+	q.Set("sig", ClickSignature(secret, sendID, target))
+	return fmt.Sprintf("%s/api/email/track/click/%d?%s", strings.TrimRight(baseURL, "/"), sendID, q.Encode())
+}
+
+// RewriteLinks replaces the target of every http(s) link in an HTML body with
+// track(target). Other links (mailto:, anchors, relative paths) are left alone.
+func RewriteLinks(htmlBody string, track func(target string) string) string {
+	return hrefPattern.ReplaceAllStringFunc(htmlBody, func(match string) string {
+		sub := hrefPattern.FindStringSubmatch(match)
+		raw, quote := sub[3], `"`
+		if strings.HasPrefix(sub[2], "'") {
+			raw, quote = sub[4], "'"
+		}
+
+		target := html.UnescapeString(strings.TrimSpace(raw))
+		lower := strings.ToLower(target)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			return match
+		}
+		return sub[1] + quote + html.EscapeString(track(target)) + quote
+	})
+}
@@ -0,0 +1,172 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"gritcms/apps/api/internal/config"
+)
+
+// Message is a fully rendered email handed to a Transport.
+type Message struct {
+	From     string // e.g. "Name <email@example.com>"
+	ReplyTo  string
+	To       string
+	Subject  string
+	HTMLBody string
+	Headers  map[string]string // extra headers, e.g. List-Unsubscribe
+
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a Message.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"` // e.g. "text/calendar; method=REQUEST"
+	Content     []byte `json:"content"`
+}
+
+// Transport delivers a Message and returns the provider's message ID.
+type Transport interface {
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// NewTransport builds the transport selected by cfg.Driver.
+func NewTransport(cfg config.MailConfig) (Transport, error) {
+	switch cfg.Driver {
+	case "", "resend":
+		if cfg.ResendAPIKey == "" || cfg.ResendAPIKey == "re_your_api_key" {
+			return nil, fmt.Errorf("RESEND_API_KEY is not set")
+		}
+		return NewResendTransport(cfg.ResendAPIKey), nil
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is not set")
+		}
+		return NewSMTPTransport(cfg), nil
+	case "file":
+		return NewFileTransport(cfg.FileDir, false)
+	case "maildir":
+		return NewFileTransport(cfg.FileDir, true)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// newMessageID returns a unique RFC 5322 Message-ID for the sender's domain.
+func newMessageID(from string) string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if i := strings.LastIndex(addr.Address, "@"); i >= 0 {
+			domain = addr.Address[i+1:]
+		}
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(b), domain)
+}
+
+// buildMIME renders msg as an RFC 5322 message with a quoted-printable HTML
+// body, wrapped in multipart/mixed when it has attachments.
+func buildMIME(msg Message, messageID string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := func(k, v string) {
+		buf.WriteString(k + ": " + v + "\r\n")
+	}
+	header("From", msg.From)
+	header("To", msg.To)
+	if msg.ReplyTo != "" {
+		header("Reply-To", msg.ReplyTo)
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID)
+	keys := make([]string, 0, len(msg.Headers))
+	for k := range msg.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		header(k, msg.Headers[k])
+	}
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", `text/html; charset="utf-8"`)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.HTMLBody); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header("Content-Type", `multipart/mixed; boundary="`+mw.Boundary()+`"`)
+	buf.WriteString("\r\n")
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {`text/html; charset="utf-8"`},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding body: %w", err)
+	}
+	var body bytes.Buffer
+	if err := writeQuotedPrintable(&body, msg.HTMLBody); err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(body.Bytes()); err != nil {
+		return nil, fmt.Errorf("encoding body: %w", err)
+	}
+
+	for _, a := range msg.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType + `; name="` + a.Filename + `"`},
+			"Content-Disposition":       {`attachment; filename="` + a.Filename + `"`},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encoding attachment %s: %w", a.Filename, err)
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Content)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("encoding message: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(buf *bytes.Buffer, body string) error {
+	qp := quotedprintable.NewWriter(buf)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("encoding body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("encoding body: %w", err)
+	}
+	return nil
+}
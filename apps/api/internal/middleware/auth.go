@@ -11,7 +11,8 @@ import (
 	"gritcms/apps/api/internal/services"
 )
 
-// Auth creates a JWT authentication middleware.
+// Auth creates an authentication middleware that accepts user JWTs and API
+// keys as bearer tokens.
 func Auth(db *gorm.DB, authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -38,6 +39,11 @@ func Auth(db *gorm.DB, authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		if services.IsAPIKey(parts[1]) {
+			authenticateAPIKey(c, db, parts[1])
+			return
+		}
+
 		claims, err := authService.ValidateToken(parts[1])
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -74,18 +80,41 @@ func Auth(db *gorm.DB, authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		// Tokens issued before the user's last password reset are revoked, as
+		// are tokens whose session was signed out.
+		var session models.UserSession
+		if claims.TokenVersion != user.TokenVersion ||
+			db.Where("id = ? AND user_id = ?", claims.SessionID, user.ID).First(&session).Error != nil ||
+			!session.IsActive() {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "Session has been revoked, please sign in again",
+				},
+			})
+			c.Abort()
+			return
+		}
+
 		c.Set("user", user)
 		c.Set("user_id", user.ID)
 		c.Set("user_role", user.Role)
 		c.Set("tenant_id", user.TenantID)
+		c.Set("session_id", session.ID)
 		c.Next()
 	}
 }
 
 // RequireRole creates a middleware that checks if the user has one of the required roles.
-// OWNER role always has access (superuser).
+// OWNER role always has access (superuser). Tenant API keys have no role and
+// are allowed through: Auth has already checked their scopes for the route.
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key, ok := c.Get("api_key"); ok && key.(*models.APIKey).UserID == nil {
+			c.Next()
+			return
+		}
+
 		userRole, exists := c.Get("user_role")
 		if !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -147,3 +176,49 @@ func RequireAdmin() gin.HandlerFunc {
 func RequireContent() gin.HandlerFunc {
 	return RequireRole("OWNER", "ADMIN", "EDITOR")
 }
+
+// RequireVerifiedEmail blocks users who haven't verified their email address.
+// It lets every request through when enabled is false, and never blocks staff.
+func RequireVerifiedEmail(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		user, ok := c.MustGet("user").(models.User)
+		if ok && (user.EmailVerifiedAt != nil || models.IsAdminRole(user.Role)) {
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "EMAIL_NOT_VERIFIED",
+				"message": "Please verify your email address to continue",
+			},
+		})
+		c.Abort()
+	}
+}
+
+// RequireTwoFactor blocks admin-role users who haven't enrolled in two-factor
+// authentication. It lets every request through when enabled is false, and
+// doesn't apply to API keys, which aren't used interactively.
+func RequireTwoFactor(db *gorm.DB, enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_, isAPIKey := c.Get("api_key")
+		if !enabled || isAPIKey || !models.IsAdminRole(c.GetString("user_role")) || services.TwoFactorEnabled(db, c.GetUint("user_id")) {
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "TWO_FACTOR_SETUP_REQUIRED",
+				"message": "Set up two-factor authentication in your profile to continue",
+			},
+		})
+		c.Abort()
+	}
+}
@@ -17,6 +17,7 @@ const (
 	CommissionApproved = "approved"
 	CommissionPaid     = "paid"
 	CommissionRejected = "rejected"
+	CommissionReversed = "reversed" // order refunded after the commission was earned
 
 	PayoutPending    = "pending"
 	PayoutProcessing = "processing"
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// --- API Keys ---
+
+// APIKeyPrefix starts every API key, so keys are easy to spot in logs and
+// secret scanners and can't be mistaken for a JWT.
+const APIKeyPrefix = "gcms_"
+
+// APIKeyResources are the areas an API key can be granted. Each has a
+// "<resource>:read" and a "<resource>:write" scope; write includes read.
+var APIKeyResources = []string{
+	"affiliates",
+	"analytics",
+	"booking",
+	"community",
+	"contacts",
+	"content",
+	"courses",
+	"email",
+	"funnels",
+	"media",
+	"orders",
+	"products",
+	"webhooks",
+	"workflows",
+}
+
+// ScopeEmailSend allows actually sending email (campaigns, one-off emails,
+// sequence enrollments), which email:write alone does not.
+const ScopeEmailSend = "email:send"
+
+// APIKeyScopes returns every scope that can be granted to a key, sorted.
+func APIKeyScopes() []string {
+	scopes := []string{ScopeEmailSend}
+	for _, r := range APIKeyResources {
+		scopes = append(scopes, r+":read", r+":write")
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// IsAPIKeyScope reports whether scope can be granted to a key.
+func IsAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is a long-lived credential for server-to-server access. A key
+// either acts as the user it was issued to, never with more access than
+// that user's role, or, with no user, on behalf of the whole tenant. Either
+// way it is limited to its scopes. Only the key's SHA-256 hash is stored.
+type APIKey struct {
+	ID          uint           `gorm:"primarykey" json:"id"`
+	TenantID    uint           `gorm:"index;not null;default:1" json:"tenant_id"`
+	UserID      *uint          `gorm:"index" json:"user_id"` // nil for tenant keys
+	Name        string         `gorm:"size:255;not null" json:"name"`
+	Prefix      string         `gorm:"size:20;not null" json:"prefix"` // first characters of the key, shown to tell keys apart
+	KeyHash     string         `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	Scopes      datatypes.JSON `gorm:"type:jsonb" json:"scopes"` // ["contacts:write", "email:send"]
+	ExpiresAt   *time.Time     `json:"expires_at"`
+	LastUsedAt  *time.Time     `json:"last_used_at"`
+	LastUsedIP  string         `gorm:"size:45" json:"last_used_ip"`
+	RevokedAt   *time.Time     `json:"revoked_at"`
+	CreatedByID uint           `json:"created_by_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// IsActive reports whether the key can still be used.
+func (k *APIKey) IsActive() bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || time.Now().Before(*k.ExpiresAt))
+}
+
+// HasScope reports whether the key grants scope. A write scope also grants
+// the matching read scope.
+func (k *APIKey) HasScope(scope string) bool {
+	var scopes []string
+	if k.Scopes != nil {
+		_ = json.Unmarshal(k.Scopes, &scopes)
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(scope, ":read"); ok && s == resource+":write" {
+			return true
+		}
+	}
+	return false
+}
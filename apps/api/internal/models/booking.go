@@ -1,8 +1,11 @@
 package models
 
 import (
+	"encoding/json"
+	"sort"
 	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +15,7 @@ const (
 	CalendarStatusActive   = "active"
 	CalendarStatusInactive = "inactive"
 
+	AppointmentPending     = "pending" // paid booking awaiting payment
 	AppointmentConfirmed   = "confirmed"
 	AppointmentCancelled   = "cancelled"
 	AppointmentRescheduled = "rescheduled"
@@ -30,8 +34,13 @@ type Calendar struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
-	EventTypes     []BookingEventType `gorm:"foreignKey:CalendarID" json:"event_types,omitempty"`
-	Availabilities []Availability     `gorm:"foreignKey:CalendarID" json:"availabilities,omitempty"`
+	// Google calendar checked for busy times when offering slots; empty
+	// uses the connected account's default calendar.
+	GoogleCalendarID string `gorm:"size:255" json:"google_calendar_id"`
+
+	EventTypes     []BookingEventType     `gorm:"foreignKey:CalendarID" json:"event_types,omitempty"`
+	Availabilities []Availability         `gorm:"foreignKey:CalendarID" json:"availabilities,omitempty"`
+	Overrides      []AvailabilityOverride `gorm:"foreignKey:CalendarID" json:"overrides,omitempty"`
 }
 
 type BookingEventType struct {
@@ -52,10 +61,37 @@ type BookingEventType struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Reminders go out ReminderOffsets minutes before an appointment; the
+	// follow-up email FollowUpDelayMinutes after it is marked complete.
+	ReminderOffsets      datatypes.JSON `gorm:"type:jsonb;default:'[1440,60]'" json:"reminder_offsets"` // []int
+	FollowUpEnabled      bool           `gorm:"default:false" json:"follow_up_enabled"`
+	FollowUpDelayMinutes int            `gorm:"default:60" json:"follow_up_delay_minutes"`
+	FollowUpSubject      string         `gorm:"size:255" json:"follow_up_subject"`
+	FollowUpMessage      string         `gorm:"type:text" json:"follow_up_message"`
+
+	// Paid appointments cancelled at least RefundWindowHours before they
+	// start are refunded.
+	RefundWindowHours int `gorm:"default:24" json:"refund_window_hours"`
+
+	// Slots start at least MinNoticeMinutes from now and at most
+	// MaxAdvanceDays ahead; 0 means no limit.
+	MinNoticeMinutes int `gorm:"default:0" json:"min_notice_minutes"`
+	MaxAdvanceDays   int `gorm:"default:0" json:"max_advance_days"`
+
+	// Event types can pool Hosts, calendars besides their own; each booking
+	// goes to one free host picked by AssignmentStrategy.
+	AssignmentStrategy string     `gorm:"size:20;default:'round_robin'" json:"assignment_strategy"`
+	Hosts              []Calendar `gorm:"many2many:booking_event_type_hosts" json:"hosts,omitempty"`
+
 	Calendar     *Calendar     `gorm:"foreignKey:CalendarID" json:"calendar,omitempty"`
 	Appointments []Appointment `gorm:"foreignKey:EventTypeID" json:"appointments,omitempty"`
 }
 
+const (
+	AssignRoundRobin  = "round_robin"  // the host booked least recently
+	AssignLeastBooked = "least_booked" // the host with the fewest upcoming bookings
+)
+
 type Availability struct {
 	ID         uint   `gorm:"primarykey" json:"id"`
 	TenantID   uint   `gorm:"index;not null;default:1" json:"tenant_id"`
@@ -80,6 +116,108 @@ type Appointment struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 
+	// Sequence is the .ics revision, bumped when the appointment is
+	// rescheduled or cancelled so calendars replace the earlier invite.
+	Sequence int `gorm:"default:0" json:"sequence"`
+
+	// Paid bookings stay pending, holding the slot until HoldExpiresAt,
+	// and are confirmed once their order is paid.
+	OrderID       *uint      `gorm:"index" json:"order_id"`
+	HoldExpiresAt *time.Time `gorm:"index" json:"hold_expires_at"`
+
+	// CalendarID is the host the booking was assigned to. Appointments
+	// booked before event types had hosts leave it unset and belong to
+	// their event type's calendar.
+	CalendarID *uint `gorm:"index" json:"calendar_id"`
+
 	EventType *BookingEventType `gorm:"foreignKey:EventTypeID" json:"event_type,omitempty"`
 	Contact   *Contact          `gorm:"foreignKey:ContactID" json:"contact,omitempty"`
+	Order     *Order            `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Calendar  *Calendar         `gorm:"foreignKey:CalendarID" json:"calendar,omitempty"`
+}
+
+// AvailabilityOverride replaces a calendar's weekly hours on one date. A row
+// without times blocks the whole day (a holiday); rows with times are the
+// only hours bookable that day, so they can add hours to a day off or cut a
+// day short.
+type AvailabilityOverride struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	TenantID   uint      `gorm:"index;not null;default:1" json:"tenant_id"`
+	CalendarID uint      `gorm:"index;not null" json:"calendar_id"`
+	Date       string    `gorm:"size:10;not null;index" json:"date"` // "2025-12-25"
+	StartTime  string    `gorm:"size:5" json:"start_time"`           // "09:00", empty when blocked
+	EndTime    string    `gorm:"size:5" json:"end_time"`             // "17:00", empty when blocked
+	Reason     string    `gorm:"size:255" json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Blocked reports whether the override makes the day unavailable.
+func (o AvailabilityOverride) Blocked() bool {
+	return o.StartTime == "" || o.EndTime == ""
+}
+
+// AppointmentHoldDuration is how long a pending paid booking holds its slot.
+const AppointmentHoldDuration = 15 * time.Minute
+
+// HostAppointments scopes a query to the appointments on a host calendar.
+func HostAppointments(calendarID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(calendar_id = ? OR (calendar_id IS NULL AND event_type_id IN (?)))", calendarID,
+			db.Session(&gorm.Session{NewDB: true}).Model(&BookingEventType{}).Select("id").Where("calendar_id = ?", calendarID))
+	}
+}
+
+// SlotTakingAppointments scopes a query to the appointments that occupy
+// their slot: everything but cancellations and lapsed payment holds.
+func SlotTakingAppointments(db *gorm.DB) *gorm.DB {
+	return db.Where("status != ? AND (status != ? OR hold_expires_at > ?)",
+		AppointmentCancelled, AppointmentPending, time.Now())
+}
+
+// ReminderMinutes returns the event type's reminder offsets in minutes before
+// the appointment, largest first, ignoring invalid and duplicate entries.
+func (et *BookingEventType) ReminderMinutes() []int {
+	var offsets []int
+	if et.ReminderOffsets != nil {
+		_ = json.Unmarshal(et.ReminderOffsets, &offsets)
+	}
+	seen := map[int]bool{}
+	minutes := []int{}
+	for _, m := range offsets {
+		if m > 0 && !seen[m] {
+			seen[m] = true
+			minutes = append(minutes, m)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(minutes)))
+	return minutes
+}
+
+// --- Appointment Reminders ---
+
+const (
+	ReminderKindReminder = "reminder"
+	ReminderKindFollowUp = "follow_up"
+
+	ReminderScheduled = "scheduled"
+	ReminderSent      = "sent"
+	ReminderCancelled = "cancelled"
+)
+
+// AppointmentReminder is a reminder or follow-up email queued as a delayed
+// job. The job only sends while the row is still scheduled, so cancelling
+// the row cancels the email.
+type AppointmentReminder struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	TenantID      uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	AppointmentID uint       `gorm:"index;not null" json:"appointment_id"`
+	Kind          string     `gorm:"size:20;not null" json:"kind"`    // reminder, follow_up
+	OffsetMinutes int        `gorm:"default:0" json:"offset_minutes"` // before the start, or after completion for follow-ups
+	SendAt        time.Time  `gorm:"index;not null" json:"send_at"`
+	Status        string     `gorm:"size:20;default:'scheduled';index" json:"status"`
+	TaskID        string     `gorm:"size:100" json:"task_id"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
@@ -64,6 +64,9 @@ type Price struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Stripe recurring price created on first subscription checkout; cleared when the amount or interval changes.
+	StripePriceID string `gorm:"size:255" json:"stripe_price_id"`
 }
 
 // --- Product Variants ---
@@ -108,9 +111,19 @@ type Order struct {
 	CouponID        *uint          `gorm:"index" json:"coupon_id"`
 	Metadata        datatypes.JSON `gorm:"type:jsonb" json:"metadata"`
 	PaidAt          *time.Time     `json:"paid_at"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Affiliate referral in effect when the order was placed. The commission
+	// is credited to it once the order is paid.
+	ReferredByAccountID *uint `gorm:"index" json:"referred_by_account_id"`
+	ReferralLinkID      *uint `json:"referral_link_id"`
+	// Affiliate credited with the sale; set once its commission is created.
+	AffiliateAccountID *uint `gorm:"index" json:"affiliate_account_id"`
+	// Subscription this order bills for: the initial signup or a renewal invoice.
+	SubscriptionID *uint `gorm:"index" json:"subscription_id"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	Contact  *Contact    `gorm:"foreignKey:ContactID" json:"contact,omitempty"`
 	Items    []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
@@ -171,10 +184,12 @@ type Coupon struct {
 // --- Subscriptions ---
 
 const (
-	SubscriptionActive    = "active"
-	SubscriptionPastDue   = "past_due"
-	SubscriptionCancelled = "cancelled"
-	SubscriptionPaused    = "paused"
+	SubscriptionIncomplete = "incomplete" // awaiting the first payment
+	SubscriptionTrialing   = "trialing"
+	SubscriptionActive     = "active"
+	SubscriptionPastDue    = "past_due"
+	SubscriptionCancelled  = "cancelled"
+	SubscriptionPaused     = "paused"
 )
 
 type Subscription struct {
@@ -190,6 +205,7 @@ type Subscription struct {
 	CurrentPeriodEnd       time.Time      `json:"current_period_end"`
 	CancelledAt            *time.Time     `json:"cancelled_at"`
 	CancelAtPeriodEnd      bool           `gorm:"default:false" json:"cancel_at_period_end"`
+	TrialEndsAt            *time.Time     `json:"trial_ends_at"`
 	CreatedAt              time.Time      `json:"created_at"`
 	UpdatedAt              time.Time      `json:"updated_at"`
 	DeletedAt              gorm.DeletedAt `gorm:"index" json:"-"`
@@ -198,3 +214,25 @@ type Subscription struct {
 	Product *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
 	Price   *Price   `gorm:"foreignKey:PriceID" json:"price,omitempty"`
 }
+
+// OwnsProduct reports whether a contact has bought a product outright or holds
+// a live subscription to it. Orders belonging to a subscription don't count on
+// their own, so access ends when the subscription does.
+func OwnsProduct(db *gorm.DB, contactID, productID uint) bool {
+	var count int64
+	db.Model(&OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("orders.contact_id = ? AND orders.status IN ? AND orders.subscription_id IS NULL AND order_items.product_id = ?",
+			contactID, []string{OrderStatusPaid, OrderStatusPartiallyRefunded}, productID).
+		Count(&count)
+	if count > 0 {
+		return true
+	}
+
+	db.Model(&Subscription{}).
+		Where("contact_id = ? AND product_id = ?", contactID, productID).
+		Where("status IN ? OR (status = ? AND current_period_end > ?)",
+			[]string{SubscriptionActive, SubscriptionTrialing, SubscriptionPastDue}, SubscriptionCancelled, time.Now()).
+		Count(&count)
+	return count > 0
+}
@@ -30,9 +30,15 @@ type Space struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Moderation: posts matching a blocked keyword, and a member's first post
+	// when RequireFirstPostApproval is set, are held for review.
+	BlockedKeywords          datatypes.JSON `gorm:"type:jsonb" json:"blocked_keywords"` // []string
+	RequireFirstPostApproval bool           `gorm:"default:false" json:"require_first_post_approval"`
+
 	Members     []CommunityMember `gorm:"foreignKey:SpaceID" json:"members,omitempty"`
 	MemberCount int64             `gorm:"-" json:"member_count,omitempty"`
 	ThreadCount int64             `gorm:"-" json:"thread_count,omitempty"`
+	Membership  *CommunityMember  `gorm:"-" json:"membership,omitempty"` // the requesting member's, on member routes
 }
 
 // --- Community Members ---
@@ -51,6 +57,7 @@ type CommunityMember struct {
 	Role       string         `gorm:"size:20;default:'member'" json:"role"`
 	JoinedAt   time.Time      `json:"joined_at"`
 	MutedUntil *time.Time     `json:"muted_until"`
+	BannedAt   *time.Time     `json:"banned_at"`
 	CreatedAt  time.Time      `json:"created_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 
@@ -87,10 +94,20 @@ type Thread struct {
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 
+	ModerationStatus string `gorm:"size:20;default:'approved';index" json:"moderation_status"`
+
 	Author  *Contact `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	Replies []Reply  `gorm:"foreignKey:ThreadID" json:"replies,omitempty"`
 }
 
+// --- Moderation ---
+
+const (
+	ModerationApproved = "approved"
+	ModerationPending  = "pending" // held for review; visible only to its author and moderators
+	ModerationHidden   = "hidden"
+)
+
 // --- Replies ---
 
 type Reply struct {
@@ -105,10 +122,69 @@ type Reply struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
+	ModerationStatus string `gorm:"size:20;default:'approved';index" json:"moderation_status"`
+
 	Author   *Contact `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
 	Children []Reply  `gorm:"foreignKey:ParentID" json:"children,omitempty"`
 }
 
+// --- Reports ---
+
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"  // the content was hidden or deleted
+	ReportStatusDismissed = "dismissed" // the content was approved
+)
+
+// ContentReport is a member flagging a thread or reply for moderators.
+type ContentReport struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	TenantID       uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	SpaceID        uint       `gorm:"index;not null" json:"space_id"`
+	ReportableType string     `gorm:"size:20;not null;index:idx_report_target" json:"reportable_type"` // thread, reply
+	ReportableID   uint       `gorm:"not null;index:idx_report_target" json:"reportable_id"`
+	ReporterID     uint       `gorm:"index;not null" json:"reporter_id"`     // contact_id
+	Reason         string     `gorm:"size:50;default:'other'" json:"reason"` // spam, abuse, off_topic, other
+	Details        string     `gorm:"type:text" json:"details"`
+	Status         string     `gorm:"size:20;default:'open';index" json:"status"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Reporter *Contact `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+}
+
+// --- Moderation Log ---
+
+const (
+	ModActionHold    = "hold" // automatic: keyword match or first post
+	ModActionApprove = "approve"
+	ModActionHide    = "hide"
+	ModActionDelete  = "delete"
+	ModActionDismiss = "dismiss"
+	ModActionMute    = "mute"
+	ModActionUnmute  = "unmute"
+	ModActionBan     = "ban"
+	ModActionUnban   = "unban"
+)
+
+// ModerationLog is the audit trail of moderation actions in a space.
+type ModerationLog struct {
+	ID             uint           `gorm:"primarykey" json:"id"`
+	TenantID       uint           `gorm:"index;not null;default:1" json:"tenant_id"`
+	SpaceID        uint           `gorm:"index;not null" json:"space_id"`
+	Action         string         `gorm:"size:20;not null;index" json:"action"`
+	TargetType     string         `gorm:"size:20;not null" json:"target_type"` // thread, reply, member, report
+	TargetID       uint           `gorm:"not null" json:"target_id"`
+	ActorUserID    *uint          `gorm:"index" json:"actor_user_id"` // nil for automatic actions
+	ActorContactID *uint          `json:"actor_contact_id"`
+	Reason         string         `gorm:"type:text" json:"reason"`
+	Metadata       datatypes.JSON `gorm:"type:jsonb" json:"metadata"`
+	CreatedAt      time.Time      `json:"created_at"`
+
+	ActorUser *User `gorm:"foreignKey:ActorUserID" json:"actor_user,omitempty"`
+}
+
 // --- Reactions ---
 
 type Reaction struct {
@@ -154,6 +230,7 @@ type CommunityEvent struct {
 
 	Attendees     []EventAttendee `gorm:"foreignKey:EventID" json:"attendees,omitempty"`
 	AttendeeCount int64           `gorm:"-" json:"attendee_count,omitempty"`
+	Registration  *EventAttendee  `gorm:"-" json:"registration,omitempty"` // the requesting member's, on member routes
 }
 
 // --- Event Attendees ---
@@ -168,3 +245,107 @@ type EventAttendee struct {
 
 	Contact *Contact `gorm:"foreignKey:ContactID" json:"contact,omitempty"`
 }
+
+// --- Follows ---
+
+const (
+	FollowableSpace  = "space"
+	FollowableThread = "thread"
+)
+
+// CommunityFollow subscribes a contact to a space's new threads or a thread's
+// replies. Posting in a thread follows it automatically; unfollowing keeps the
+// row, muted, so it isn't followed again.
+type CommunityFollow struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	TenantID       uint      `gorm:"index;not null;default:1" json:"tenant_id"`
+	ContactID      uint      `gorm:"uniqueIndex:idx_follow_unique;not null" json:"contact_id"`
+	FollowableType string    `gorm:"size:20;uniqueIndex:idx_follow_unique;index:idx_follow_target;not null" json:"followable_type"` // space, thread
+	FollowableID   uint      `gorm:"uniqueIndex:idx_follow_unique;index:idx_follow_target;not null" json:"followable_id"`
+	Muted          bool      `gorm:"default:false" json:"muted"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// --- Notifications ---
+
+const (
+	NotifyReply        = "reply"
+	NotifyMention      = "mention"
+	NotifyAnnouncement = "announcement"
+	NotifyNewThread    = "new_thread"
+)
+
+// CommunityNotification is an in-app notification about community activity.
+// Notifications not emailed on their own are sent in the contact's digest.
+type CommunityNotification struct {
+	ID         uint       `gorm:"primarykey" json:"id"`
+	TenantID   uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	ContactID  uint       `gorm:"index:idx_notification_contact;not null" json:"contact_id"`
+	Type       string     `gorm:"size:20;not null" json:"type"` // reply, mention, announcement, new_thread
+	ActorID    *uint      `json:"actor_id"`                     // the contact whose post triggered it
+	SpaceID    uint       `gorm:"index;not null" json:"space_id"`
+	ThreadID   uint       `gorm:"index;not null" json:"thread_id"`
+	ReplyID    *uint      `json:"reply_id"`
+	Title      string     `gorm:"size:500" json:"title"`
+	Body       string     `gorm:"type:text" json:"body"`
+	URL        string     `gorm:"size:500" json:"url"`
+	ReadAt     *time.Time `gorm:"index:idx_notification_contact" json:"read_at"`
+	EmailedAt  *time.Time `json:"emailed_at"`
+	DigestedAt *time.Time `json:"digested_at"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+
+	Actor *Contact `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// CommunityNotificationPreference is a contact's community notification
+// settings. Contacts without a row get the column defaults.
+type CommunityNotificationPreference struct {
+	ID                 uint       `gorm:"primarykey" json:"id"`
+	TenantID           uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	ContactID          uint       `gorm:"uniqueIndex;not null" json:"contact_id"`
+	EmailReplies       bool       `gorm:"default:true" json:"email_replies"`
+	EmailMentions      bool       `gorm:"default:true" json:"email_mentions"`
+	EmailAnnouncements bool       `gorm:"default:true" json:"email_announcements"`
+	AutoFollow         bool       `gorm:"default:true" json:"auto_follow"`        // follow threads you post in
+	Digest             string     `gorm:"size:20;default:'weekly'" json:"digest"` // off, daily, weekly
+	LastDigestAt       *time.Time `json:"last_digest_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// CommunityPreferencesFor returns the contact's notification preferences, or
+// the defaults if they haven't saved any.
+func CommunityPreferencesFor(db *gorm.DB, contactID uint) CommunityNotificationPreference {
+	pref := CommunityNotificationPreference{
+		TenantID:           1,
+		ContactID:          contactID,
+		EmailReplies:       true,
+		EmailMentions:      true,
+		EmailAnnouncements: true,
+		AutoFollow:         true,
+		Digest:             DigestWeekly,
+	}
+	db.Where("contact_id = ?", contactID).First(&pref)
+	return pref
+}
+
+// EmailsInstantly reports whether a notification type is emailed as it
+// happens rather than left for the digest.
+func (p *CommunityNotificationPreference) EmailsInstantly(notificationType string) bool {
+	switch notificationType {
+	case NotifyReply:
+		return p.EmailReplies
+	case NotifyMention:
+		return p.EmailMentions
+	case NotifyAnnouncement:
+		return p.EmailAnnouncements
+	}
+	return false
+}
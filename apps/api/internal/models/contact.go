@@ -1,6 +1,8 @@
 package models
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"gorm.io/datatypes"
@@ -34,9 +36,18 @@ type Contact struct {
 	CustomFields   datatypes.JSON `gorm:"type:jsonb" json:"custom_fields"`
 	UserID         *uint          `gorm:"index" json:"user_id"` // Optional link to a User account
 	LastActivityAt *time.Time     `gorm:"index" json:"last_activity_at"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	EmailToken     string         `gorm:"size:64;index" json:"-"` // opens the email preferences page
+
+	// Affiliate attribution: the last referral link the contact followed.
+	ReferredByAccountID *uint      `gorm:"index" json:"referred_by_account_id"`
+	ReferralLinkID      *uint      `json:"referral_link_id"`
+	ReferredAt          *time.Time `json:"referred_at"`
+
+	StripeCustomerID string `gorm:"size:255;index" json:"stripe_customer_id"` // created on first subscription checkout
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	Tags       []Tag             `gorm:"many2many:contact_tags" json:"tags,omitempty"`
@@ -64,6 +75,25 @@ func (c *Contact) FullName() string {
 	return name
 }
 
+// EnsureEmailToken returns the contact's email preferences token, generating
+// and saving one the first time it is needed.
+func (c *Contact) EnsureEmailToken(db *gorm.DB) string {
+	if c.EmailToken != "" {
+		return c.EmailToken
+	}
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+	// Only fill an empty token so a concurrent caller's token isn't overwritten.
+	res := db.Model(&Contact{}).Where("id = ? AND (email_token = '' OR email_token IS NULL)", c.ID).
+		Update("email_token", token)
+	if res.RowsAffected == 0 {
+		db.Model(&Contact{}).Select("email_token").Where("id = ?", c.ID).Scan(&token)
+	}
+	c.EmailToken = token
+	return token
+}
+
 // Tag represents a label that can be applied to contacts.
 type Tag struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
@@ -21,6 +21,13 @@ const (
 	CourseAccessMembership = "membership"
 )
 
+// Drip modes control when lessons unlock for an enrolled student.
+const (
+	DripModeEnrollment = "enrollment" // DripDelayDays after the student enrolled
+	DripModeSequential = "sequential" // once the previous lesson is completed (and DripDelayDays have passed)
+	DripModeDate       = "date"       // on each lesson's DripDate, for cohort courses
+)
+
 // Course represents an online course.
 type Course struct {
 	ID               uint           `gorm:"primarykey" json:"id"`
@@ -34,6 +41,7 @@ type Course struct {
 	Currency         string         `gorm:"size:3;default:'USD'" json:"currency"`
 	Status           string         `gorm:"size:20;default:'draft';index" json:"status"`
 	AccessType       string         `gorm:"size:20;default:'free'" json:"access_type"`
+	DripMode         string         `gorm:"size:20;default:'enrollment'" json:"drip_mode"`
 	ProductID        *uint          `gorm:"index" json:"product_id"`
 	InstructorID     *uint          `gorm:"index" json:"instructor_id"`
 	PublishedAt      *time.Time     `json:"published_at"`
@@ -89,6 +97,7 @@ type Lesson struct {
 	SortOrder       int            `gorm:"default:0" json:"sort_order"`
 	IsFreePreview   bool           `gorm:"default:false" json:"is_free_preview"`
 	DripDelayDays   int            `gorm:"default:0" json:"drip_delay_days"`
+	DripDate        *time.Time     `json:"drip_date"` // unlock date in the "date" drip mode
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
@@ -96,6 +105,60 @@ type Lesson struct {
 	Quizzes []Quiz `gorm:"foreignKey:LessonID;constraint:OnDelete:CASCADE" json:"quizzes,omitempty"`
 }
 
+// --- Lesson Drip ---
+
+// LessonAccess reports whether a lesson is available to a student.
+type LessonAccess struct {
+	LessonID     uint       `json:"lesson_id"`
+	Unlocked     bool       `json:"unlocked"`
+	UnlocksAt    *time.Time `json:"unlocks_at"`              // when a drip-locked lesson opens
+	LockedReason string     `json:"locked_reason,omitempty"` // not_enrolled, drip, previous_lesson
+}
+
+// LessonUnlockAt returns when a lesson's drip schedule opens it for a student
+// enrolled at enrolledAt, or nil if it is available straight away.
+func LessonUnlockAt(course *Course, lesson *Lesson, enrolledAt time.Time) *time.Time {
+	if course.DripMode == DripModeDate {
+		return lesson.DripDate
+	}
+	if lesson.DripDelayDays <= 0 {
+		return nil
+	}
+	t := enrolledAt.AddDate(0, 0, lesson.DripDelayDays)
+	return &t
+}
+
+// CourseLessonAccess works out which lessons an enrollment can open, in course
+// order. The course's modules and lessons must be loaded and sorted; completed
+// holds the IDs of the lessons the student has completed. A nil enrollment
+// only opens free previews.
+func CourseLessonAccess(course *Course, enrollment *CourseEnrollment, completed map[uint]bool, now time.Time) []LessonAccess {
+	var access []LessonAccess
+	prevDone := true
+	for _, mod := range course.Modules {
+		for i := range mod.Lessons {
+			lesson := &mod.Lessons[i]
+			a := LessonAccess{LessonID: lesson.ID, Unlocked: true}
+
+			switch {
+			case lesson.IsFreePreview || completed[lesson.ID]:
+			case enrollment == nil:
+				a.Unlocked, a.LockedReason = false, "not_enrolled"
+			default:
+				if at := LessonUnlockAt(course, lesson, enrollment.EnrolledAt); at != nil && now.Before(*at) {
+					a.Unlocked, a.UnlocksAt, a.LockedReason = false, at, "drip"
+				} else if course.DripMode == DripModeSequential && !prevDone {
+					a.Unlocked, a.LockedReason = false, "previous_lesson"
+				}
+			}
+
+			access = append(access, a)
+			prevDone = completed[lesson.ID]
+		}
+	}
+	return access
+}
+
 // --- Course Enrollments ---
 
 const (
@@ -116,6 +179,7 @@ type CourseEnrollment struct {
 	CompletedAt        *time.Time `json:"completed_at"`
 	ProgressPercentage float64    `gorm:"type:decimal(5,2);default:0" json:"progress_percentage"`
 	Source             string     `gorm:"size:50" json:"source"` // purchase, manual, coupon, free
+	DripNotifiedAt     *time.Time `json:"drip_notified_at"`      // lessons unlocked up to here have been emailed
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 
@@ -148,6 +212,17 @@ type LessonProgress struct {
 	Lesson Lesson `gorm:"foreignKey:LessonID" json:"lesson,omitempty"`
 }
 
+// CompletedLessonIDs returns the IDs of the completed lessons in progresses.
+func CompletedLessonIDs(progresses []LessonProgress) map[uint]bool {
+	completed := make(map[uint]bool, len(progresses))
+	for _, p := range progresses {
+		if p.Status == ProgressCompleted {
+			completed[p.LessonID] = true
+		}
+	}
+	return completed
+}
+
 // --- Quizzes ---
 
 // Quiz is an assessment attached to a lesson.
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/datatypes"
@@ -16,6 +17,7 @@ type EmailList struct {
 	Name           string         `gorm:"size:255;not null" json:"name"`
 	Description    string         `gorm:"size:500" json:"description"`
 	DoubleOptin    bool           `gorm:"default:false" json:"double_optin"`
+	Topic          string         `gorm:"size:100" json:"topic"` // groups lists on the preferences page
 	WelcomeEmailID *uint          `gorm:"index" json:"welcome_email_id"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
@@ -85,6 +87,7 @@ const (
 	CampaignStatusDraft     = "draft"
 	CampaignStatusScheduled = "scheduled"
 	CampaignStatusSending   = "sending"
+	CampaignStatusTesting   = "testing" // A/B variants sent, waiting to pick a winner
 	CampaignStatusSent      = "sent"
 	CampaignStatusCancelled = "cancelled"
 )
@@ -108,6 +111,17 @@ type EmailCampaign struct {
 	ScheduledAt  *time.Time     `json:"scheduled_at"`
 	SentAt       *time.Time     `json:"sent_at"`
 	Stats        datatypes.JSON `gorm:"type:jsonb" json:"stats"` // { sent, delivered, opened, clicked, bounced, unsubscribed }
+
+	// A/B testing: with two to four variants, TestPercent of the audience is
+	// split between them and the rest gets the winner after TestWaitHours.
+	Variants       datatypes.JSON `gorm:"type:jsonb" json:"variants"`      // []CampaignVariant
+	TestPercent    int            `gorm:"default:20" json:"test_percent"`
+	TestWaitHours  int            `gorm:"default:4" json:"test_wait_hours"`
+	WinnerMetric   string         `gorm:"size:20;default:'open_rate'" json:"winner_metric"`
+	WinningVariant string         `gorm:"size:10" json:"winning_variant"`
+	TestEndsAt     *time.Time     `json:"test_ends_at"`
+	VariantStats   datatypes.JSON `gorm:"type:jsonb" json:"variant_stats"` // map[variant key]CampaignVariantStats
+
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -123,9 +137,109 @@ type CampaignStats struct {
 	Opened       int `json:"opened"`
 	Clicked      int `json:"clicked"`
 	Bounced      int `json:"bounced"`
+	Complained   int `json:"complained"`
 	Unsubscribed int `json:"unsubscribed"`
 }
 
+// Winner metric constants
+const (
+	WinnerMetricOpenRate  = "open_rate"
+	WinnerMetricClickRate = "click_rate"
+)
+
+// CampaignVariant is one version of an A/B tested campaign. Empty fields fall
+// back to the campaign's own subject and content.
+type CampaignVariant struct {
+	Key         string `json:"key"` // "A", "B", ...
+	Subject     string `json:"subject"`
+	TemplateID  *uint  `json:"template_id"`
+	HTMLContent string `json:"html_content"`
+}
+
+// CampaignVariantStats holds analytics for one variant of a campaign.
+type CampaignVariantStats struct {
+	CampaignStats
+	Recipients int     `json:"recipients"`
+	OpenRate   float64 `json:"open_rate"`
+	ClickRate  float64 `json:"click_rate"`
+}
+
+// ComputeCampaignStats tallies a campaign's sends by status.
+func ComputeCampaignStats(db *gorm.DB, campaignID uint) CampaignStats {
+	// Count sends by status
+	type StatusCount struct {
+		Status string
+		Count  int64
+	}
+	var counts []StatusCount
+	db.Model(&EmailSend{}).Select("status, count(*) as count").
+		Where("campaign_id = ?", campaignID).Group("status").Find(&counts)
+
+	stats := CampaignStats{}
+	for _, sc := range counts {
+		stats.add(sc.Status, int(sc.Count))
+	}
+	stats.Sent += stats.Delivered + stats.Opened + stats.Clicked + stats.Complained // cumulative
+	return stats
+}
+
+// ComputeVariantStats tallies a campaign's sends per A/B variant. Opens and
+// clicks count every send that was ever opened or clicked, whatever its
+// current status, so rates compare fairly between variants.
+func ComputeVariantStats(db *gorm.DB, campaignID uint) map[string]CampaignVariantStats {
+	type variantCount struct {
+		Variant string
+		Status  string
+		Count   int64
+		Opened  int64
+		Clicked int64
+	}
+	var counts []variantCount
+	db.Model(&EmailSend{}).
+		Select("variant, status, count(*) as count, count(COALESCE(opened_at, clicked_at)) as opened, count(clicked_at) as clicked").
+		Where("campaign_id = ? AND variant <> ''", campaignID).Group("variant, status").Find(&counts)
+
+	result := map[string]CampaignVariantStats{}
+	opened := map[string]int64{}
+	clicked := map[string]int64{}
+	for _, vc := range counts {
+		vs := result[vc.Variant]
+		vs.add(vc.Status, int(vc.Count))
+		if vc.Status != SendStatusQueued && vc.Status != SendStatusFailed {
+			vs.Recipients += int(vc.Count)
+		}
+		result[vc.Variant] = vs
+		opened[vc.Variant] += vc.Opened
+		clicked[vc.Variant] += vc.Clicked
+	}
+	for key, vs := range result {
+		vs.Sent += vs.Delivered + vs.Opened + vs.Clicked + vs.Complained
+		if vs.Recipients > 0 {
+			vs.OpenRate = float64(opened[key]) / float64(vs.Recipients)
+			vs.ClickRate = float64(clicked[key]) / float64(vs.Recipients)
+		}
+		result[key] = vs
+	}
+	return result
+}
+
+func (s *CampaignStats) add(status string, n int) {
+	switch status {
+	case SendStatusSent:
+		s.Sent += n
+	case SendStatusDelivered:
+		s.Delivered += n
+	case SendStatusOpened:
+		s.Opened += n
+	case SendStatusClicked:
+		s.Clicked += n
+	case SendStatusBounced:
+		s.Bounced += n
+	case SendStatusComplained:
+		s.Complained += n
+	}
+}
+
 // --- Email Sends (individual sends tracking) ---
 
 const (
@@ -134,8 +248,9 @@ const (
 	SendStatusDelivered = "delivered"
 	SendStatusOpened    = "opened"
 	SendStatusClicked   = "clicked"
-	SendStatusBounced   = "bounced"
-	SendStatusFailed    = "failed"
+	SendStatusBounced    = "bounced"
+	SendStatusComplained = "complained"
+	SendStatusFailed     = "failed"
 )
 
 // EmailSend tracks an individual email delivery to a contact.
@@ -145,6 +260,7 @@ type EmailSend struct {
 	ContactID      uint       `gorm:"index;not null" json:"contact_id"`
 	CampaignID     *uint      `gorm:"index" json:"campaign_id"`
 	SequenceStepID *uint      `gorm:"index" json:"sequence_step_id"`
+	Variant        string     `gorm:"size:10;index" json:"variant"` // A/B test variant key, if any
 	Subject        string     `gorm:"size:500" json:"subject"`
 	Status         string     `gorm:"size:20;default:'queued';index" json:"status"`
 	ExternalID     string     `gorm:"size:255;index" json:"external_id"` // Resend message ID
@@ -160,6 +276,36 @@ type EmailSend struct {
 	SequenceStep *EmailSequenceStep `gorm:"foreignKey:SequenceStepID" json:"sequence_step,omitempty"`
 }
 
+// EmailClickEvent records one click on a tracked link in an email.
+type EmailClickEvent struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	TenantID   uint      `gorm:"index;not null;default:1" json:"tenant_id"`
+	SendID     uint      `gorm:"index;not null" json:"send_id"`
+	CampaignID *uint     `gorm:"index" json:"campaign_id"`
+	ContactID  uint      `gorm:"index;not null" json:"contact_id"`
+	URL        string    `gorm:"type:text;not null" json:"url"`
+	UserAgent  string    `gorm:"type:text" json:"user_agent"`
+	IPAddress  string    `gorm:"size:45" json:"ip_address"`
+	ClickedAt  time.Time `gorm:"index" json:"clicked_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CampaignLinkStats holds click analytics for one link in a campaign.
+type CampaignLinkStats struct {
+	URL            string `json:"url"`
+	Clicks         int    `json:"clicks"`
+	UniqueClickers int    `json:"unique_clickers"`
+}
+
+// ComputeLinkStats returns click counts per link of a campaign, most clicked first.
+func ComputeLinkStats(db *gorm.DB, campaignID uint) []CampaignLinkStats {
+	links := []CampaignLinkStats{}
+	db.Model(&EmailClickEvent{}).
+		Select("url, count(*) as clicks, count(DISTINCT contact_id) as unique_clickers").
+		Where("campaign_id = ?", campaignID).Group("url").Order("clicks DESC").Find(&links)
+	return links
+}
+
 // --- Email Sequences ---
 
 const (
@@ -181,6 +327,7 @@ type EmailSequence struct {
 	Description string         `gorm:"size:500" json:"description"`
 	Trigger     string         `gorm:"size:50;default:'manual'" json:"trigger"` // manual or event-based
 	TriggerEvent string        `gorm:"size:100" json:"trigger_event"`           // e.g. "email.subscribed"
+	ExitEvent   string         `gorm:"size:100" json:"exit_event"`              // e.g. "purchase.completed" ends the sequence
 	Status      string         `gorm:"size:20;default:'draft';index" json:"status"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -215,6 +362,7 @@ const (
 	EnrollmentStatusActive    = "active"
 	EnrollmentStatusCompleted = "completed"
 	EnrollmentStatusCancelled = "cancelled"
+	EnrollmentStatusExited    = "exited"
 )
 
 // EmailSequenceEnrollment tracks a contact's progress through a sequence.
@@ -269,3 +417,71 @@ type SegmentRuleGroup struct {
 	Operator string        `json:"operator"` // "and" | "or"
 	Rules    []SegmentRule `json:"rules"`
 }
+
+// --- Suppressions ---
+
+const (
+	SuppressionUnsubscribedAll = "unsubscribed_all"
+	SuppressionBounced         = "bounced"
+	SuppressionComplained      = "complained"
+	SuppressionManual          = "manual"
+)
+
+// EmailSuppression blocks an address, or a whole domain, from receiving further
+// email whichever list, tag or segment it is reached through. Exactly one of
+// Email and Domain is set. Unsubscribed-all entries only block marketing mail;
+// every other reason blocks transactional mail too.
+type EmailSuppression struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	TenantID  uint      `gorm:"uniqueIndex:idx_suppression_entry;not null;default:1" json:"tenant_id"`
+	Email     string    `gorm:"uniqueIndex:idx_suppression_entry;size:255;not null;default:''" json:"email"`   // lowercased
+	Domain    string    `gorm:"uniqueIndex:idx_suppression_entry;size:255;not null;default:''" json:"domain"` // lowercased
+	ContactID *uint     `gorm:"index" json:"contact_id"`
+	Reason    string    `gorm:"size:30;not null;index" json:"reason"`
+	Source    string    `gorm:"size:100" json:"source"` // e.g. "resend", "admin", "preferences"
+	Details   string    `gorm:"type:text" json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FindSuppression returns the suppression entry matching an address or its
+// domain, or nil when the address may be mailed.
+func FindSuppression(db *gorm.DB, email string) *EmailSuppression {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+	domain := email
+	if i := strings.LastIndex(email, "@"); i >= 0 {
+		domain = email[i+1:]
+	}
+
+	var entry EmailSuppression
+	if err := db.Where("(email = ? AND email <> '') OR (domain = ? AND domain <> '')", email, domain).
+		First(&entry).Error; err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// IsEmailSuppressed reports whether marketing email to an address is blocked.
+func IsEmailSuppressed(db *gorm.DB, email string) bool {
+	return FindSuppression(db, email) != nil
+}
+
+// IsEmailBlocked is IsEmailSuppressed for a given kind of mail: transactional
+// email still reaches contacts who only unsubscribed from everything.
+func IsEmailBlocked(db *gorm.DB, email string, transactional bool) bool {
+	entry := FindSuppression(db, email)
+	if entry == nil {
+		return false
+	}
+	return !transactional || entry.Reason != SuppressionUnsubscribedAll
+}
+
+// ExcludeSuppressed narrows a contacts query to addresses that may receive
+// marketing email.
+func ExcludeSuppressed(q *gorm.DB) *gorm.DB {
+	return q.Where("LOWER(email) NOT IN (SELECT email FROM email_suppressions WHERE email <> '')").
+		Where("SPLIT_PART(LOWER(email), '@', 2) NOT IN (SELECT domain FROM email_suppressions WHERE domain <> '')")
+}
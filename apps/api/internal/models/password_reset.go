@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PasswordResetTTL is how long a password reset link stays valid.
+const PasswordResetTTL = time.Hour
+
+// PasswordResetToken is a single-use password reset link. Only the SHA-256
+// hash of the token is stored; the token itself exists only in the email.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	TenantID  uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
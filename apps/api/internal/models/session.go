@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// --- Sessions ---
+
+// Reasons a session was revoked.
+const (
+	SessionRevokedLogout        = "logout"
+	SessionRevokedByUser        = "revoked"
+	SessionRevokedByAdmin       = "admin"
+	SessionRevokedPasswordReset = "password_reset"
+	SessionRevokedDeactivated   = "deactivated"
+	SessionRevokedDeleted       = "deleted"
+	SessionRevokedReuse         = "token_reuse"
+)
+
+// UserSession is one signed-in device. Access tokens carry the session ID,
+// so revoking the session signs that device out on its next request.
+type UserSession struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	TenantID      uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	UserID        uint       `gorm:"index;not null" json:"user_id"`
+	Device        string     `gorm:"size:255" json:"device"` // "Chrome on macOS"
+	IPAddress     string     `gorm:"size:45" json:"ip_address"`
+	UserAgent     string     `gorm:"type:text" json:"user_agent"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	ExpiresAt     time.Time  `gorm:"index;not null" json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason string     `gorm:"size:50" json:"revoked_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Current marks the session the request was made with.
+	Current bool `gorm:"-" json:"current"`
+}
+
+// IsActive reports whether the session can still be used.
+func (s *UserSession) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// RefreshToken is one refresh token issued to a session. Each refresh
+// rotates it: the presented token is marked rotated and a new one issued, so
+// a rotated token turning up again means it was stolen.
+type RefreshToken struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	SessionID uint       `gorm:"index;not null" json:"session_id"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	RotatedAt *time.Time `json:"rotated_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -19,3 +19,12 @@ type Setting struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// GetSetting returns a setting's value, or fallback when it isn't set.
+func GetSetting(db *gorm.DB, key, fallback string) string {
+	var setting Setting
+	if err := db.Where("tenant_id = ? AND key = ?", 1, key).First(&setting).Error; err != nil || setting.Value == "" {
+		return fallback
+	}
+	return setting.Value
+}
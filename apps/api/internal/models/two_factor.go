@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// --- Two-Factor Authentication ---
+
+// UserTwoFactor holds a user's TOTP secret. The row exists from the moment
+// setup starts; 2FA is only on once EnabledAt is set by confirming a code.
+type UserTwoFactor struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	TenantID       uint       `gorm:"index;not null;default:1" json:"tenant_id"`
+	UserID         uint       `gorm:"uniqueIndex;not null" json:"user_id"`
+	Secret         string     `gorm:"size:64;not null" json:"-"`
+	EnabledAt      *time.Time `json:"enabled_at"`
+	LastUsedStep   int64      `json:"-"` // TOTP time step of the last accepted code, to stop replays
+	FailedAttempts int        `gorm:"default:0" json:"-"`
+	LockedUntil    *time.Time `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Enabled reports whether logins must present a second factor.
+func (t *UserTwoFactor) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TwoFactorRecoveryCode is a single-use code for signing in without the
+// authenticator app. Only its SHA-256 hash is stored.
+type TwoFactorRecoveryCode struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -52,6 +52,8 @@ type User struct {
 	GoogleID        string         `gorm:"size:255" json:"-"`
 	GithubID        string         `gorm:"size:255" json:"-"`
 	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	VerifySentAt    *time.Time     `json:"-"`                           // last verification email, for rate limiting
+	TokenVersion    int            `gorm:"not null;default:0" json:"-"` // bumped to sign the user out everywhere
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
@@ -143,6 +145,23 @@ func Models() []interface{} {
 		&Workflow{},
 		&WorkflowAction{},
 		&WorkflowExecution{},
+		&EmailSuppression{},
+		&EmailClickEvent{},
+		&ContentReport{},
+		&ModerationLog{},
+		&CommunityFollow{},
+		&CommunityNotification{},
+		&CommunityNotificationPreference{},
+		&AppointmentReminder{},
+		&AvailabilityOverride{},
+		&WebhookEndpoint{},
+		&WebhookDelivery{},
+		&PasswordResetToken{},
+		&UserSession{},
+		&RefreshToken{},
+		&UserTwoFactor{},
+		&TwoFactorRecoveryCode{},
+		&APIKey{},
 		// grit:models
 	}
 }
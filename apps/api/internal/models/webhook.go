@@ -0,0 +1,81 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// --- Outbound Webhooks ---
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryRetrying  = "retrying"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookAllEvents subscribes an endpoint to every event.
+const WebhookAllEvents = "*"
+
+// WebhookEndpoint is an external URL that receives signed event payloads.
+type WebhookEndpoint struct {
+	ID          uint           `gorm:"primarykey" json:"id"`
+	TenantID    uint           `gorm:"index;not null;default:1" json:"tenant_id"`
+	Name        string         `gorm:"size:255;not null" json:"name"`
+	URL         string         `gorm:"size:2048;not null" json:"url"`
+	Description string         `gorm:"type:text" json:"description"`
+	Secret      string         `gorm:"size:100;not null" json:"secret"` // signs each delivery
+	Events      datatypes.JSON `gorm:"type:jsonb" json:"events"`        // ["purchase.completed"] or ["*"]
+	Active      bool           `gorm:"default:true" json:"active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Subscribes reports whether the endpoint wants an event.
+func (e WebhookEndpoint) Subscribes(event string) bool {
+	var names []string
+	if e.Events != nil {
+		_ = json.Unmarshal(e.Events, &names)
+	}
+	for _, n := range names {
+		if n == event || n == WebhookAllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWebhookSecret returns a random signing secret.
+func NewWebhookSecret() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+// WebhookDelivery is one attempt series to send one event to one endpoint.
+// Redelivering creates a new row, so the log keeps every send.
+type WebhookDelivery struct {
+	ID           uint           `gorm:"primarykey" json:"id"`
+	TenantID     uint           `gorm:"index;not null;default:1" json:"tenant_id"`
+	EndpointID   uint           `gorm:"index;not null" json:"endpoint_id"`
+	EventID      string         `gorm:"size:50;index" json:"event_id"` // shared by every delivery of one event
+	Event        string         `gorm:"size:100;index" json:"event"`
+	Payload      datatypes.JSON `gorm:"type:jsonb" json:"payload"`
+	Status       string         `gorm:"size:20;default:'pending';index" json:"status"`
+	Attempts     int            `gorm:"default:0" json:"attempts"`
+	ResponseCode int            `json:"response_code"`
+	ResponseBody string         `gorm:"type:text" json:"response_body"`
+	Error        string         `gorm:"type:text" json:"error"`
+	DurationMs   int64          `json:"duration_ms"`
+	DeliveredAt  *time.Time     `json:"delivered_at"`
+	CreatedAt    time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+
+	Endpoint *WebhookEndpoint `gorm:"foreignKey:EndpointID" json:"endpoint,omitempty"`
+}
@@ -19,6 +19,26 @@ const (
 	ExecutionFailed    = "failed"
 )
 
+const (
+	TriggerEvent    = "event"
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+const (
+	ActionSendEmail      = "send_email"
+	ActionAddTag         = "add_tag"
+	ActionRemoveTag      = "remove_tag"
+	ActionEnrollCourse   = "enroll_course"
+	ActionAddToList      = "add_to_list"
+	ActionRemoveFromList = "remove_from_list"
+	ActionWait           = "wait"
+	ActionWebhook        = "webhook"
+	ActionUpdateContact  = "update_contact"
+	ActionCreateNote     = "create_note"
+	ActionCondition      = "condition"
+)
+
 type Workflow struct {
 	ID             uint           `gorm:"primarykey" json:"id"`
 	TenantID       uint           `gorm:"index;not null;default:1" json:"tenant_id"`
@@ -28,6 +48,7 @@ type Workflow struct {
 	TriggerConfig  datatypes.JSON `gorm:"type:jsonb" json:"trigger_config"`    // e.g. {"event": "purchase.completed"}
 	Status         string         `gorm:"size:20;default:'draft'" json:"status"`
 	ExecutionCount int64          `gorm:"default:0" json:"execution_count"`
+	LastRunAt      *time.Time     `json:"last_run_at"` // last time a schedule trigger fired
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
@@ -64,3 +85,39 @@ type WorkflowExecution struct {
 	Workflow *Workflow `gorm:"foreignKey:WorkflowID" json:"workflow,omitempty"`
 	Contact  *Contact  `gorm:"foreignKey:ContactID" json:"contact,omitempty"`
 }
+
+// WorkflowCondition is the shape of a "condition" action's Config and of any
+// action's Condition. Field/Operator/Value use the same vocabulary as SegmentRule.
+// On a "condition" action a false result jumps to ElseStep (or ends the run when
+// nil); on any other action it skips that step.
+type WorkflowCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+	ElseStep *int   `json:"else_step,omitempty"`
+}
+
+// WorkflowSchedule is the TriggerConfig of a "schedule" workflow. Cron is a
+// standard five-field expression (a "CRON_TZ=Area/City " prefix is allowed).
+// Every contact in the segment, or carrying the tag, gets an execution.
+type WorkflowSchedule struct {
+	Cron      string `json:"cron"`
+	SegmentID uint   `json:"segment_id,omitempty"`
+	TagID     uint   `json:"tag_id,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+}
+
+// HasAudience reports whether the schedule targets a segment or a tag.
+func (s WorkflowSchedule) HasAudience() bool {
+	return s.SegmentID > 0 || s.TagID > 0 || s.Tag != ""
+}
+
+// WorkflowLogEntry is one line in WorkflowExecution.Log.
+type WorkflowLogEntry struct {
+	Step     int       `json:"step"`
+	ActionID uint      `json:"action_id"`
+	Type     string    `json:"type"`
+	Status   string    `json:"status"` // completed, skipped, waiting, failed
+	Message  string    `json:"message"`
+	At       time.Time `json:"at"`
+}
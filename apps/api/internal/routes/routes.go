@@ -101,7 +101,7 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 				cfg.GORMStudioUsername: cfg.GORMStudioPassword,
 			})
 		}
-		studio.Mount(r, db, []interface{}{&models.Tenant{}, &models.User{}, &models.Upload{}, &models.Blog{}, &models.Setting{}, &models.MediaAsset{}, &models.Tag{}, &models.Contact{}, &models.ContactActivity{}, &models.CustomFieldDefinition{}, &models.Page{}, &models.Post{}, &models.PostCategory{}, &models.PostTag{}, &models.Menu{}, &models.MenuItem{}, &models.EmailList{}, &models.EmailSubscription{}, &models.EmailTemplate{}, &models.EmailCampaign{}, &models.EmailSend{}, &models.EmailSequence{}, &models.EmailSequenceStep{}, &models.EmailSequenceEnrollment{}, &models.Segment{}, &models.Course{}, &models.CourseModule{}, &models.Lesson{}, &models.CourseEnrollment{}, &models.LessonProgress{}, &models.Quiz{}, &models.QuizQuestion{}, &models.QuizAttempt{}, &models.Certificate{}, &models.Product{}, &models.Price{}, &models.ProductVariant{}, &models.Coupon{}, &models.Order{}, &models.OrderItem{}, &models.Subscription{}, &models.Space{}, &models.CommunityMember{}, &models.Thread{}, &models.Reply{}, &models.Reaction{}, &models.CommunityEvent{}, &models.EventAttendee{}, &models.Funnel{}, &models.FunnelStep{}, &models.FunnelVisit{}, &models.FunnelConversion{}, &models.Calendar{}, &models.BookingEventType{}, &models.Availability{}, &models.Appointment{}, &models.AffiliateProgram{}, &models.AffiliateAccount{}, &models.AffiliateLink{}, &models.Commission{}, &models.Payout{}, &models.Workflow{}, &models.WorkflowAction{}, &models.WorkflowExecution{} /* grit:studio */}, studioCfg)
+		studio.Mount(r, db, []interface{}{&models.Tenant{}, &models.User{}, &models.Upload{}, &models.Blog{}, &models.Setting{}, &models.MediaAsset{}, &models.Tag{}, &models.Contact{}, &models.ContactActivity{}, &models.CustomFieldDefinition{}, &models.Page{}, &models.Post{}, &models.PostCategory{}, &models.PostTag{}, &models.Menu{}, &models.MenuItem{}, &models.EmailList{}, &models.EmailSubscription{}, &models.EmailTemplate{}, &models.EmailCampaign{}, &models.EmailSend{}, &models.EmailSequence{}, &models.EmailSequenceStep{}, &models.EmailSequenceEnrollment{}, &models.Segment{}, &models.Course{}, &models.CourseModule{}, &models.Lesson{}, &models.CourseEnrollment{}, &models.LessonProgress{}, &models.Quiz{}, &models.QuizQuestion{}, &models.QuizAttempt{}, &models.Certificate{}, &models.Product{}, &models.Price{}, &models.ProductVariant{}, &models.Coupon{}, &models.Order{}, &models.OrderItem{}, &models.Subscription{}, &models.Space{}, &models.CommunityMember{}, &models.Thread{}, &models.Reply{}, &models.Reaction{}, &models.CommunityEvent{}, &models.EventAttendee{}, &models.Funnel{}, &models.FunnelStep{}, &models.FunnelVisit{}, &models.FunnelConversion{}, &models.Calendar{}, &models.BookingEventType{}, &models.Availability{}, &models.Appointment{}, &models.AffiliateProgram{}, &models.AffiliateAccount{}, &models.AffiliateLink{}, &models.Commission{}, &models.Payout{}, &models.Workflow{}, &models.WorkflowAction{}, &models.WorkflowExecution{}, &models.EmailSuppression{}, &models.EmailClickEvent{}, &models.ContentReport{}, &models.ModerationLog{}, &models.CommunityFollow{}, &models.CommunityNotification{}, &models.CommunityNotificationPreference{}, &models.AppointmentReminder{}, &models.AvailabilityOverride{}, &models.WebhookEndpoint{}, &models.WebhookDelivery{}, &models.PasswordResetToken{}, &models.UserSession{}, &models.RefreshToken{}, &models.UserTwoFactor{}, &models.TwoFactorRecoveryCode{}, &models.APIKey{} /* grit:studio */}, studioCfg)
 		log.Println("GORM Studio mounted at /studio")
 	}
 
@@ -112,7 +112,7 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		Version:     "1.0.0",
 		UI:          gindocs.UIScalar,
 		ScalarTheme: "kepler",
-		Models:      []interface{}{&models.Tenant{}, &models.User{}, &models.Upload{}, &models.Blog{}, &models.Setting{}, &models.MediaAsset{}, &models.Tag{}, &models.Contact{}, &models.ContactActivity{}, &models.CustomFieldDefinition{}, &models.Page{}, &models.Post{}, &models.PostCategory{}, &models.PostTag{}, &models.Menu{}, &models.MenuItem{}, &models.EmailList{}, &models.EmailSubscription{}, &models.EmailTemplate{}, &models.EmailCampaign{}, &models.EmailSend{}, &models.EmailSequence{}, &models.EmailSequenceStep{}, &models.EmailSequenceEnrollment{}, &models.Segment{}, &models.Course{}, &models.CourseModule{}, &models.Lesson{}, &models.CourseEnrollment{}, &models.LessonProgress{}, &models.Quiz{}, &models.QuizQuestion{}, &models.QuizAttempt{}, &models.Certificate{}, &models.Product{}, &models.Price{}, &models.ProductVariant{}, &models.Coupon{}, &models.Order{}, &models.OrderItem{}, &models.Subscription{}, &models.Space{}, &models.CommunityMember{}, &models.Thread{}, &models.Reply{}, &models.Reaction{}, &models.CommunityEvent{}, &models.EventAttendee{}, &models.Funnel{}, &models.FunnelStep{}, &models.FunnelVisit{}, &models.FunnelConversion{}, &models.Calendar{}, &models.BookingEventType{}, &models.Availability{}, &models.Appointment{}, &models.AffiliateProgram{}, &models.AffiliateAccount{}, &models.AffiliateLink{}, &models.Commission{}, &models.Payout{}, &models.Workflow{}, &models.WorkflowAction{}, &models.WorkflowExecution{}},
+		Models:      []interface{}{&models.Tenant{}, &models.User{}, &models.Upload{}, &models.Blog{}, &models.Setting{}, &models.MediaAsset{}, &models.Tag{}, &models.Contact{}, &models.ContactActivity{}, &models.CustomFieldDefinition{}, &models.Page{}, &models.Post{}, &models.PostCategory{}, &models.PostTag{}, &models.Menu{}, &models.MenuItem{}, &models.EmailList{}, &models.EmailSubscription{}, &models.EmailTemplate{}, &models.EmailCampaign{}, &models.EmailSend{}, &models.EmailSequence{}, &models.EmailSequenceStep{}, &models.EmailSequenceEnrollment{}, &models.Segment{}, &models.Course{}, &models.CourseModule{}, &models.Lesson{}, &models.CourseEnrollment{}, &models.LessonProgress{}, &models.Quiz{}, &models.QuizQuestion{}, &models.QuizAttempt{}, &models.Certificate{}, &models.Product{}, &models.Price{}, &models.ProductVariant{}, &models.Coupon{}, &models.Order{}, &models.OrderItem{}, &models.Subscription{}, &models.Space{}, &models.CommunityMember{}, &models.Thread{}, &models.Reply{}, &models.Reaction{}, &models.CommunityEvent{}, &models.EventAttendee{}, &models.Funnel{}, &models.FunnelStep{}, &models.FunnelVisit{}, &models.FunnelConversion{}, &models.Calendar{}, &models.BookingEventType{}, &models.Availability{}, &models.Appointment{}, &models.AffiliateProgram{}, &models.AffiliateAccount{}, &models.AffiliateLink{}, &models.Commission{}, &models.Payout{}, &models.Workflow{}, &models.WorkflowAction{}, &models.WorkflowExecution{}, &models.EmailSuppression{}, &models.EmailClickEvent{}, &models.ContentReport{}, &models.ModerationLog{}, &models.CommunityFollow{}, &models.CommunityNotification{}, &models.CommunityNotificationPreference{}, &models.AppointmentReminder{}, &models.AvailabilityOverride{}, &models.WebhookEndpoint{}, &models.WebhookDelivery{}, &models.PasswordResetToken{}, &models.UserSession{}, &models.RefreshToken{}, &models.UserTwoFactor{}, &models.TwoFactorRecoveryCode{}, &models.APIKey{}},
 		Auth: gindocs.AuthConfig{
 			Type:         gindocs.AuthBearer,
 			BearerFormat: "JWT",
@@ -165,6 +165,14 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		DB:          db,
 		AuthService: authService,
 		Config:      cfg,
+		Mailer:      svc.Mailer,
+	}
+	sessionHandler := &handlers.SessionHandler{
+		DB: db,
+	}
+	twoFactorHandler := &handlers.TwoFactorHandler{
+		DB:     db,
+		Config: cfg,
 	}
 	userHandler := &handlers.UserHandler{
 		DB: db,
@@ -188,17 +196,24 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	postHandler := handlers.NewPostHandler(db)
 	menuHandler := handlers.NewMenuHandler(db)
 	settingHandler := handlers.NewSettingHandler(db)
-	emailHandler := handlers.NewEmailHandler(db, svc.Jobs)
+	sequenceTriggers := services.NewSequenceTriggers(db)
+	emailHandler := handlers.NewEmailHandler(db, svc.Jobs, sequenceTriggers, cfg)
+	emailWebhookHandler := handlers.NewEmailWebhookHandler(db, cfg)
 	courseHandler := handlers.NewCourseHandler(db)
 	commerceHandler := handlers.NewCommerceHandler(db, svc.Cache)
 	analyticsHandler := handlers.NewAnalyticsHandler(db)
 	communityHandler := handlers.NewCommunityHandler(db)
 	funnelHandler := handlers.NewFunnelHandler(db)
 	meetingService := integrations.NewMeetingService(db, cfg)
-	bookingHandler := handlers.NewBookingHandler(db, meetingService, cfg)
+	bookingHandler := handlers.NewBookingHandler(db, meetingService, svc.Jobs, cfg)
 	affiliateHandler := handlers.NewAffiliateHandler(db)
-	workflowHandler := handlers.NewWorkflowHandler(db)
+	workflowTriggers := services.NewWorkflowTriggers(db, svc.Jobs)
+	workflowHandler := handlers.NewWorkflowHandler(db, svc.Jobs, workflowTriggers)
 	paymentHandler := handlers.NewPaymentHandler(db, cfg)
+	webhookHandler := handlers.NewWebhookHandler(db, svc.Jobs)
+	apiKeyHandler := &handlers.APIKeyHandler{
+		DB: db,
+	}
 	// grit:handlers
 
 	// Health check
@@ -236,7 +251,10 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	r.GET("/api/p/email/lists/:id", publicCache, emailHandler.GetPublicList)
 	r.POST("/api/email/subscribe", emailHandler.Subscribe)
 	r.GET("/api/email/confirm/:token", emailHandler.ConfirmSubscription)
+	r.GET("/api/email/unsubscribe", emailHandler.UnsubscribePage)
 	r.POST("/api/email/unsubscribe", emailHandler.Unsubscribe)
+	r.GET("/api/email/preferences/:token", emailHandler.GetPreferences)
+	r.PUT("/api/email/preferences/:token", emailHandler.UpdatePreferences)
 	r.GET("/api/email/track/open/:id", emailHandler.TrackOpen)
 	r.GET("/api/email/track/click/:id", emailHandler.TrackClick)
 
@@ -272,6 +290,9 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	// Stripe webhook (public, no auth — Stripe sends events here)
 	r.POST("/api/webhooks/stripe", paymentHandler.StripeWebhook)
 
+	// Resend webhook (public, signed — delivery, bounce and complaint notifications)
+	r.POST("/api/webhooks/resend", emailWebhookHandler.ResendWebhook)
+
 	// Public Stripe config (publishable key)
 	r.GET("/api/p/stripe/config", paymentHandler.StripeConfig)
 
@@ -283,9 +304,11 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	{
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/login/2fa", authHandler.LoginTwoFactor)
 		auth.POST("/refresh", authHandler.Refresh)
 		auth.POST("/forgot-password", authHandler.ForgotPassword)
 		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
 	}
 
 	// OAuth2 social login
@@ -301,6 +324,7 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	{
 		protected.GET("/auth/me", authHandler.Me)
 		protected.POST("/auth/logout", authHandler.Logout)
+		protected.POST("/auth/resend-verification", authHandler.ResendVerification)
 
 		// User routes (authenticated)
 		protected.GET("/users/:id", userHandler.GetByID)
@@ -319,6 +343,7 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		protected.POST("/ai/stream", aiHandler.Stream)
 
 		// Student routes (any authenticated user)
+		verifiedEmail := middleware.RequireVerifiedEmail(cfg.RequireEmailVerification)
 		student := protected.Group("/student")
 		{
 			student.GET("/courses", courseHandler.StudentGetCourses)
@@ -329,6 +354,48 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 			// Purchases
 			student.GET("/purchases", commerceHandler.StudentGetPurchases)
 			student.GET("/purchases/:orderId", commerceHandler.StudentGetPurchase)
+
+			// Community
+			student.GET("/community/spaces", communityHandler.StudentListSpaces)
+			student.GET("/community/spaces/:id", communityHandler.StudentGetSpace)
+			student.POST("/community/spaces/:id/join", communityHandler.StudentJoinSpace)
+			student.DELETE("/community/spaces/:id/join", communityHandler.StudentLeaveSpace)
+			student.GET("/community/spaces/:id/threads", communityHandler.StudentListThreads)
+			student.POST("/community/spaces/:id/threads", verifiedEmail, communityHandler.StudentCreateThread)
+			student.GET("/community/threads/:threadId", communityHandler.StudentGetThread)
+			student.PUT("/community/threads/:threadId", communityHandler.StudentUpdateThread)
+			student.DELETE("/community/threads/:threadId", communityHandler.StudentDeleteThread)
+			student.POST("/community/threads/:threadId/replies", verifiedEmail, communityHandler.StudentCreateReply)
+			student.PUT("/community/replies/:replyId", communityHandler.StudentUpdateReply)
+			student.DELETE("/community/replies/:replyId", communityHandler.StudentDeleteReply)
+			student.POST("/community/reactions", communityHandler.StudentToggleReaction)
+			student.GET("/community/events", communityHandler.StudentListEvents)
+			student.POST("/community/events/:eventId/register", communityHandler.StudentRegisterForEvent)
+			student.DELETE("/community/events/:eventId/register", communityHandler.StudentCancelEventRegistration)
+
+			// Community moderation (reports from members; queue and actions for space moderators)
+			student.POST("/community/reports", communityHandler.StudentReportContent)
+			student.GET("/community/spaces/:id/moderation/queue", communityHandler.ModerationQueue)
+			student.GET("/community/spaces/:id/moderation/logs", communityHandler.ModerationLogs)
+			student.POST("/community/moderation/:type/:targetId/approve", communityHandler.ApproveContent)
+			student.POST("/community/moderation/:type/:targetId/hide", communityHandler.HideContent)
+			student.DELETE("/community/moderation/:type/:targetId", communityHandler.DeleteContent)
+			student.POST("/community/reports/:reportId/dismiss", communityHandler.DismissReport)
+			student.POST("/community/members/:memberId/mute", communityHandler.MuteMember)
+			student.POST("/community/members/:memberId/unmute", communityHandler.UnmuteMember)
+			student.POST("/community/members/:memberId/ban", communityHandler.BanMember)
+			student.POST("/community/members/:memberId/unban", communityHandler.UnbanMember)
+
+			// Community notifications, preferences and follows
+			student.GET("/community/notifications", communityHandler.StudentListNotifications)
+			student.POST("/community/notifications/read-all", communityHandler.StudentMarkAllNotificationsRead)
+			student.POST("/community/notifications/:notificationId/read", communityHandler.StudentMarkNotificationRead)
+			student.GET("/community/notification-preferences", communityHandler.StudentGetNotificationPreferences)
+			student.PUT("/community/notification-preferences", communityHandler.StudentUpdateNotificationPreferences)
+			student.POST("/community/spaces/:id/follow", communityHandler.StudentFollowSpace)
+			student.DELETE("/community/spaces/:id/follow", communityHandler.StudentUnfollowSpace)
+			student.POST("/community/threads/:threadId/follow", communityHandler.StudentFollowThread)
+			student.DELETE("/community/threads/:threadId/follow", communityHandler.StudentUnfollowThread)
 		}
 
 		// Checkout (any authenticated user)
@@ -345,17 +412,29 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		profile.GET("", userHandler.GetProfile)
 		profile.PUT("", userHandler.UpdateProfile)
 		profile.DELETE("", userHandler.DeleteProfile)
+		profile.GET("/sessions", sessionHandler.ListMine)
+		profile.DELETE("/sessions", sessionHandler.RevokeOthers)
+		profile.DELETE("/sessions/:id", sessionHandler.RevokeMine)
+		profile.GET("/2fa", twoFactorHandler.Status)
+		profile.POST("/2fa/setup", twoFactorHandler.Setup)
+		profile.POST("/2fa/enable", twoFactorHandler.Enable)
+		profile.POST("/2fa/disable", twoFactorHandler.Disable)
+		profile.POST("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
 	}
 
 	// Admin routes
 	admin := r.Group("/api")
 	admin.Use(middleware.Auth(db, authService))
 	admin.Use(middleware.RequireRole("ADMIN"))
+	admin.Use(middleware.RequireTwoFactor(db, cfg.RequireAdminTwoFactor))
 	{
 		admin.GET("/users", userHandler.List)
 		admin.POST("/users", userHandler.Create)
 		admin.PUT("/users/:id", userHandler.Update)
 		admin.DELETE("/users/:id", userHandler.Delete)
+		admin.GET("/users/:id/sessions", sessionHandler.ListForUser)
+		admin.POST("/users/:id/logout", sessionHandler.ForceLogout)
+		admin.DELETE("/users/:id/2fa", twoFactorHandler.ResetForUser)
 
 		// Admin system routes
 		admin.GET("/admin/jobs/stats", jobsHandler.Stats)
@@ -490,6 +569,11 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		admin.DELETE("/email/segments/:id", emailHandler.DeleteSegment)
 		admin.GET("/email/segments/:id/preview", emailHandler.PreviewSegment)
 
+		// Email suppression list (admin)
+		admin.GET("/email/suppressions", emailHandler.ListSuppressions)
+		admin.POST("/email/suppressions", emailHandler.CreateSuppression)
+		admin.DELETE("/email/suppressions/:id", emailHandler.DeleteSuppression)
+
 		// Email sends log & dashboard (admin)
 		admin.GET("/email/sends", emailHandler.ListSends)
 		admin.GET("/email/dashboard", emailHandler.DashboardStats)
@@ -633,6 +717,18 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		admin.POST("/community/events/:eventId/register", communityHandler.RegisterForEvent)
 		admin.DELETE("/community/events/:eventId/attendees/:attendeeId", communityHandler.CancelRegistration)
 
+		// Community moderation
+		admin.GET("/community/moderation/queue", communityHandler.ModerationQueue)
+		admin.GET("/community/moderation/logs", communityHandler.ModerationLogs)
+		admin.POST("/community/moderation/:type/:targetId/approve", communityHandler.ApproveContent)
+		admin.POST("/community/moderation/:type/:targetId/hide", communityHandler.HideContent)
+		admin.DELETE("/community/moderation/:type/:targetId", communityHandler.DeleteContent)
+		admin.POST("/community/reports/:reportId/dismiss", communityHandler.DismissReport)
+		admin.POST("/community/members/:memberId/mute", communityHandler.MuteMember)
+		admin.POST("/community/members/:memberId/unmute", communityHandler.UnmuteMember)
+		admin.POST("/community/members/:memberId/ban", communityHandler.BanMember)
+		admin.POST("/community/members/:memberId/unban", communityHandler.UnbanMember)
+
 		// Funnels (admin)
 		admin.GET("/funnels", funnelHandler.ListFunnels)
 		admin.GET("/funnels/:id", funnelHandler.GetFunnel)
@@ -661,6 +757,9 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 
 		// Booking availability (admin)
 		admin.PUT("/booking/calendars/:id/availability", bookingHandler.SetAvailability)
+		admin.GET("/booking/calendars/:id/overrides", bookingHandler.ListOverrides)
+		admin.POST("/booking/calendars/:id/overrides", bookingHandler.CreateOverride)
+		admin.DELETE("/booking/overrides/:overrideId", bookingHandler.DeleteOverride)
 
 		// Booking appointments (admin)
 		admin.GET("/booking/appointments", bookingHandler.ListAppointments)
@@ -723,6 +822,24 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 		admin.GET("/workflows/executions", workflowHandler.ListExecutions)
 		admin.GET("/workflows/executions/:execId", workflowHandler.GetExecution)
 
+		// Outbound webhooks (admin)
+		admin.GET("/webhook-endpoints/events", webhookHandler.ListEvents)
+		admin.GET("/webhook-endpoints", webhookHandler.ListEndpoints)
+		admin.GET("/webhook-endpoints/:id", webhookHandler.GetEndpoint)
+		admin.POST("/webhook-endpoints", webhookHandler.CreateEndpoint)
+		admin.PUT("/webhook-endpoints/:id", webhookHandler.UpdateEndpoint)
+		admin.DELETE("/webhook-endpoints/:id", webhookHandler.DeleteEndpoint)
+		admin.POST("/webhook-endpoints/:id/rotate-secret", webhookHandler.RotateSecret)
+		admin.GET("/webhook-deliveries", webhookHandler.ListDeliveries)
+		admin.GET("/webhook-deliveries/:deliveryId", webhookHandler.GetDelivery)
+		admin.POST("/webhook-deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+
+		// API keys (admin)
+		admin.GET("/api-keys/scopes", apiKeyHandler.ListScopes)
+		admin.GET("/api-keys", apiKeyHandler.List)
+		admin.POST("/api-keys", apiKeyHandler.Create)
+		admin.DELETE("/api-keys/:id", apiKeyHandler.Revoke)
+
 		// System info (admin)
 		admin.GET("/admin/system/info", func(c *gin.Context) {
 			var dbVersion string
@@ -773,5 +890,21 @@ func Setup(db *gorm.DB, cfg *config.Config, svc *Services) *gin.Engine {
 	// Register contact activity event listeners
 	services.RegisterActivityListeners(db)
 
+	// Credit affiliates for referred purchases and reverse commissions on refunds
+	services.RegisterAffiliateListeners(db)
+
+	// Notify community followers, space members and @mentioned contacts
+	services.RegisterCommunityNotificationListeners(db, svc.Jobs)
+
+	// Confirm paid bookings when their order is paid
+	services.RegisterBookingPaymentListeners(db, meetingService, svc.Jobs)
+
+	// Forward events to registered outbound webhook endpoints
+	services.RegisterWebhookListeners(db, svc.Jobs)
+
+	// Subscribe active event-triggered workflows and email sequences
+	workflowTriggers.Reload()
+	sequenceTriggers.Reload()
+
 	return r
 }
@@ -186,6 +186,34 @@ func RegisterActivityListeners(db *gorm.DB) {
 			"Cancelled subscription", m)
 	})
 
+	bus.On(events.SubscriptionCreated, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		contactID := toUint(m["contact_id"])
+		if contactID == 0 {
+			return
+		}
+		var product models.Product
+		db.First(&product, toUint(m["product_id"]))
+		logActivity(db, contactID, 1, "commerce", "subscribed",
+			fmt.Sprintf("Subscribed to \"%s\"", product.Name), m)
+	})
+
+	bus.On(events.SubscriptionPastDue, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		contactID := toUint(m["contact_id"])
+		if contactID == 0 {
+			return
+		}
+		logActivity(db, contactID, 1, "commerce", "subscription_past_due",
+			"Subscription payment failed", m)
+	})
+
 	// --- Community events ---
 	bus.On(events.CommunityMemberJoined, func(data interface{}) {
 		m, ok := data.(map[string]interface{})
@@ -278,6 +306,26 @@ func RegisterActivityListeners(db *gorm.DB) {
 		logActivity(db, contactID, 1, "affiliates", "referral", "Referred a new visitor", m)
 	})
 
+	bus.On(events.AffiliateCommission, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		contactID := toUint(m["contact_id"])
+		if contactID == 0 {
+			return
+		}
+		status, _ := m["status"].(string)
+		amount, _ := m["amount"].(int64)
+		if status == models.CommissionReversed {
+			logActivity(db, contactID, 1, "affiliates", "commission_reversed",
+				fmt.Sprintf("Commission of $%.2f reversed (order refunded)", float64(amount)/100), m)
+			return
+		}
+		logActivity(db, contactID, 1, "affiliates", "commission_earned",
+			fmt.Sprintf("Earned a $%.2f commission", float64(amount)/100), m)
+	})
+
 	log.Println("[activity] Registered contact activity listeners")
 }
 
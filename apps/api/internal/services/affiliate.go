@@ -0,0 +1,277 @@
+package services
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+)
+
+// RegisterAffiliateListeners credits affiliates for purchases by contacts they
+// referred, and claws the commission back when the order is refunded.
+func RegisterAffiliateListeners(db *gorm.DB) {
+	bus := events.Default()
+
+	bus.On(events.PurchaseCompleted, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if orderID := toUint(m["order_id"]); orderID != 0 {
+			CreateOrderCommission(db, orderID)
+		}
+	})
+
+	bus.On(events.PurchaseRefunded, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if orderID := toUint(m["order_id"]); orderID != 0 {
+			ReverseOrderCommissions(db, orderID)
+		}
+	})
+
+	log.Println("[affiliate] Registered commission listeners")
+}
+
+// RecordReferral attributes a contact to the affiliate owning code as of
+// clickedAt, when the contact followed the referral link. A referral still
+// within its program's cookie window is kept, so a later link can't take over
+// the sale, and a click older than the window is ignored. Affiliates can't
+// refer themselves.
+func RecordReferral(db *gorm.DB, contact *models.Contact, code string, linkID *uint, clickedAt time.Time) bool {
+	if contact == nil || contact.ID == 0 || code == "" || clickedAt.After(time.Now()) {
+		return false
+	}
+	var account models.AffiliateAccount
+	if err := db.Preload("Program").Where("referral_code = ? AND status = ?", code, models.AffiliateStatusActive).
+		First(&account).Error; err != nil {
+		return false
+	}
+	if account.ContactID == contact.ID || !referralCurrent(account.Program, clickedAt) {
+		return false
+	}
+	if ActiveReferral(db, contact) != nil {
+		return false
+	}
+	if linkID != nil {
+		var count int64
+		db.Model(&models.AffiliateLink{}).Where("id = ? AND account_id = ?", *linkID, account.ID).Count(&count)
+		if count == 0 {
+			linkID = nil
+		}
+	}
+
+	db.Model(&models.Contact{}).Where("id = ?", contact.ID).Updates(map[string]interface{}{
+		"referred_by_account_id": account.ID,
+		"referral_link_id":       linkID,
+		"referred_at":            clickedAt,
+	})
+	contact.ReferredByAccountID = &account.ID
+	contact.ReferralLinkID = linkID
+	contact.ReferredAt = &clickedAt
+	return true
+}
+
+// ActiveReferral returns the affiliate account a contact was referred by, if
+// the account and its program are active and the referral is still within the
+// program's cookie window.
+func ActiveReferral(db *gorm.DB, contact *models.Contact) *models.AffiliateAccount {
+	if contact.ReferredByAccountID == nil || contact.ReferredAt == nil {
+		return nil
+	}
+	var account models.AffiliateAccount
+	if err := db.Preload("Program").First(&account, *contact.ReferredByAccountID).Error; err != nil {
+		return nil
+	}
+	program := account.Program
+	if account.Status != models.AffiliateStatusActive || program == nil || program.Status != "active" {
+		return nil
+	}
+	if !referralCurrent(program, *contact.ReferredAt) {
+		return nil
+	}
+	return &account
+}
+
+// ApplyReferral copies the contact's active referral onto a new order, so the
+// order's commission is decided by the referral in effect when it was placed.
+func ApplyReferral(db *gorm.DB, order *models.Order, contact *models.Contact) {
+	if order.ReferredByAccountID != nil || contact == nil {
+		return
+	}
+	if account := ActiveReferral(db, contact); account != nil && account.ContactID != contact.ID {
+		order.ReferredByAccountID = &account.ID
+		order.ReferralLinkID = contact.ReferralLinkID
+	}
+}
+
+// referralCurrent reports whether a referral made at referredAt is still within
+// the program's cookie window. Programs without a window never expire referrals.
+func referralCurrent(program *models.AffiliateProgram, referredAt time.Time) bool {
+	return program == nil || program.CookieDays <= 0 || time.Now().Before(referredAt.AddDate(0, 0, program.CookieDays))
+}
+
+// CreateOrderCommission creates the commission for a paid order that carried
+// a referral when it was placed. It is idempotent: an order is credited at
+// most once.
+func CreateOrderCommission(db *gorm.DB, orderID uint) *models.Commission {
+	var order models.Order
+	if err := db.Preload("Items").First(&order, orderID).Error; err != nil {
+		return nil
+	}
+	if order.Status != models.OrderStatusPaid || order.AffiliateAccountID != nil {
+		return nil
+	}
+	var existing int64
+	db.Model(&models.Commission{}).Where("order_id = ?", order.ID).Count(&existing)
+	if existing > 0 {
+		return nil
+	}
+
+	// The cookie window was checked when the referral was copied onto the order.
+	if order.ReferredByAccountID == nil {
+		return nil
+	}
+	var account models.AffiliateAccount
+	if err := db.Preload("Program").First(&account, *order.ReferredByAccountID).Error; err != nil {
+		return nil
+	}
+	program := account.Program
+	if account.Status != models.AffiliateStatusActive || program == nil || program.Status != "active" {
+		return nil
+	}
+	if account.ContactID == order.ContactID {
+		return nil
+	}
+
+	amount := commissionAmount(program, order.Total)
+	if amount <= 0 {
+		return nil
+	}
+
+	// Claim the order so concurrent payment confirmations can't double-credit it.
+	claim := db.Model(&models.Order{}).
+		Where("id = ? AND affiliate_account_id IS NULL", order.ID).
+		Update("affiliate_account_id", account.ID)
+	if claim.RowsAffected == 0 {
+		return nil
+	}
+
+	commission := models.Commission{
+		TenantID:  order.TenantID,
+		AccountID: account.ID,
+		OrderID:   &order.ID,
+		Amount:    amount,
+		Status:    models.CommissionPending,
+	}
+	if len(order.Items) > 0 {
+		commission.ProductID = order.Items[0].ProductID
+	}
+	if err := db.Create(&commission).Error; err != nil {
+		log.Printf("[affiliate] Failed to create commission for order %d: %v", order.ID, err)
+		db.Model(&models.Order{}).Where("id = ?", order.ID).Update("affiliate_account_id", nil)
+		return nil
+	}
+
+	if order.ReferralLinkID != nil {
+		db.Model(&models.AffiliateLink{}).Where("id = ?", *order.ReferralLinkID).
+			UpdateColumn("conversions", gorm.Expr("conversions + 1"))
+	}
+
+	if program.AutoApprove {
+		ApproveCommission(db, &commission)
+	}
+
+	events.Emit(events.AffiliateCommission, map[string]interface{}{
+		"commission_id": commission.ID,
+		"account_id":    account.ID,
+		"contact_id":    account.ContactID,
+		"order_id":      order.ID,
+		"amount":        commission.Amount,
+		"status":        commission.Status,
+	})
+
+	log.Printf("[affiliate] Commission %d (%d cents) for account %d on order %d",
+		commission.ID, commission.Amount, account.ID, order.ID)
+	return &commission
+}
+
+// ApproveCommission approves a pending commission and credits the affiliate's
+// balance and lifetime earnings. It reports whether the commission was pending.
+func ApproveCommission(db *gorm.DB, commission *models.Commission) bool {
+	now := time.Now()
+	res := db.Model(&models.Commission{}).
+		Where("id = ? AND status = ?", commission.ID, models.CommissionPending).
+		Updates(map[string]interface{}{
+			"status":      models.CommissionApproved,
+			"approved_at": now,
+		})
+	if res.RowsAffected == 0 {
+		return false
+	}
+	commission.Status = models.CommissionApproved
+	commission.ApprovedAt = &now
+
+	db.Model(&models.AffiliateAccount{}).Where("id = ?", commission.AccountID).
+		UpdateColumns(map[string]interface{}{
+			"balance":      gorm.Expr("balance + ?", commission.Amount),
+			"total_earned": gorm.Expr("total_earned + ?", commission.Amount),
+		})
+	return true
+}
+
+// ReverseOrderCommissions claws back the commissions earned on a refunded
+// order. Commissions already paid out leave a negative balance that is
+// recovered from the affiliate's future earnings.
+func ReverseOrderCommissions(db *gorm.DB, orderID uint) {
+	var commissions []models.Commission
+	db.Preload("Account").Where("order_id = ? AND status IN ?", orderID,
+		[]string{models.CommissionPending, models.CommissionApproved, models.CommissionPaid}).
+		Find(&commissions)
+
+	for _, commission := range commissions {
+		res := db.Model(&models.Commission{}).
+			Where("id = ? AND status = ?", commission.ID, commission.Status).
+			Update("status", models.CommissionReversed)
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		if commission.Status != models.CommissionPending {
+			db.Model(&models.AffiliateAccount{}).Where("id = ?", commission.AccountID).
+				UpdateColumns(map[string]interface{}{
+					"balance":      gorm.Expr("balance - ?", commission.Amount),
+					"total_earned": gorm.Expr("total_earned - ?", commission.Amount),
+				})
+		}
+
+		var contactID uint
+		if commission.Account != nil {
+			contactID = commission.Account.ContactID
+		}
+		events.Emit(events.AffiliateCommission, map[string]interface{}{
+			"commission_id": commission.ID,
+			"account_id":    commission.AccountID,
+			"contact_id":    contactID,
+			"order_id":      orderID,
+			"amount":        commission.Amount,
+			"status":        models.CommissionReversed,
+		})
+
+		log.Printf("[affiliate] Reversed commission %d on refunded order %d", commission.ID, orderID)
+	}
+}
+
+// commissionAmount returns the commission in cents for an order total (also in cents).
+func commissionAmount(program *models.AffiliateProgram, total float64) int64 {
+	if program.CommissionType == "fixed" {
+		return program.CommissionAmount
+	}
+	return int64(math.Round(total * float64(program.CommissionAmount) / 100))
+}
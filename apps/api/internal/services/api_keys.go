@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+)
+
+// apiKeyTouchInterval limits how often a key's last-used time is written, so
+// a busy integration doesn't cause a write on every request.
+const apiKeyTouchInterval = time.Minute
+
+var ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+
+// IsAPIKey reports whether a bearer token is an API key rather than a JWT.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, models.APIKeyPrefix)
+}
+
+// GenerateAPIKey returns a new API key and the prefix shown for it. The key
+// itself is only ever returned here; store its HashToken.
+func GenerateAPIKey() (key, prefix string, err error) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", fmt.Errorf("generating API key: %w", err)
+	}
+	key = models.APIKeyPrefix + token
+	return key, key[:len(models.APIKeyPrefix)+8], nil
+}
+
+// AuthenticateAPIKey looks up an active API key and, for keys issued to a
+// user, that user, who must still be active. Tenant keys need the admin who
+// created them to still exist, since what they create is attributed to that
+// admin. It records when and from where the key was last used.
+func AuthenticateAPIKey(db *gorm.DB, key, ip string) (*models.APIKey, *models.User, error) {
+	var apiKey models.APIKey
+	if err := db.Where("key_hash = ?", HashToken(key)).First(&apiKey).Error; err != nil || !apiKey.IsActive() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	var user *models.User
+	if apiKey.UserID != nil {
+		user = &models.User{}
+		if err := db.First(user, *apiKey.UserID).Error; err != nil {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		if !user.Active {
+			return nil, nil, ErrAccountDisabled
+		}
+	} else {
+		var count int64
+		db.Model(&models.User{}).Where("id = ?", apiKey.CreatedByID).Count(&count)
+		if count == 0 {
+			return nil, nil, ErrInvalidAPIKey
+		}
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval || apiKey.LastUsedIP != ip {
+		db.Model(&apiKey).Updates(map[string]interface{}{"last_used_at": now, "last_used_ip": ip})
+	}
+	return &apiKey, user, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"time"
@@ -29,33 +30,14 @@ type Claims struct {
 	TenantID uint   `json:"tenant_id"`
 	Email    string `json:"email"`
 	Role     string `json:"role"`
+	// SessionID is the UserSession the token was issued to.
+	SessionID uint `json:"sid"`
+	// TokenVersion must match the user's current token version; bumping the
+	// user's version revokes every token issued before.
+	TokenVersion int `json:"tv"`
 	jwt.RegisteredClaims
 }
 
-// GenerateTokenPair creates a new access + refresh token pair.
-func (s *AuthService) GenerateTokenPair(userID uint, email, role string) (*TokenPair, error) {
-	return s.GenerateTokenPairWithTenant(userID, 1, email, role)
-}
-
-// GenerateTokenPairWithTenant creates a new token pair including the tenant ID.
-func (s *AuthService) GenerateTokenPairWithTenant(userID, tenantID uint, email, role string) (*TokenPair, error) {
-	accessToken, expiresAt, err := s.generateToken(userID, tenantID, email, role, s.AccessExpiry)
-	if err != nil {
-		return nil, fmt.Errorf("generating access token: %w", err)
-	}
-
-	refreshToken, _, err := s.generateToken(userID, tenantID, email, role, s.RefreshExpiry)
-	if err != nil {
-		return nil, fmt.Errorf("generating refresh token: %w", err)
-	}
-
-	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
-	}, nil
-}
-
 // ValidateToken parses and validates a JWT token.
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -77,16 +59,24 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// GenerateResetToken creates a random hex token for password resets.
-func GenerateResetToken() (string, error) {
+// GenerateOpaqueToken creates a random hex token, used for password reset
+// links and refresh tokens.
+func GenerateOpaqueToken() (string, error) {
 	bytes := make([]byte, 32)
 	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("generating reset token: %w", err)
+		return "", fmt.Errorf("generating token: %w", err)
 	}
 	return hex.EncodeToString(bytes), nil
 }
 
-func (s *AuthService) generateToken(userID, tenantID uint, email, role string, expiry time.Duration) (string, int64, error) {
+// HashToken returns the hex SHA-256 of a token, the form single-use tokens
+// are stored and looked up in.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *AuthService) generateToken(userID, tenantID uint, email, role string, sessionID uint, tokenVersion int, expiry time.Duration) (string, int64, error) {
 	expiresAt := time.Now().Add(expiry)
 
 	claims := &Claims{
@@ -94,6 +84,9 @@ func (s *AuthService) generateToken(userID, tenantID uint, email, role string, e
 		TenantID: tenantID,
 		Email:    email,
 		Role:     role,
+
+		SessionID:    sessionID,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
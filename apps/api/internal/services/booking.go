@@ -0,0 +1,147 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/refund"
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/integrations"
+	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/models"
+)
+
+// RegisterBookingPaymentListeners confirms paid bookings once their order is
+// paid. A payment that lands after the hold was released is refunded.
+func RegisterBookingPaymentListeners(db *gorm.DB, meetings *integrations.MeetingService, jobClient *jobs.Client) {
+	bus := events.Default()
+
+	bus.On(events.PurchaseCompleted, func(data interface{}) {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if orderID := toUint(m["order_id"]); orderID != 0 {
+			confirmPaidAppointment(db, meetings, jobClient, orderID)
+		}
+	})
+
+	log.Println("[booking] Registered payment listeners")
+}
+
+// ConfirmAppointment does everything that follows a confirmed booking: it
+// creates the external meeting, queues the confirmation email and reminders,
+// and emits BookingConfirmed.
+func ConfirmAppointment(db *gorm.DB, meetings *integrations.MeetingService, jobClient *jobs.Client,
+	appt *models.Appointment, et models.BookingEventType, contact models.Contact) {
+	// Create external meetings (non-blocking — never fail the booking)
+	if meetings != nil {
+		meetingURL, err := meetings.CreateMeetingForAppointment(appt, et, contact)
+		if err != nil {
+			log.Printf("[booking] Failed to create meeting: %v", err)
+		} else if meetingURL != "" {
+			db.Model(appt).Updates(map[string]interface{}{
+				"meeting_url":     meetingURL,
+				"google_event_id": appt.GoogleEventID,
+				"zoom_meeting_id": appt.ZoomMeetingID,
+			})
+			appt.MeetingURL = meetingURL
+		}
+	}
+
+	if jobClient != nil {
+		if err := jobs.ScheduleAppointmentReminders(db, jobClient, appt, &et); err != nil {
+			log.Printf("[booking] Failed to schedule reminders for appointment %d: %v", appt.ID, err)
+		}
+		if err := jobClient.EnqueueBookingEmail(appt.ID, jobs.BookingEmailConfirmed); err != nil {
+			log.Printf("[booking] Failed to queue confirmation for appointment %d: %v", appt.ID, err)
+		}
+	}
+
+	events.Emit(events.BookingConfirmed, map[string]interface{}{
+		"appointment_id": appt.ID, "contact_id": contact.ID, "event_type": et.Name,
+	})
+}
+
+// RefundBookingOrder refunds a paid booking order in full through Stripe and
+// marks it refunded.
+func RefundBookingOrder(db *gorm.DB, order *models.Order) error {
+	if order.Status != models.OrderStatusPaid {
+		return fmt.Errorf("order %d is not paid", order.ID)
+	}
+	if order.PaymentProvider == "stripe" && order.PaymentID != "" {
+		params := &stripe.RefundParams{PaymentIntent: stripe.String(order.PaymentID)}
+		params.SetIdempotencyKey(fmt.Sprintf("refund-order-%d", order.ID))
+		if _, err := refund.New(params); err != nil {
+			return fmt.Errorf("refunding payment %s: %w", order.PaymentID, err)
+		}
+	}
+
+	res := db.Model(&models.Order{}).
+		Where("id = ? AND status = ?", order.ID, models.OrderStatusPaid).
+		Update("status", models.OrderStatusRefunded)
+	if res.RowsAffected == 0 {
+		return nil
+	}
+	order.Status = models.OrderStatusRefunded
+
+	events.Emit(events.PurchaseRefunded, map[string]interface{}{
+		"order_id":   order.ID,
+		"contact_id": order.ContactID,
+		"total":      order.Total,
+	})
+	return nil
+}
+
+func confirmPaidAppointment(db *gorm.DB, meetings *integrations.MeetingService, jobClient *jobs.Client, orderID uint) {
+	var appt models.Appointment
+	if err := db.Where("order_id = ?", orderID).First(&appt).Error; err != nil {
+		return
+	}
+
+	// Claim the appointment so a webhook racing the client-side
+	// confirmation doesn't confirm it twice.
+	res := db.Model(&models.Appointment{}).
+		Where("id = ? AND status = ?", appt.ID, models.AppointmentPending).
+		Updates(map[string]interface{}{"status": models.AppointmentConfirmed, "hold_expires_at": nil})
+	if res.Error != nil {
+		return
+	}
+	if res.RowsAffected == 0 {
+		db.Select("status").First(&appt, appt.ID)
+		if appt.Status == models.AppointmentCancelled {
+			refundReleasedHold(db, &appt, orderID)
+		}
+		return
+	}
+	appt.Status = models.AppointmentConfirmed
+	appt.HoldExpiresAt = nil
+
+	var et models.BookingEventType
+	var contact models.Contact
+	if err := db.First(&et, appt.EventTypeID).Error; err != nil {
+		return
+	}
+	if err := db.First(&contact, appt.ContactID).Error; err != nil {
+		return
+	}
+	ConfirmAppointment(db, meetings, jobClient, &appt, et, contact)
+	log.Printf("[booking] Appointment %d confirmed by payment of order %d", appt.ID, orderID)
+}
+
+// refundReleasedHold refunds an order paid after its appointment's hold ran
+// out and the slot was released.
+func refundReleasedHold(db *gorm.DB, appt *models.Appointment, orderID uint) {
+	var order models.Order
+	if err := db.First(&order, orderID).Error; err != nil || order.Status != models.OrderStatusPaid {
+		return
+	}
+	if err := RefundBookingOrder(db, &order); err != nil {
+		log.Printf("[booking] Failed to refund order %d for released appointment %d: %v", orderID, appt.ID, err)
+		return
+	}
+	log.Printf("[booking] Refunded order %d: appointment %d was released before payment", orderID, appt.ID)
+}
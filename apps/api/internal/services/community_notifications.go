@@ -0,0 +1,360 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/models"
+)
+
+// mentionPattern matches the mention tokens the editors insert, @[Name](contactID).
+var mentionPattern = regexp.MustCompile(`@\[([^\]]*)\]\((\d+)\)`)
+
+// maxMentionsPerPost caps how many people one thread or reply can notify by
+// mentioning them; mentions past the cap are ignored.
+const maxMentionsPerPost = 10
+
+// RegisterCommunityNotificationListeners notifies followers about new threads
+// and replies, space members about announcements, and anyone @mentioned. The
+// author follows the thread they post in unless they've turned that off.
+func RegisterCommunityNotificationListeners(db *gorm.DB, jobClient *jobs.Client) {
+	bus := events.Default()
+	n := &communityNotifier{db: db, jobs: jobClient}
+
+	bus.On(events.CommunityThreadCreated, func(data interface{}) {
+		thread, ok := data.(models.Thread)
+		if !ok {
+			return
+		}
+		n.threadCreated(&thread)
+	})
+
+	bus.On(events.CommunityReplyCreated, func(data interface{}) {
+		reply, ok := data.(models.Reply)
+		if !ok {
+			return
+		}
+		n.replyCreated(&reply)
+	})
+
+	log.Println("[community] Registered notification listeners")
+}
+
+// FollowCommunity follows a space or thread for a contact. Automatic follows
+// don't override an earlier unfollow.
+func FollowCommunity(db *gorm.DB, contactID uint, followableType string, followableID uint, automatic bool) {
+	follow := models.CommunityFollow{
+		TenantID:       1,
+		ContactID:      contactID,
+		FollowableType: followableType,
+		FollowableID:   followableID,
+	}
+	if automatic {
+		db.Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+		return
+	}
+	db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contact_id"}, {Name: "followable_type"}, {Name: "followable_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"muted": false, "updated_at": time.Now()}),
+	}).Create(&follow)
+}
+
+// UnfollowCommunity stops notifications about a space or thread. The follow is
+// kept, muted, so posting in the thread doesn't follow it again.
+func UnfollowCommunity(db *gorm.DB, contactID uint, followableType string, followableID uint) {
+	follow := models.CommunityFollow{
+		TenantID:       1,
+		ContactID:      contactID,
+		FollowableType: followableType,
+		FollowableID:   followableID,
+		Muted:          true,
+	}
+	db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contact_id"}, {Name: "followable_type"}, {Name: "followable_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"muted": true, "updated_at": time.Now()}),
+	}).Create(&follow)
+}
+
+// IsFollowing reports whether the contact follows the space or thread.
+func IsFollowing(db *gorm.DB, contactID uint, followableType string, followableID uint) bool {
+	var count int64
+	db.Model(&models.CommunityFollow{}).
+		Where("contact_id = ? AND followable_type = ? AND followable_id = ? AND muted = ?", contactID, followableType, followableID, false).
+		Count(&count)
+	return count > 0
+}
+
+type communityNotifier struct {
+	db   *gorm.DB
+	jobs *jobs.Client
+}
+
+func (n *communityNotifier) threadCreated(thread *models.Thread) {
+	var space models.Space
+	if err := n.db.First(&space, thread.SpaceID).Error; err != nil {
+		return
+	}
+	n.autoFollow(thread.AuthorID, thread.ID)
+
+	recipients := map[uint]string{}
+	if thread.Type == models.ThreadTypeAnnouncement {
+		var memberIDs []uint
+		n.db.Model(&models.CommunityMember{}).Where("space_id = ? AND banned_at IS NULL", space.ID).
+			Pluck("contact_id", &memberIDs)
+		for _, id := range memberIDs {
+			recipients[id] = models.NotifyAnnouncement
+		}
+	} else {
+		for _, id := range n.followers(models.FollowableSpace, space.ID) {
+			recipients[id] = models.NotifyNewThread
+		}
+	}
+	for _, id := range n.mentionedMembers(space.ID, thread.Content) {
+		recipients[id] = models.NotifyMention
+	}
+	delete(recipients, thread.AuthorID)
+
+	author := n.contactName(thread.AuthorID)
+	titles := map[string]string{
+		models.NotifyAnnouncement: fmt.Sprintf("New announcement in %s: %s", space.Name, thread.Title),
+		models.NotifyNewThread:    fmt.Sprintf("%s started \"%s\" in %s", author, thread.Title, space.Name),
+		models.NotifyMention:      fmt.Sprintf("%s mentioned you in \"%s\"", author, thread.Title),
+	}
+	n.notify(&space, thread, nil, thread.AuthorID, recipients, titles, contentExcerpt(thread.Content, 280))
+}
+
+func (n *communityNotifier) replyCreated(reply *models.Reply) {
+	var thread models.Thread
+	if err := n.db.First(&thread, reply.ThreadID).Error; err != nil {
+		return
+	}
+	var space models.Space
+	if err := n.db.First(&space, thread.SpaceID).Error; err != nil {
+		return
+	}
+	n.autoFollow(reply.AuthorID, thread.ID)
+
+	recipients := map[uint]string{}
+	for _, id := range n.followers(models.FollowableThread, thread.ID) {
+		recipients[id] = models.NotifyReply
+	}
+	for _, id := range n.mentionedMembers(space.ID, reply.Content) {
+		recipients[id] = models.NotifyMention
+	}
+	delete(recipients, reply.AuthorID)
+
+	author := n.contactName(reply.AuthorID)
+	titles := map[string]string{
+		models.NotifyReply:   fmt.Sprintf("%s replied to \"%s\"", author, thread.Title),
+		models.NotifyMention: fmt.Sprintf("%s mentioned you in \"%s\"", author, thread.Title),
+	}
+	n.notify(&space, &thread, reply, reply.AuthorID, recipients, titles, contentExcerpt(reply.Content, 280))
+}
+
+// autoFollow follows the thread for its author or a replier, if they want that.
+func (n *communityNotifier) autoFollow(contactID, threadID uint) {
+	pref := models.CommunityPreferencesFor(n.db, contactID)
+	if pref.AutoFollow {
+		FollowCommunity(n.db, contactID, models.FollowableThread, threadID, true)
+	}
+}
+
+func (n *communityNotifier) followers(followableType string, followableID uint) []uint {
+	var ids []uint
+	n.db.Model(&models.CommunityFollow{}).
+		Where("followable_type = ? AND followable_id = ? AND muted = ?", followableType, followableID, false).
+		Pluck("contact_id", &ids)
+	return ids
+}
+
+func (n *communityNotifier) contactName(contactID uint) string {
+	var contact models.Contact
+	if err := n.db.Select("id, first_name, last_name, email").First(&contact, contactID).Error; err != nil {
+		return "Someone"
+	}
+	if name := strings.TrimSpace(contact.FirstName + " " + contact.LastName); name != "" {
+		return name
+	}
+	return "Someone"
+}
+
+// canRead reports whether a contact may still read the space: banned members
+// never can, and private and paid spaces need a current membership.
+func (n *communityNotifier) canRead(space *models.Space, members map[uint]*models.CommunityMember, contactID uint) bool {
+	member := members[contactID]
+	if member != nil && member.BannedAt != nil {
+		return false
+	}
+	switch space.Type {
+	case models.SpaceTypePrivate:
+		return member != nil
+	case models.SpaceTypePaid:
+		if member == nil {
+			return false
+		}
+		if member.Role != models.MemberRoleMember || space.ProductID == nil {
+			return true
+		}
+		return models.OwnsProduct(n.db, contactID, *space.ProductID)
+	}
+	return true
+}
+
+// notify stores a notification for each recipient who can read the space and
+// emails the ones whose preferences ask for it straight away.
+func (n *communityNotifier) notify(space *models.Space, thread *models.Thread, reply *models.Reply, actorID uint,
+	recipients map[uint]string, titles map[string]string, body string) {
+	if len(recipients) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(recipients))
+	for id := range recipients {
+		ids = append(ids, id)
+	}
+	var members []models.CommunityMember
+	n.db.Where("space_id = ? AND contact_id IN ?", space.ID, ids).Find(&members)
+	byContact := map[uint]*models.CommunityMember{}
+	for i := range members {
+		byContact[members[i].ContactID] = &members[i]
+	}
+	var contacts []models.Contact
+	n.db.Where("id IN ?", ids).Find(&contacts)
+
+	siteName := models.GetSetting(n.db, "site_name", "GritCMS")
+	siteURL := strings.TrimRight(models.GetSetting(n.db, "site_url", ""), "/")
+	url := siteURL + "/community/" + space.Slug + "?thread=" + strconv.FormatUint(uint64(thread.ID), 10)
+
+	var replyID *uint
+	if reply != nil {
+		replyID = &reply.ID
+	}
+
+	created := 0
+	for _, contact := range contacts {
+		if !n.canRead(space, byContact, contact.ID) {
+			continue
+		}
+		notificationType := recipients[contact.ID]
+		notification := models.CommunityNotification{
+			TenantID:  1,
+			ContactID: contact.ID,
+			Type:      notificationType,
+			ActorID:   &actorID,
+			SpaceID:   space.ID,
+			ThreadID:  thread.ID,
+			ReplyID:   replyID,
+			Title:     titles[notificationType],
+			Body:      body,
+			URL:       url,
+		}
+		if err := n.db.Create(&notification).Error; err != nil {
+			log.Printf("[community] Failed to notify contact %d: %v", contact.ID, err)
+			continue
+		}
+		created++
+
+		pref := models.CommunityPreferencesFor(n.db, contact.ID)
+		if n.jobs == nil || contact.Email == "" || !pref.EmailsInstantly(notificationType) {
+			continue
+		}
+		err := n.jobs.EnqueueSendEmail(contact.Email, notification.Title, "notification", map[string]interface{}{
+			"AppName":    siteName,
+			"Year":       time.Now().Year(),
+			"Title":      notification.Title,
+			"Message":    body,
+			"ActionURL":  url,
+			"ActionText": "View discussion",
+		})
+		if err != nil {
+			log.Printf("[community] Failed to email notification %d: %v", notification.ID, err)
+			continue
+		}
+		n.db.Model(&notification).Update("emailed_at", time.Now())
+	}
+
+	if created > 0 {
+		log.Printf("[community] Sent %d notifications for thread %d", created, thread.ID)
+	}
+}
+
+// mentionedMembers returns the contacts @mentioned in post content who are
+// members of the space and not banned from it. Anyone else mentioned, such
+// as an arbitrary contact ID typed into the token, isn't notified.
+func (n *communityNotifier) mentionedMembers(spaceID uint, content datatypes.JSON) []uint {
+	ids := mentionedContacts(content)
+	if len(ids) == 0 {
+		return nil
+	}
+	var memberIDs []uint
+	n.db.Model(&models.CommunityMember{}).
+		Where("space_id = ? AND contact_id IN ? AND banned_at IS NULL", spaceID, ids).
+		Pluck("contact_id", &memberIDs)
+	return memberIDs
+}
+
+// mentionedContacts returns the first maxMentionsPerPost distinct contact IDs
+// @mentioned in post content.
+func mentionedContacts(content datatypes.JSON) []uint {
+	seen := map[uint]bool{}
+	var ids []uint
+	for _, m := range mentionPattern.FindAllStringSubmatch(string(content), -1) {
+		id, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil || id == 0 || seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		ids = append(ids, uint(id))
+		if len(ids) == maxMentionsPerPost {
+			break
+		}
+	}
+	return ids
+}
+
+// contentExcerpt returns up to max characters of a post's text. Content is
+// either a plain string or a rich-text document with "text" leaves.
+func contentExcerpt(content datatypes.JSON, max int) string {
+	var doc interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return ""
+	}
+	var parts []string
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch node := v.(type) {
+		case string:
+			parts = append(parts, node)
+		case []interface{}:
+			for _, child := range node {
+				walk(child)
+			}
+		case map[string]interface{}:
+			if text, ok := node["text"].(string); ok {
+				parts = append(parts, text)
+			}
+			if children, ok := node["content"]; ok {
+				walk(children)
+			}
+		}
+	}
+	walk(doc)
+
+	text := strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+	text = mentionPattern.ReplaceAllString(text, "@$1")
+	if runes := []rune(text); len(runes) > max {
+		return string(runes[:max]) + "…"
+	}
+	return text
+}
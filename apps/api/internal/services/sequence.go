@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/models"
+)
+
+// SequenceTriggers enrolls contacts into active event-triggered email sequences
+// when their TriggerEvent fires, and exits them when their ExitEvent fires.
+// Like WorkflowTriggers, it registers one dispatcher per event name and Reload
+// swaps the index those dispatchers read from.
+type SequenceTriggers struct {
+	DB *gorm.DB
+
+	mu        sync.RWMutex
+	enroll    map[string][]uint
+	exit      map[string][]uint
+	listening map[string]bool
+}
+
+// NewSequenceTriggers creates a sequence trigger registry. Call Reload to subscribe.
+func NewSequenceTriggers(db *gorm.DB) *SequenceTriggers {
+	return &SequenceTriggers{
+		DB:        db,
+		enroll:    make(map[string][]uint),
+		exit:      make(map[string][]uint),
+		listening: make(map[string]bool),
+	}
+}
+
+// Reload rebuilds the subscriptions from the active sequences in the database.
+// Call it whenever a sequence is created, edited, paused or deleted.
+func (t *SequenceTriggers) Reload() {
+	var sequences []models.EmailSequence
+	if err := t.DB.Where("status = ?", models.SequenceStatusActive).Find(&sequences).Error; err != nil {
+		log.Printf("[sequences] Failed to load triggers: %v", err)
+		return
+	}
+
+	enroll := make(map[string][]uint)
+	exit := make(map[string][]uint)
+	for _, seq := range sequences {
+		if seq.Trigger == models.SequenceTriggerEvent && seq.TriggerEvent != "" {
+			enroll[seq.TriggerEvent] = append(enroll[seq.TriggerEvent], seq.ID)
+		}
+		if seq.ExitEvent != "" {
+			exit[seq.ExitEvent] = append(exit[seq.ExitEvent], seq.ID)
+		}
+	}
+
+	t.mu.Lock()
+	t.enroll = enroll
+	t.exit = exit
+	var subscribe []string
+	for _, index := range []map[string][]uint{enroll, exit} {
+		for event := range index {
+			if !t.listening[event] {
+				t.listening[event] = true
+				subscribe = append(subscribe, event)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for _, event := range subscribe {
+		event := event
+		events.On(event, func(data interface{}) {
+			t.dispatch(event, data)
+		})
+	}
+}
+
+func (t *SequenceTriggers) dispatch(event string, data interface{}) {
+	t.mu.RLock()
+	enrollIDs := t.enroll[event]
+	exitIDs := t.exit[event]
+	t.mu.RUnlock()
+	if len(enrollIDs) == 0 && len(exitIDs) == 0 {
+		return
+	}
+
+	contactID := eventContactID(t.DB, data)
+	if contactID == 0 {
+		return
+	}
+
+	// Exits run first so an event can't enroll a contact into a sequence it ends.
+	if len(exitIDs) > 0 {
+		now := time.Now()
+		res := t.DB.Model(&models.EmailSequenceEnrollment{}).
+			Where("sequence_id IN ? AND contact_id = ? AND status = ?", exitIDs, contactID, models.EnrollmentStatusActive).
+			Updates(map[string]interface{}{
+				"status":       models.EnrollmentStatusExited,
+				"completed_at": now,
+				"next_send_at": nil,
+			})
+		if res.RowsAffected > 0 {
+			log.Printf("[sequences] Contact %d exited %d sequence(s) on %q", contactID, res.RowsAffected, event)
+		}
+	}
+
+	for _, id := range enrollIDs {
+		var seq models.EmailSequence
+		if err := t.DB.First(&seq, id).Error; err != nil || seq.Status != models.SequenceStatusActive {
+			continue
+		}
+		if seq.ExitEvent == event {
+			continue
+		}
+		if _, err := EnrollInSequence(t.DB, seq, contactID); err != nil {
+			log.Printf("[sequences] Failed to enroll contact %d in sequence %d: %v", contactID, seq.ID, err)
+		}
+	}
+}
+
+// EnrollInSequence starts a contact on the first step of a sequence. A contact
+// already active in the sequence is left alone and (nil, nil) is returned; a
+// finished, cancelled or exited enrollment is restarted.
+func EnrollInSequence(db *gorm.DB, seq models.EmailSequence, contactID uint) (*models.EmailSequenceEnrollment, error) {
+	var firstStep models.EmailSequenceStep
+	if err := db.Where("sequence_id = ?", seq.ID).Order("sort_order ASC, id ASC").First(&firstStep).Error; err != nil {
+		return nil, fmt.Errorf("sequence has no steps")
+	}
+
+	now := time.Now()
+	nextSend := now.Add(time.Duration(firstStep.DelayDays)*24*time.Hour + time.Duration(firstStep.DelayHours)*time.Hour)
+
+	var enrollment models.EmailSequenceEnrollment
+	err := db.Where("sequence_id = ? AND contact_id = ?", seq.ID, contactID).First(&enrollment).Error
+	if err == nil {
+		if enrollment.Status == models.EnrollmentStatusActive {
+			return nil, nil
+		}
+		res := db.Model(&enrollment).
+			Where("status <> ?", models.EnrollmentStatusActive).
+			Updates(map[string]interface{}{
+				"status":          models.EnrollmentStatusActive,
+				"current_step_id": firstStep.ID,
+				"enrolled_at":     now,
+				"completed_at":    nil,
+				"next_send_at":    nextSend,
+			})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil, nil
+		}
+		enrollment.Status = models.EnrollmentStatusActive
+		enrollment.CurrentStepID = &firstStep.ID
+		enrollment.EnrolledAt = now
+		enrollment.CompletedAt = nil
+		enrollment.NextSendAt = &nextSend
+	} else {
+		enrollment = models.EmailSequenceEnrollment{
+			TenantID:      seq.TenantID,
+			SequenceID:    seq.ID,
+			ContactID:     contactID,
+			CurrentStepID: &firstStep.ID,
+			Status:        models.EnrollmentStatusActive,
+			EnrolledAt:    now,
+			NextSendAt:    &nextSend,
+		}
+		// Two events racing to enroll the same contact hit the unique index; the
+		// loser finds nothing to do.
+		if err := db.Create(&enrollment).Error; err != nil {
+			var existing models.EmailSequenceEnrollment
+			if db.Where("sequence_id = ? AND contact_id = ?", seq.ID, contactID).First(&existing).Error == nil {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	events.Emit(events.EmailSequenceEnrolled, enrollment)
+	return &enrollment, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+	ErrAccountDisabled     = errors.New("account is disabled")
+)
+
+// SessionMeta describes the device a request came from.
+type SessionMeta struct {
+	IPAddress string
+	UserAgent string
+}
+
+// StartSession signs a user in on a new device and returns its first token pair.
+func (s *AuthService) StartSession(db *gorm.DB, user *models.User, meta SessionMeta) (*TokenPair, error) {
+	now := time.Now()
+	session := models.UserSession{
+		TenantID:   user.TenantID,
+		UserID:     user.ID,
+		Device:     DescribeUserAgent(meta.UserAgent),
+		IPAddress:  meta.IPAddress,
+		UserAgent:  meta.UserAgent,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.RefreshExpiry),
+	}
+
+	var refreshToken string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		var err error
+		refreshToken, err = issueRefreshToken(tx, session.ID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting session: %w", err)
+	}
+
+	return s.tokenPair(user, session.ID, refreshToken)
+}
+
+// RefreshSession exchanges a refresh token for a new token pair. The token is
+// rotated: it can't be used again, and if it is, the whole session is revoked
+// because the token must have leaked.
+func (s *AuthService) RefreshSession(db *gorm.DB, refreshToken string, meta SessionMeta) (*TokenPair, *models.User, error) {
+	var token models.RefreshToken
+	if err := db.Where("token_hash = ?", HashToken(refreshToken)).First(&token).Error; err != nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if token.RotatedAt != nil {
+		_ = RevokeSession(db, token.SessionID, models.SessionRevokedReuse)
+		return nil, nil, ErrRefreshTokenReused
+	}
+
+	var session models.UserSession
+	if err := db.First(&session, token.SessionID).Error; err != nil || !session.IsActive() {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	var user models.User
+	if err := db.First(&user, session.UserID).Error; err != nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if !user.Active {
+		_ = RevokeSession(db, session.ID, models.SessionRevokedDeactivated)
+		return nil, nil, ErrAccountDisabled
+	}
+
+	now := time.Now()
+	var newToken string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Of two requests racing with the same token only one claims it.
+		claim := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND rotated_at IS NULL", token.ID).
+			Update("rotated_at", now)
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		updates := map[string]interface{}{
+			"last_used_at": now,
+			"expires_at":   now.Add(s.RefreshExpiry),
+		}
+		if meta.IPAddress != "" {
+			updates["ip_address"] = meta.IPAddress
+		}
+		if err := tx.Model(&session).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		var err error
+		newToken, err = issueRefreshToken(tx, session.ID)
+		return err
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		_ = RevokeSession(db, session.ID, models.SessionRevokedReuse)
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("rotating refresh token: %w", err)
+	}
+
+	pair, err := s.tokenPair(&user, session.ID, newToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, &user, nil
+}
+
+// RevokeSession signs one session out.
+func RevokeSession(db *gorm.DB, sessionID uint, reason string) error {
+	return db.Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+}
+
+// RevokeUserSessions signs a user out everywhere and returns how many
+// sessions were revoked.
+func RevokeUserSessions(db *gorm.DB, userID uint, reason string) (int64, error) {
+	result := db.Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+func issueRefreshToken(tx *gorm.DB, sessionID uint) (string, error) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Create(&models.RefreshToken{SessionID: sessionID, TokenHash: HashToken(token)}).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *AuthService) tokenPair(user *models.User, sessionID uint, refreshToken string) (*TokenPair, error) {
+	accessToken, expiresAt, err := s.generateToken(user.ID, user.TenantID, user.Email, user.Role, sessionID, user.TokenVersion, s.AccessExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("generating access token: %w", err)
+	}
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// DescribeUserAgent turns a User-Agent header into a short label such as
+// "Chrome on macOS".
+func DescribeUserAgent(ua string) string {
+	browser := ""
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/") || strings.Contains(ua, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	case strings.HasPrefix(ua, "curl/"):
+		browser = "curl"
+	}
+
+	os := ""
+	switch {
+	case strings.Contains(ua, "iPhone"):
+		os = "iOS"
+	case strings.Contains(ua, "iPad"):
+		os = "iPadOS"
+	case strings.Contains(ua, "Android"):
+		os = "Android"
+	case strings.Contains(ua, "Windows"):
+		os = "Windows"
+	case strings.Contains(ua, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(ua, "CrOS"):
+		os = "ChromeOS"
+	case strings.Contains(ua, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+	return "Unknown device"
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/models"
+)
+
+const (
+	// TwoFactorChallengeTTL is how long a user has to enter their code after
+	// their password was accepted.
+	TwoFactorChallengeTTL = 5 * time.Minute
+
+	twoFactorMaxAttempts = 5
+	twoFactorLockout     = 15 * time.Minute
+	recoveryCodeCount    = 10
+)
+
+var (
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrTwoFactorLocked           = errors.New("too many failed two-factor attempts")
+)
+
+// TwoFactorChallenge returns the token a user trades, together with a code,
+// for a token pair once their password has been accepted. It stops working if
+// the password changes or the user is signed out everywhere.
+func (s *AuthService) TwoFactorChallenge(user *models.User) (string, time.Time) {
+	token := s.signUserToken("2fa-challenge", user.ID, twoFactorChallengeBinding(user), TwoFactorChallengeTTL)
+	return token, time.Now().Add(TwoFactorChallengeTTL)
+}
+
+// ParseTwoFactorChallenge returns the user a challenge token was issued to.
+func (s *AuthService) ParseTwoFactorChallenge(db *gorm.DB, token string) (*models.User, error) {
+	user, err := s.parseUserToken(db, "2fa-challenge", token, twoFactorChallengeBinding)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	return user, nil
+}
+
+func twoFactorChallengeBinding(u *models.User) string {
+	return u.Password + "\n" + strconv.Itoa(u.TokenVersion)
+}
+
+// TwoFactorEnabled reports whether a user must present a second factor.
+func TwoFactorEnabled(db *gorm.DB, userID uint) bool {
+	var count int64
+	db.Model(&models.UserTwoFactor{}).Where("user_id = ? AND enabled_at IS NOT NULL", userID).Count(&count)
+	return count > 0
+}
+
+// VerifySecondFactor checks an authenticator code or an unused recovery code
+// for a user with 2FA enabled. Each TOTP code and recovery code is accepted
+// once, and repeated failures lock the user out for a while.
+func VerifySecondFactor(db *gorm.DB, userID uint, code string) error {
+	var tf models.UserTwoFactor
+	if err := db.Where("user_id = ?", userID).First(&tf).Error; err != nil || !tf.Enabled() {
+		return ErrInvalidTwoFactorCode
+	}
+
+	now := time.Now()
+	if tf.LockedUntil != nil && now.Before(*tf.LockedUntil) {
+		return ErrTwoFactorLocked
+	}
+
+	code = normalizeCode(code)
+	accepted := false
+	if step, ok := ValidateTOTP(tf.Secret, code, now); ok {
+		// Claim the step so the same code can't be replayed.
+		res := db.Model(&models.UserTwoFactor{}).
+			Where("id = ? AND last_used_step < ?", tf.ID, step).
+			Update("last_used_step", step)
+		accepted = res.Error == nil && res.RowsAffected == 1
+	} else if len(code) != totpDigits {
+		res := db.Model(&models.TwoFactorRecoveryCode{}).
+			Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, HashToken(code)).
+			Update("used_at", now)
+		accepted = res.Error == nil && res.RowsAffected == 1
+	}
+
+	if accepted {
+		if tf.FailedAttempts > 0 {
+			db.Model(&tf).Updates(map[string]interface{}{"failed_attempts": 0, "locked_until": nil})
+		}
+		return nil
+	}
+
+	updates := map[string]interface{}{"failed_attempts": gorm.Expr("failed_attempts + 1")}
+	if tf.FailedAttempts+1 >= twoFactorMaxAttempts {
+		updates["failed_attempts"] = 0
+		updates["locked_until"] = now.Add(twoFactorLockout)
+	}
+	db.Model(&tf).Updates(updates)
+	return ErrInvalidTwoFactorCode
+}
+
+// GenerateRecoveryCodes replaces a user's recovery codes and returns the new
+// ones. They are shown once; only their hashes are kept.
+func GenerateRecoveryCodes(db *gorm.DB, userID uint) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	rows := make([]models.TwoFactorRecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(b)) // 8 characters
+		codes[i] = raw[:4] + "-" + raw[4:]
+		rows[i] = models.TwoFactorRecoveryCode{UserID: userID, CodeHash: HashToken(raw)}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storing recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// normalizeCode strips the spacing and dashes people type into codes.
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/models"
+)
+
+// WebhookEnvelope is the JSON body of every outbound webhook.
+type WebhookEnvelope struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// RegisterWebhookListeners forwards every event on the bus to the active
+// webhook endpoints subscribed to it.
+func RegisterWebhookListeners(db *gorm.DB, jobClient *jobs.Client) {
+	if jobClient == nil {
+		log.Println("[webhooks] Job queue not configured, outbound webhooks disabled")
+		return
+	}
+
+	bus := events.Default()
+	for _, event := range events.All {
+		event := event
+		bus.On(event, func(data interface{}) {
+			DispatchWebhooks(db, jobClient, event, data)
+		})
+	}
+
+	log.Println("[webhooks] Registered event listeners")
+}
+
+// DispatchWebhooks queues a delivery of an event to each subscribed endpoint.
+func DispatchWebhooks(db *gorm.DB, jobClient *jobs.Client, event string, data interface{}) {
+	var endpoints []models.WebhookEndpoint
+	if err := db.Where("active = ?", true).Find(&endpoints).Error; err != nil {
+		log.Printf("[webhooks] Failed to load endpoints: %v", err)
+		return
+	}
+	var subscribed []models.WebhookEndpoint
+	for _, ep := range endpoints {
+		if ep.Subscribes(event) {
+			subscribed = append(subscribed, ep)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("[webhooks] Failed to encode %q payload: %v", event, err)
+		return
+	}
+	envelope := WebhookEnvelope{
+		ID:        newWebhookEventID(),
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      raw,
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("[webhooks] Failed to encode %q envelope: %v", event, err)
+		return
+	}
+
+	for _, ep := range subscribed {
+		if _, err := jobs.QueueWebhookDelivery(db, jobClient, ep.ID, envelope.ID, event, body); err != nil {
+			log.Printf("[webhooks] Failed to queue %q for endpoint %d: %v", event, ep.ID, err)
+		}
+	}
+}
+
+func newWebhookEventID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"gritcms/apps/api/internal/cron"
+	"gritcms/apps/api/internal/events"
+	"gritcms/apps/api/internal/jobs"
+	"gritcms/apps/api/internal/models"
+)
+
+// WorkflowTriggers starts executions of active event-triggered workflows when
+// their configured event fires on the event bus, and publishes active
+// schedule-triggered workflows to the cron task list.
+//
+// The bus has no way to remove a handler, so a single dispatcher is registered
+// per event name the first time any workflow needs it; Reload only swaps the
+// event → workflow index the dispatchers read from.
+type WorkflowTriggers struct {
+	DB   *gorm.DB
+	Jobs *jobs.Client
+
+	mu        sync.RWMutex
+	byEvent   map[string][]uint
+	listening map[string]bool
+}
+
+// NewWorkflowTriggers creates a trigger registry. Call Reload to subscribe.
+func NewWorkflowTriggers(db *gorm.DB, jobClient *jobs.Client) *WorkflowTriggers {
+	return &WorkflowTriggers{
+		DB:        db,
+		Jobs:      jobClient,
+		byEvent:   make(map[string][]uint),
+		listening: make(map[string]bool),
+	}
+}
+
+// Reload rebuilds the subscriptions from the active workflows in the database.
+// Call it whenever a workflow is created, edited, paused or deleted.
+func (t *WorkflowTriggers) Reload() {
+	t.reloadSchedules()
+
+	var workflows []models.Workflow
+	if err := t.DB.Where("trigger_type = ? AND status = ?", models.TriggerEvent, models.WorkflowStatusActive).
+		Find(&workflows).Error; err != nil {
+		log.Printf("[workflows] Failed to load event triggers: %v", err)
+		return
+	}
+
+	byEvent := make(map[string][]uint)
+	for _, wf := range workflows {
+		// The admin UI saves the event as "event_name".
+		var cfg struct {
+			Event     string `json:"event"`
+			EventName string `json:"event_name"`
+		}
+		if wf.TriggerConfig != nil {
+			_ = json.Unmarshal(wf.TriggerConfig, &cfg)
+		}
+		event := cfg.Event
+		if event == "" {
+			event = cfg.EventName
+		}
+		if event == "" {
+			continue
+		}
+		byEvent[event] = append(byEvent[event], wf.ID)
+	}
+
+	t.mu.Lock()
+	t.byEvent = byEvent
+	var subscribe []string
+	for event := range byEvent {
+		if !t.listening[event] {
+			t.listening[event] = true
+			subscribe = append(subscribe, event)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, event := range subscribe {
+		event := event
+		events.On(event, func(data interface{}) {
+			t.dispatch(event, data)
+		})
+	}
+}
+
+// reloadSchedules lists active scheduled workflows in cron.RegisteredTasks. The
+// workflow:check-scheduled task is what actually fires them.
+func (t *WorkflowTriggers) reloadSchedules() {
+	var workflows []models.Workflow
+	if err := t.DB.Where("trigger_type = ? AND status = ?", models.TriggerSchedule, models.WorkflowStatusActive).
+		Order("id ASC").Find(&workflows).Error; err != nil {
+		log.Printf("[workflows] Failed to load schedule triggers: %v", err)
+		return
+	}
+
+	tasks := make([]cron.Task, 0, len(workflows))
+	for _, wf := range workflows {
+		cfg, _, err := jobs.ParseWorkflowSchedule(wf.TriggerConfig)
+		if err != nil {
+			log.Printf("[workflows] Workflow %d has an invalid schedule: %v", wf.ID, err)
+			continue
+		}
+		tasks = append(tasks, cron.Task{
+			Name:     fmt.Sprintf("Workflow: %s", wf.Name),
+			Schedule: cfg.Cron,
+			Type:     jobs.TypeWorkflowCheckScheduled,
+		})
+	}
+	cron.SetWorkflowTasks(tasks)
+}
+
+func (t *WorkflowTriggers) dispatch(event string, data interface{}) {
+	t.mu.RLock()
+	ids := t.byEvent[event]
+	t.mu.RUnlock()
+	if len(ids) == 0 {
+		return
+	}
+
+	if t.Jobs == nil {
+		log.Printf("[workflows] Job queue not configured, skipping %q triggers", event)
+		return
+	}
+
+	contactID := eventContactID(t.DB, data)
+	if contactID == 0 {
+		log.Printf("[workflows] No contact in %q payload, skipping triggers", event)
+		return
+	}
+
+	for _, id := range ids {
+		var wf models.Workflow
+		if err := t.DB.First(&wf, id).Error; err != nil || wf.Status != models.WorkflowStatusActive {
+			continue
+		}
+		// A workflow whose own actions emit its trigger event would otherwise
+		// start itself again for the same contact, forever.
+		var running int64
+		t.DB.Model(&models.WorkflowExecution{}).
+			Where("workflow_id = ? AND contact_id = ? AND status = ?", wf.ID, contactID, models.ExecutionRunning).
+			Count(&running)
+		if running > 0 {
+			log.Printf("[workflows] Workflow %d is already running for contact %d, skipping %q", wf.ID, contactID, event)
+			continue
+		}
+		if _, err := jobs.StartWorkflow(t.DB, t.Jobs, wf, contactID, event); err != nil {
+			log.Printf("[workflows] Failed to start workflow %d on %q: %v", wf.ID, event, err)
+		}
+	}
+}
+
+// eventContactID extracts the contact an event is about. Payloads are either
+// maps carrying "contact_id" or models with a ContactID field; a Contact
+// payload is the contact itself.
+func eventContactID(db *gorm.DB, data interface{}) uint {
+	switch v := data.(type) {
+	case models.Contact:
+		return v.ID
+	case *models.Contact:
+		return v.ID
+	case models.LessonProgress:
+		var enrollment models.CourseEnrollment
+		if err := db.First(&enrollment, v.EnrollmentID).Error; err != nil {
+			return 0
+		}
+		return enrollment.ContactID
+	case map[string]interface{}:
+		if id := toUint(v["contact_id"]); id > 0 {
+			return id
+		}
+		if email, ok := v["email"].(string); ok && email != "" {
+			var contact models.Contact
+			if err := db.Where("tenant_id = 1 AND email = ?", email).First(&contact).Error; err == nil {
+				return contact.ID
+			}
+		}
+		return 0
+	}
+
+	// Any other model: look for a contact_id field in its JSON form.
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return 0
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return 0
+	}
+	return toUint(m["contact_id"])
+}
@@ -2,6 +2,7 @@
 
 import { useState, useMemo } from "react";
 import { useParams } from "next/navigation";
+import { toast } from "sonner";
 import Link from "next/link";
 import {
   ArrowLeft,
@@ -13,12 +14,18 @@ import {
   User,
   Mail,
   FileText,
+  Lock,
 } from "lucide-react";
 import {
   usePublicEventType,
   useAvailableSlots,
   useBookAppointment,
 } from "@/hooks/use-booking";
+import { useAuth } from "@/hooks/use-auth";
+import { useCreateCheckout, useConfirmCheckout } from "@/hooks/use-checkout";
+import { StripeProvider } from "@/components/stripe-provider";
+import { CheckoutForm } from "@/components/checkout-form";
+import type { CheckoutResponse } from "@repo/shared/types";
 
 /* ------------------------------------------------------------------ */
 /*  Helpers                                                           */
@@ -273,6 +280,7 @@ function BookingForm({
   slug,
   date,
   time,
+  paid,
   accentColor,
   onSuccess,
   onBack,
@@ -280,29 +288,111 @@ function BookingForm({
   slug: string;
   date: string;
   time: string;
+  paid: boolean;
   accentColor: string;
   onSuccess: (appt: { start_at: string; name: string }) => void;
   onBack: () => void;
 }) {
-  const [name, setName] = useState("");
-  const [email, setEmail] = useState("");
+  const { user, isAuthenticated } = useAuth();
+  const [name, setName] = useState(
+    user ? `${user.first_name} ${user.last_name}`.trim() : ""
+  );
+  const [email, setEmail] = useState(user?.email ?? "");
   const [notes, setNotes] = useState("");
+  const [checkoutData, setCheckoutData] = useState<CheckoutResponse | null>(null);
   const bookMutation = useBookAppointment();
+  const { mutate: createCheckout, isPending: checkingOut } = useCreateCheckout();
+  const { mutateAsync: confirmCheckout } = useConfirmCheckout();
+
+  // Build the start_at: combine date + time
+  const startAt = time.includes("T") ? time : `${date}T${time}`;
 
   function handleSubmit(e: React.FormEvent) {
     e.preventDefault();
 
-    // Build the start_at: combine date + time
-    const startAt = time.includes("T") ? time : `${date}T${time}`;
-
     bookMutation.mutate(
       { slug, start_at: startAt, name, email, notes: notes || undefined },
       {
-        onSuccess: () => onSuccess({ start_at: startAt, name }),
+        onSuccess: ({ appointment, requires_payment }) => {
+          if (!requires_payment) {
+            onSuccess({ start_at: startAt, name });
+            return;
+          }
+          // The slot is held while the client pays
+          createCheckout(
+            { type: "booking", appointment_id: appointment.id },
+            { onSuccess: (data) => setCheckoutData(data) }
+          );
+        },
       }
     );
   }
 
+  // Paid bookings are paid through checkout, which needs an account
+  if (paid && !isAuthenticated) {
+    return (
+      <div className="space-y-4">
+        <button
+          type="button"
+          onClick={onBack}
+          className="inline-flex items-center gap-1.5 text-sm text-text-secondary hover:text-foreground transition-colors mb-2"
+        >
+          <ArrowLeft className="h-3.5 w-3.5" />
+          Change time
+        </button>
+        <div className="rounded-lg border border-border bg-bg-elevated px-4 py-6 text-center">
+          <Lock className="mx-auto h-6 w-6 text-text-muted" />
+          <p className="mt-3 text-sm text-text-secondary">
+            Log in to book and pay for this session.
+          </p>
+          <Link
+            href={`/auth/login?redirect=/book/${slug}`}
+            className="mt-4 inline-flex rounded-lg px-4 py-2 text-sm font-semibold text-white"
+            style={{ backgroundColor: accentColor }}
+          >
+            Log in to continue
+          </Link>
+        </div>
+      </div>
+    );
+  }
+
+  if (checkoutData) {
+    return (
+      <div className="space-y-4">
+        <div className="rounded-lg border border-border bg-bg-elevated px-4 py-3 text-sm text-text-secondary">
+          <span className="font-medium text-foreground">{formatDateLong(date)}</span>
+          {" at "}
+          <span className="font-medium text-foreground">{formatTime(time)}</span>
+          {checkoutData.hold_expires_at && (
+            <span className="block mt-1 text-xs text-text-muted">
+              We&apos;re holding this time until {formatTime(checkoutData.hold_expires_at)}.
+            </span>
+          )}
+        </div>
+        <StripeProvider
+          clientSecret={checkoutData.client_secret}
+          publishableKey={checkoutData.publishable_key}
+        >
+          <CheckoutForm
+            amount={checkoutData.amount}
+            currency={checkoutData.currency}
+            orderId={checkoutData.order_id}
+            onSuccess={async (orderId) => {
+              try {
+                await confirmCheckout(orderId);
+              } catch {
+                // Webhook will handle it as fallback
+              }
+              onSuccess({ start_at: startAt, name });
+            }}
+            onError={(msg) => toast.error(msg)}
+          />
+        </StripeProvider>
+      </div>
+    );
+  }
+
   return (
     <form onSubmit={handleSubmit} className="space-y-4">
       <button
@@ -389,22 +479,25 @@ function BookingForm({
       {/* Error */}
       {bookMutation.isError && (
         <p className="text-sm text-red-400">
-          Something went wrong. Please try again.
+          {(bookMutation.error as any)?.response?.data?.error ||
+            "Something went wrong. Please try again."}
         </p>
       )}
 
       {/* Submit */}
       <button
         type="submit"
-        disabled={bookMutation.isPending}
+        disabled={bookMutation.isPending || checkingOut}
         className="w-full rounded-lg px-4 py-2.5 text-sm font-semibold text-white transition-opacity disabled:opacity-60 flex items-center justify-center gap-2"
         style={{ backgroundColor: accentColor }}
       >
-        {bookMutation.isPending ? (
+        {bookMutation.isPending || checkingOut ? (
           <>
             <Loader2 className="h-4 w-4 animate-spin" />
             Booking...
           </>
+        ) : paid ? (
+          "Continue to Payment"
         ) : (
           "Confirm Booking"
         )}
@@ -672,6 +765,7 @@ export default function BookingPage() {
                 slug={slug}
                 date={selectedDate}
                 time={selectedTime}
+                paid={eventType.price > 0}
                 accentColor={accent}
                 onBack={() => {
                   setSelectedTime("");
@@ -16,11 +16,12 @@ import {
   ChevronRight,
   ChevronLeft,
   ChevronRight as ChevronRightIcon,
+  Lock,
 } from "lucide-react";
 import { useAuth } from "@/hooks/use-auth";
 import { usePublicCourse } from "@/hooks/use-courses";
 import { useStudentCourse, useMarkLessonComplete } from "@/hooks/use-student";
-import type { Lesson } from "@repo/shared/types";
+import type { LessonAccess } from "@repo/shared/types";
 
 const lessonIcons: Record<string, typeof Play> = {
   video: Play,
@@ -50,8 +51,10 @@ export default function LearnPage() {
   const router = useRouter();
   const slug = typeof params.slug === "string" ? params.slug : "";
   const { user, isAuthenticated, isLoading: authLoading } = useAuth();
-  const { data: course, isLoading: courseLoading } = usePublicCourse(slug);
-  const { data: studentData, isLoading: studentLoading } = useStudentCourse(course?.id ?? 0);
+  const { data: publicCourse, isLoading: courseLoading } = usePublicCourse(slug);
+  const { data: studentData, isLoading: studentLoading } = useStudentCourse(publicCourse?.id ?? 0);
+  // The public course withholds lesson content; enrolled students get it from their own course data.
+  const course = studentData?.course ?? publicCourse;
   const { mutate: markComplete, isPending: marking } = useMarkLessonComplete();
 
   const [activeLessonId, setActiveLessonId] = useState<number | null>(null);
@@ -79,6 +82,16 @@ export default function LearnPage() {
     return set;
   }, [studentData]);
 
+  // Drip/sequential lock state per lesson
+  const accessById = useMemo(() => {
+    const map = new Map<number, LessonAccess>();
+    studentData?.lesson_access?.forEach((a) => map.set(a.lesson_id, a));
+    return map;
+  }, [studentData]);
+
+  const activeAccess = activeLesson ? accessById.get(activeLesson.id) : undefined;
+  const activeLocked = !!activeAccess && !activeAccess.unlocked;
+
   // Expand all modules by default once course loads
   useMemo(() => {
     if (course?.modules && expandedModules.size === 0) {
@@ -220,6 +233,7 @@ export default function LearnPage() {
                           const LIcon = lessonIcons[lesson.type] || FileText;
                           const isActive = lesson.id === activeLesson?.id;
                           const isDone = completedIds.has(lesson.id);
+                          const isLocked = accessById.get(lesson.id)?.unlocked === false;
 
                           return (
                             <button
@@ -233,12 +247,14 @@ export default function LearnPage() {
                             >
                               {isDone ? (
                                 <CheckCircle className="h-3.5 w-3.5 text-green-400 shrink-0" />
+                              ) : isLocked ? (
+                                <Lock className="h-3.5 w-3.5 text-text-muted shrink-0" />
                               ) : (
                                 <LIcon className={`h-3.5 w-3.5 shrink-0 ${isActive ? "text-accent" : "text-text-muted"}`} />
                               )}
                               <span
                                 className={`text-xs flex-1 truncate ${
-                                  isActive ? "text-accent font-medium" : "text-foreground"
+                                  isActive ? "text-accent font-medium" : isLocked ? "text-text-muted" : "text-foreground"
                                 }`}
                               >
                                 {lesson.title}
@@ -275,6 +291,25 @@ export default function LearnPage() {
 
         {activeLesson ? (
           <div className="max-w-4xl mx-auto px-6 py-8">
+            {/* Locked lesson */}
+            {activeLocked && (
+              <div className="mb-8 flex flex-col items-center justify-center rounded-xl border border-border bg-bg-elevated px-6 py-16 text-center">
+                <Lock className="h-8 w-8 text-text-muted mb-3" />
+                <h2 className="text-lg font-semibold text-foreground">This lesson is locked</h2>
+                <p className="mt-1 text-sm text-text-muted">
+                  {activeAccess?.locked_reason === "previous_lesson"
+                    ? "Complete the previous lesson to unlock it."
+                    : activeAccess?.unlocks_at
+                      ? `Unlocks on ${new Date(activeAccess.unlocks_at).toLocaleDateString(undefined, {
+                          month: "long",
+                          day: "numeric",
+                          year: "numeric",
+                        })}.`
+                      : "This lesson isn't available yet."}
+                </p>
+              </div>
+            )}
+
             {/* Video player */}
             {activeLesson.type === "video" && !!activeLesson.video_url && (
               <div className="aspect-video rounded-xl overflow-hidden bg-black mb-8">
@@ -346,7 +381,12 @@ export default function LearnPage() {
                 Previous
               </button>
 
-              {!completedIds.has(activeLesson.id) ? (
+              {activeLocked ? (
+                <span className="inline-flex items-center gap-1.5 text-sm text-text-muted font-medium">
+                  <Lock className="h-4 w-4" />
+                  Locked
+                </span>
+              ) : !completedIds.has(activeLesson.id) ? (
                 <button
                   onClick={handleMarkComplete}
                   disabled={marking}
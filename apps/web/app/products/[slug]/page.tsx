@@ -220,6 +220,8 @@ export default function ProductDetailPage() {
                   amount={checkoutData.amount}
                   currency={checkoutData.currency}
                   orderId={checkoutData.order_id}
+                  intent={checkoutData.intent}
+                  trialDays={checkoutData.trial_days}
                   onSuccess={async (orderId) => {
                     toast.success("Payment successful!");
                     try {
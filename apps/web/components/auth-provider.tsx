@@ -9,7 +9,10 @@ interface AuthContextType {
   user: User | null;
   isAuthenticated: boolean;
   isLoading: boolean;
-  login: (email: string, password: string) => Promise<void>;
+  // login resolves with a challenge token when the account has 2FA on; pass
+  // it to loginTwoFactor with the user's code to finish signing in.
+  login: (email: string, password: string) => Promise<string | null>;
+  loginTwoFactor: (challengeToken: string, code: string) => Promise<void>;
   register: (data: { first_name: string; last_name: string; email: string; password: string }) => Promise<void>;
   logout: () => void;
 }
@@ -18,7 +21,8 @@ const AuthContext = createContext<AuthContextType>({
   user: null,
   isAuthenticated: false,
   isLoading: true,
-  login: async () => {},
+  login: async () => null,
+  loginTwoFactor: async () => {},
   register: async () => {},
   logout: () => {},
 });
@@ -50,6 +54,17 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
 
   const login = useCallback(async (email: string, password: string) => {
     const { data } = await api.post("/api/auth/login", { email, password });
+    if (data.data?.two_factor_required) {
+      return data.data.challenge_token as string;
+    }
+    const tokens = data.data?.tokens;
+    setTokens(tokens.access_token, tokens.refresh_token);
+    setUser(data.data?.user ?? null);
+    return null;
+  }, []);
+
+  const loginTwoFactor = useCallback(async (challengeToken: string, code: string) => {
+    const { data } = await api.post("/api/auth/login/2fa", { challenge_token: challengeToken, code });
     const tokens = data.data?.tokens;
     setTokens(tokens.access_token, tokens.refresh_token);
     setUser(data.data?.user ?? null);
@@ -68,7 +83,7 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
   }, []);
 
   return (
-    <AuthContext.Provider value={{ user, isAuthenticated: !!user, isLoading, login, register, logout }}>
+    <AuthContext.Provider value={{ user, isAuthenticated: !!user, isLoading, login, loginTwoFactor, register, logout }}>
       {children}
     </AuthContext.Provider>
   );
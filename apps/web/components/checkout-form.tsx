@@ -12,6 +12,8 @@ interface CheckoutFormProps {
   amount: number;
   currency: string;
   orderId: number;
+  intent?: "payment" | "setup";
+  trialDays?: number;
   onSuccess: (orderId: number) => void;
   onError: (message: string) => void;
 }
@@ -20,6 +22,8 @@ export function CheckoutForm({
   amount,
   currency,
   orderId,
+  intent = "payment",
+  trialDays = 0,
   onSuccess,
   onError,
 }: CheckoutFormProps) {
@@ -38,13 +42,18 @@ export function CheckoutForm({
 
     setIsProcessing(true);
 
-    const { error } = await stripe.confirmPayment({
+    const options = {
       elements,
       confirmParams: {
         return_url: `${window.location.origin}/checkout/success?order_id=${orderId}`,
       },
-      redirect: "if_required",
-    });
+      redirect: "if_required" as const,
+    };
+    // Free trials save the card for the first renewal instead of charging it
+    const { error } =
+      intent === "setup"
+        ? await stripe.confirmSetup(options)
+        : await stripe.confirmPayment(options);
 
     if (error) {
       onError(error.message ?? "Payment failed");
@@ -90,7 +99,9 @@ export function CheckoutForm({
           ) : (
             <>
               <Lock className="h-4 w-4" />
-              Pay {formattedAmount}
+              {intent === "setup"
+                ? `Start ${trialDays}-day free trial`
+                : `Pay ${formattedAmount}`}
             </>
           )}
         </button>
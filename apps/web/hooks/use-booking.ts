@@ -49,7 +49,11 @@ export function useBookAppointment() {
       notes?: string;
     }) => {
       const { data } = await api.post(`/api/book/${slug}`, body);
-      return data.data as Appointment;
+      // Paid event types return a pending appointment to pay for via checkout
+      return {
+        appointment: data.data as Appointment,
+        requires_payment: !!data.requires_payment,
+      };
     },
   });
 }
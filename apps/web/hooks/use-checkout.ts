@@ -6,9 +6,10 @@ import { api } from "@/lib/api";
 import type { CheckoutResponse, CheckoutStatus } from "@repo/shared/types";
 
 interface CheckoutInput {
-  type: "product" | "course";
+  type: "product" | "course" | "booking";
   product_id?: number;
   course_id?: number;
+  appointment_id?: number;
   price_id?: number;
   coupon_code?: string;
 }
@@ -1,8 +1,22 @@
 "use client";
 
-import { useQuery } from "@tanstack/react-query";
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { toast } from "sonner";
 import { api } from "@/lib/api";
-import type { Space } from "@repo/shared/types";
+import type {
+  CommunityEvent,
+  CommunityNotification,
+  CommunityNotificationPreference,
+  ContentReport,
+  EventAttendee,
+  ReactionType,
+  ReportReason,
+  Reaction,
+  Space,
+  Thread,
+  ThreadReply,
+  ThreadType,
+} from "@repo/shared/types";
 
 export function usePublicSpaces() {
   return useQuery({
@@ -24,3 +38,371 @@ export function usePublicSpace(slug: string) {
     enabled: !!slug,
   });
 }
+
+// --- Member (authenticated) ---
+
+interface MemberSpaceData {
+  space: Space;
+  can_read: boolean;
+  can_post: boolean;
+  following: boolean;
+}
+
+interface ThreadData {
+  thread: Thread;
+  my_reactions: Reaction[];
+  following: boolean;
+}
+
+export function useMemberSpaces() {
+  return useQuery({
+    queryKey: ["member-spaces"],
+    queryFn: async () => {
+      const { data } = await api.get("/api/student/community/spaces");
+      return data.data as Space[];
+    },
+  });
+}
+
+export function useMemberSpace(slug: string, enabled = true) {
+  return useQuery({
+    queryKey: ["member-spaces", slug],
+    queryFn: async () => {
+      const { data } = await api.get(`/api/student/community/spaces/${slug}`);
+      return {
+        space: data.data,
+        can_read: data.can_read,
+        can_post: data.can_post,
+        following: data.following,
+      } as MemberSpaceData;
+    },
+    enabled: !!slug && enabled,
+  });
+}
+
+export function useJoinSpace() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (spaceId: number) => {
+      await api.post(`/api/student/community/spaces/${spaceId}/join`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["member-spaces"] });
+      toast.success("Welcome to the space!");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to join space"),
+  });
+}
+
+export function useLeaveSpace() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (spaceId: number) => {
+      await api.delete(`/api/student/community/spaces/${spaceId}/join`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["member-spaces"] });
+      toast.success("You left the space");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to leave space"),
+  });
+}
+
+export function useSpaceThreads(spaceId: number, params: { page?: number; sort?: string; type?: string } = {}) {
+  return useQuery({
+    queryKey: ["space-threads", spaceId, params],
+    queryFn: async () => {
+      const { data } = await api.get(`/api/student/community/spaces/${spaceId}/threads`, { params });
+      return data as { data: Thread[]; meta: { total: number; page: number; page_size: number; pages: number } };
+    },
+    enabled: spaceId > 0,
+  });
+}
+
+export function useThread(threadId: number) {
+  return useQuery({
+    queryKey: ["threads", threadId],
+    queryFn: async () => {
+      const { data } = await api.get(`/api/student/community/threads/${threadId}`);
+      return { thread: data.data, my_reactions: data.my_reactions, following: data.following } as ThreadData;
+    },
+    enabled: threadId > 0,
+  });
+}
+
+export function useCreateThread() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ spaceId, ...body }: { spaceId: number; title: string; content: unknown; type?: ThreadType }) => {
+      const { data } = await api.post(`/api/student/community/spaces/${spaceId}/threads`, body);
+      return data.data as Thread;
+    },
+    onSuccess: (_data, vars) => {
+      qc.invalidateQueries({ queryKey: ["space-threads", vars.spaceId] });
+      toast.success("Thread posted");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to post thread"),
+  });
+}
+
+export function useUpdateThread() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ threadId, ...body }: { threadId: number; title?: string; content?: unknown }) => {
+      const { data } = await api.put(`/api/student/community/threads/${threadId}`, body);
+      return data.data as Thread;
+    },
+    onSuccess: (data) => {
+      qc.invalidateQueries({ queryKey: ["threads", data.id] });
+      qc.invalidateQueries({ queryKey: ["space-threads", data.space_id] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update thread"),
+  });
+}
+
+export function useDeleteThread() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (threadId: number) => {
+      await api.delete(`/api/student/community/threads/${threadId}`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["space-threads"] });
+      toast.success("Thread deleted");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to delete thread"),
+  });
+}
+
+export function useCreateReply() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ threadId, ...body }: { threadId: number; content: unknown; parent_id?: number }) => {
+      const { data } = await api.post(`/api/student/community/threads/${threadId}/replies`, body);
+      return data.data as ThreadReply;
+    },
+    onSuccess: (_data, vars) => {
+      qc.invalidateQueries({ queryKey: ["threads", vars.threadId] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to post reply"),
+  });
+}
+
+export function useUpdateReply() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ replyId, content }: { replyId: number; content: unknown }) => {
+      const { data } = await api.put(`/api/student/community/replies/${replyId}`, { content });
+      return data.data as ThreadReply;
+    },
+    onSuccess: (data) => {
+      qc.invalidateQueries({ queryKey: ["threads", data.thread_id] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update reply"),
+  });
+}
+
+export function useDeleteReply() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ replyId }: { replyId: number; threadId: number }) => {
+      await api.delete(`/api/student/community/replies/${replyId}`);
+    },
+    onSuccess: (_data, vars) => {
+      qc.invalidateQueries({ queryKey: ["threads", vars.threadId] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to delete reply"),
+  });
+}
+
+export function useToggleReaction() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({
+      reactable_type,
+      reactable_id,
+      type,
+    }: {
+      reactable_type: "thread" | "reply";
+      reactable_id: number;
+      type?: ReactionType;
+      threadId: number;
+    }) => {
+      const { data } = await api.post("/api/student/community/reactions", { reactable_type, reactable_id, type });
+      return data as { action: "added" | "removed"; data?: Reaction };
+    },
+    onSuccess: (_data, vars) => {
+      qc.invalidateQueries({ queryKey: ["threads", vars.threadId] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to react"),
+  });
+}
+
+export function useReportContent() {
+  return useMutation({
+    mutationFn: async (body: {
+      reportable_type: "thread" | "reply";
+      reportable_id: number;
+      reason: ReportReason;
+      details?: string;
+    }) => {
+      const { data } = await api.post("/api/student/community/reports", body);
+      return data as { data: ContentReport; message: string };
+    },
+    onSuccess: (data) => {
+      toast.success(data.message);
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to report content"),
+  });
+}
+
+export function useMemberEvents(spaceId?: number) {
+  return useQuery({
+    queryKey: ["member-events", spaceId ?? "all"],
+    queryFn: async () => {
+      const { data } = await api.get("/api/student/community/events", {
+        params: spaceId ? { space_id: spaceId } : undefined,
+      });
+      return data.data as CommunityEvent[];
+    },
+  });
+}
+
+export function useRegisterForEvent() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (eventId: number) => {
+      const { data } = await api.post(`/api/student/community/events/${eventId}/register`);
+      return data.data as EventAttendee;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["member-events"] });
+      toast.success("You're registered!");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to register"),
+  });
+}
+
+export function useCancelEventRegistration() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (eventId: number) => {
+      await api.delete(`/api/student/community/events/${eventId}/register`);
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["member-events"] });
+      toast.success("Registration cancelled");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to cancel registration"),
+  });
+}
+
+// --- Notifications ---
+
+export function useNotifications(params: { unread?: boolean; page?: number } = {}) {
+  return useQuery({
+    queryKey: ["community-notifications", params],
+    queryFn: async () => {
+      const sp = new URLSearchParams({ page: String(params.page ?? 1) });
+      if (params.unread) sp.set("unread", "true");
+      const { data } = await api.get(`/api/student/community/notifications?${sp}`);
+      return data as {
+        data: CommunityNotification[];
+        meta: { total: number; page: number; page_size: number; pages: number; unread: number };
+      };
+    },
+  });
+}
+
+export function useMarkNotificationRead() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (notificationId: number) => {
+      const { data } = await api.post(`/api/student/community/notifications/${notificationId}/read`);
+      return data.data as CommunityNotification;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["community-notifications"] });
+    },
+  });
+}
+
+export function useMarkAllNotificationsRead() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async () => {
+      await api.post("/api/student/community/notifications/read-all");
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["community-notifications"] });
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update notifications"),
+  });
+}
+
+export function useNotificationPreferences() {
+  return useQuery({
+    queryKey: ["community-notification-preferences"],
+    queryFn: async () => {
+      const { data } = await api.get("/api/student/community/notification-preferences");
+      return data.data as CommunityNotificationPreference;
+    },
+  });
+}
+
+export function useUpdateNotificationPreferences() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async (body: Partial<CommunityNotificationPreference>) => {
+      const { data } = await api.put("/api/student/community/notification-preferences", body);
+      return data.data as CommunityNotificationPreference;
+    },
+    onSuccess: () => {
+      qc.invalidateQueries({ queryKey: ["community-notification-preferences"] });
+      toast.success("Notification settings saved");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to save settings"),
+  });
+}
+
+// --- Follows ---
+
+export function useFollowSpace() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ spaceId, follow }: { spaceId: number; follow: boolean }) => {
+      const path = `/api/student/community/spaces/${spaceId}/follow`;
+      if (follow) {
+        await api.post(path);
+      } else {
+        await api.delete(path);
+      }
+      return follow;
+    },
+    onSuccess: (follow) => {
+      qc.invalidateQueries({ queryKey: ["member-spaces"] });
+      toast.success(follow ? "You'll be notified about new threads" : "Unfollowed space");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update follow"),
+  });
+}
+
+export function useFollowThread() {
+  const qc = useQueryClient();
+  return useMutation({
+    mutationFn: async ({ threadId, follow }: { threadId: number; follow: boolean }) => {
+      const path = `/api/student/community/threads/${threadId}/follow`;
+      if (follow) {
+        await api.post(path);
+      } else {
+        await api.delete(path);
+      }
+      return follow;
+    },
+    onSuccess: (follow, vars) => {
+      qc.invalidateQueries({ queryKey: ["threads", vars.threadId] });
+      toast.success(follow ? "You'll be notified about replies" : "Unfollowed thread");
+    },
+    onError: (err: any) => toast.error(err.response?.data?.error || "Failed to update follow"),
+  });
+}
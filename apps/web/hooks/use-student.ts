@@ -3,12 +3,13 @@
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { toast } from "sonner";
 import { api } from "@/lib/api";
-import type { Course, CourseEnrollment, LessonProgress } from "@repo/shared/types";
+import type { Course, CourseEnrollment, LessonAccess, LessonProgress } from "@repo/shared/types";
 
 interface StudentCourseData {
   course: Course;
   enrollment: CourseEnrollment;
   lesson_progresses: LessonProgress[];
+  lesson_access: LessonAccess[];
 }
 
 export function useStudentCourses() {
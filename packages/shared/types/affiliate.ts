@@ -1,5 +1,5 @@
 export type AffiliateAccountStatus = "pending" | "active" | "suspended";
-export type CommissionStatus = "pending" | "approved" | "paid" | "rejected";
+export type CommissionStatus = "pending" | "approved" | "paid" | "rejected" | "reversed";
 export type PayoutStatus = "pending" | "processing" | "completed";
 
 export interface AffiliateProgram {
@@ -0,0 +1,16 @@
+export interface ApiKey {
+  id: number;
+  tenant_id: number;
+  user_id: number | null;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  expires_at: string | null;
+  last_used_at: string | null;
+  last_used_ip: string;
+  revoked_at: string | null;
+  created_by_id: number;
+  created_at: string;
+  updated_at: string;
+  user?: { id: number; first_name: string; last_name: string; email: string };
+}
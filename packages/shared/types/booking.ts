@@ -1,5 +1,6 @@
 export type CalendarStatus = "active" | "inactive";
-export type AppointmentStatus = "confirmed" | "cancelled" | "rescheduled" | "completed";
+export type AppointmentStatus = "pending" | "confirmed" | "cancelled" | "rescheduled" | "completed";
+export type AssignmentStrategy = "round_robin" | "least_booked";
 
 export interface Calendar {
   id: number;
@@ -9,10 +10,12 @@ export interface Calendar {
   description: string;
   timezone: string;
   status: CalendarStatus;
+  google_calendar_id: string;
   created_at: string;
   updated_at: string;
   event_types?: BookingEventType[];
   availabilities?: Availability[];
+  overrides?: AvailabilityOverride[];
 }
 
 export interface BookingEventType {
@@ -29,9 +32,19 @@ export interface BookingEventType {
   price: number;
   product_id: number | null;
   color: string;
+  reminder_offsets: number[];
+  follow_up_enabled: boolean;
+  follow_up_delay_minutes: number;
+  follow_up_subject: string;
+  follow_up_message: string;
+  refund_window_hours: number;
+  min_notice_minutes: number;
+  max_advance_days: number;
+  assignment_strategy: AssignmentStrategy;
   created_at: string;
   updated_at: string;
   calendar?: Calendar;
+  hosts?: Calendar[];
 }
 
 export interface Availability {
@@ -43,6 +56,18 @@ export interface Availability {
   end_time: string;
 }
 
+export interface AvailabilityOverride {
+  id: number;
+  tenant_id: number;
+  calendar_id: number;
+  date: string;
+  start_time: string;
+  end_time: string;
+  reason: string;
+  created_at: string;
+  updated_at: string;
+}
+
 export interface Appointment {
   id: number;
   tenant_id: number;
@@ -55,8 +80,30 @@ export interface Appointment {
   meeting_url: string;
   google_event_id: string;
   zoom_meeting_id: string;
+  sequence: number;
+  order_id: number | null;
+  hold_expires_at: string | null;
+  calendar_id: number | null;
   created_at: string;
   updated_at: string;
   event_type?: BookingEventType;
+  calendar?: Calendar;
   contact?: { id: number; first_name: string; last_name: string; email: string; avatar_url: string };
 }
+
+export type AppointmentReminderKind = "reminder" | "follow_up";
+export type AppointmentReminderStatus = "scheduled" | "sent" | "cancelled";
+
+export interface AppointmentReminder {
+  id: number;
+  tenant_id: number;
+  appointment_id: number;
+  kind: AppointmentReminderKind;
+  offset_minutes: number;
+  send_at: string;
+  status: AppointmentReminderStatus;
+  task_id: string;
+  sent_at: string | null;
+  created_at: string;
+  updated_at: string;
+}
@@ -38,6 +38,7 @@ export interface Price {
   interval: PriceInterval | "";
   trial_days: number;
   sort_order: number;
+  stripe_price_id: string;
   created_at: string;
   updated_at: string;
 }
@@ -77,6 +78,10 @@ export interface Order {
   coupon_id: number | null;
   metadata: Record<string, unknown> | null;
   paid_at: string | null;
+  referred_by_account_id: number | null;
+  referral_link_id: number | null;
+  affiliate_account_id: number | null;
+  subscription_id: number | null;
   created_at: string;
   updated_at: string;
   contact?: Contact;
@@ -126,7 +131,7 @@ export interface Coupon {
 
 // --- Subscriptions ---
 
-export type SubStatus = "active" | "past_due" | "cancelled" | "paused";
+export type SubStatus = "incomplete" | "trialing" | "active" | "past_due" | "cancelled" | "paused";
 
 export interface Subscription {
   id: number;
@@ -141,6 +146,7 @@ export interface Subscription {
   current_period_end: string;
   cancelled_at: string | null;
   cancel_at_period_end: boolean;
+  trial_ends_at: string | null;
   created_at: string;
   updated_at: string;
   contact?: Contact;
@@ -160,10 +166,15 @@ export interface CheckoutRequest {
 
 export interface CheckoutResponse {
   client_secret: string;
+  intent?: "payment" | "setup";
   order_id: number;
   order_number: string;
+  subscription_id?: number;
+  appointment_id?: number;
+  hold_expires_at?: string;
   amount: number;
   currency: string;
+  trial_days?: number;
   publishable_key: string;
 }
 
@@ -1,4 +1,5 @@
 import type { Contact } from "./contact";
+import type { User } from "./user";
 
 // --- Spaces ---
 
@@ -15,10 +16,13 @@ export interface Space {
   sort_order: number;
   icon: string;
   color: string;
+  blocked_keywords: string[] | null;
+  require_first_post_approval: boolean;
   created_at: string;
   updated_at: string;
   member_count?: number;
   thread_count?: number;
+  membership?: CommunityMember;
 }
 
 // --- Members ---
@@ -33,6 +37,7 @@ export interface CommunityMember {
   role: MemberRole;
   joined_at: string;
   muted_until: string | null;
+  banned_at: string | null;
   created_at: string;
   contact?: Contact;
 }
@@ -41,6 +46,7 @@ export interface CommunityMember {
 
 export type ThreadType = "discussion" | "question" | "announcement";
 export type ThreadStatus = "open" | "closed" | "pinned";
+export type ModerationStatus = "approved" | "pending" | "hidden";
 
 export interface Thread {
   id: number;
@@ -51,6 +57,7 @@ export interface Thread {
   content: unknown;
   type: ThreadType;
   status: ThreadStatus;
+  moderation_status: ModerationStatus;
   like_count: number;
   reply_count: number;
   last_activity_at: string;
@@ -69,6 +76,7 @@ export interface ThreadReply {
   author_id: number;
   content: unknown;
   parent_id: number | null;
+  moderation_status: ModerationStatus;
   like_count: number;
   created_at: string;
   updated_at: string;
@@ -89,6 +97,106 @@ export interface Reaction {
   created_at: string;
 }
 
+// --- Moderation ---
+
+export type ReportReason = "spam" | "abuse" | "off_topic" | "other";
+export type ReportStatus = "open" | "resolved" | "dismissed";
+
+export interface ContentReport {
+  id: number;
+  tenant_id: number;
+  space_id: number;
+  reportable_type: "thread" | "reply";
+  reportable_id: number;
+  reporter_id: number;
+  reason: ReportReason;
+  details: string;
+  status: ReportStatus;
+  resolved_at: string | null;
+  created_at: string;
+  updated_at: string;
+  reporter?: Contact;
+}
+
+export interface ModerationQueueItem {
+  type: "thread" | "reply";
+  id: number;
+  space_id: number;
+  thread_id: number;
+  title: string;
+  content: unknown;
+  moderation_status: ModerationStatus;
+  author?: Contact;
+  reports: ContentReport[];
+  created_at: string;
+}
+
+export type ModerationAction =
+  | "hold"
+  | "approve"
+  | "hide"
+  | "delete"
+  | "dismiss"
+  | "mute"
+  | "unmute"
+  | "ban"
+  | "unban";
+
+export interface ModerationLog {
+  id: number;
+  tenant_id: number;
+  space_id: number;
+  action: ModerationAction;
+  target_type: "thread" | "reply" | "member" | "report";
+  target_id: number;
+  actor_user_id: number | null;
+  actor_contact_id: number | null;
+  reason: string;
+  metadata: Record<string, unknown> | null;
+  created_at: string;
+  actor_user?: User;
+}
+
+// --- Notifications ---
+
+export type FollowableType = "space" | "thread";
+export type CommunityNotificationType = "reply" | "mention" | "announcement" | "new_thread";
+export type DigestFrequency = "off" | "daily" | "weekly";
+
+// Mentions are written into post content as @[Name](contactId).
+export interface CommunityNotification {
+  id: number;
+  tenant_id: number;
+  contact_id: number;
+  type: CommunityNotificationType;
+  actor_id: number | null;
+  space_id: number;
+  thread_id: number;
+  reply_id: number | null;
+  title: string;
+  body: string;
+  url: string;
+  read_at: string | null;
+  emailed_at: string | null;
+  digested_at: string | null;
+  created_at: string;
+  actor?: Contact;
+}
+
+export interface CommunityNotificationPreference {
+  id: number;
+  tenant_id: number;
+  contact_id: number;
+  email_replies: boolean;
+  email_mentions: boolean;
+  email_announcements: boolean;
+  auto_follow: boolean;
+  digest: DigestFrequency;
+  last_digest_at: string | null;
+  created_at: string;
+  updated_at: string;
+}
+
 // --- Community Events ---
 
 export type CommunityEventType = "virtual" | "in_person";
@@ -111,6 +219,7 @@ export interface CommunityEvent {
   updated_at: string;
   attendee_count?: number;
   attendees?: EventAttendee[];
+  registration?: EventAttendee;
 }
 
 export interface EventAttendee {
@@ -13,6 +13,10 @@ export interface Contact {
   custom_fields: Record<string, unknown> | null;
   user_id: number | null;
   last_activity_at: string | null;
+  referred_by_account_id: number | null;
+  referral_link_id: number | null;
+  referred_at: string | null;
+  stripe_customer_id: string;
   created_at: string;
   updated_at: string;
   tags?: Tag[];
@@ -4,6 +4,7 @@ import type { Contact } from "./contact";
 
 export type CourseStatus = "draft" | "published" | "archived";
 export type CourseAccessType = "free" | "paid" | "membership";
+export type DripMode = "enrollment" | "sequential" | "date";
 
 export interface Course {
   id: number;
@@ -17,6 +18,7 @@ export interface Course {
   currency: string;
   status: CourseStatus;
   access_type: CourseAccessType;
+  drip_mode: DripMode;
   product_id: number | null;
   instructor_id: number | null;
   published_at: string | null;
@@ -58,6 +60,7 @@ export interface Lesson {
   sort_order: number;
   is_free_preview: boolean;
   drip_delay_days: number;
+  drip_date: string | null;
   created_at: string;
   updated_at: string;
   quizzes?: Quiz[];
@@ -77,6 +80,7 @@ export interface CourseEnrollment {
   completed_at: string | null;
   progress_percentage: number;
   source: string;
+  drip_notified_at: string | null;
   created_at: string;
   updated_at: string;
   contact?: Contact;
@@ -84,6 +88,17 @@ export interface CourseEnrollment {
   lesson_progresses?: LessonProgress[];
 }
 
+// --- Lesson Access ---
+
+export type LessonLockedReason = "not_enrolled" | "drip" | "previous_lesson";
+
+export interface LessonAccess {
+  lesson_id: number;
+  unlocked: boolean;
+  unlocks_at: string | null;
+  locked_reason?: LessonLockedReason;
+}
+
 // --- Lesson Progress ---
 
 export type ProgressStatus = "not_started" | "in_progress" | "completed";
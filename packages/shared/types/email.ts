@@ -7,6 +7,7 @@ export interface EmailList {
   tenant_id: number;
   name: string;
   description: string;
+  topic: string;
   double_optin: boolean;
   welcome_email_id: number | null;
   created_at: string;
@@ -54,7 +55,8 @@ export interface EmailTemplate {
 
 // --- Email Campaigns ---
 
-export type CampaignStatus = "draft" | "scheduled" | "sending" | "sent" | "cancelled";
+export type CampaignStatus = "draft" | "scheduled" | "sending" | "testing" | "sent" | "cancelled";
+export type WinnerMetric = "open_rate" | "click_rate";
 
 export interface CampaignStats {
   sent: number;
@@ -62,9 +64,29 @@ export interface CampaignStats {
   opened: number;
   clicked: number;
   bounced: number;
+  complained: number;
   unsubscribed: number;
 }
 
+export interface CampaignVariant {
+  key: string;
+  subject: string;
+  template_id: number | null;
+  html_content: string;
+}
+
+export interface CampaignVariantStats extends CampaignStats {
+  recipients: number;
+  open_rate: number;
+  click_rate: number;
+}
+
+export interface CampaignLinkStats {
+  url: string;
+  clicks: number;
+  unique_clickers: number;
+}
+
 export interface EmailCampaign {
   id: number;
   tenant_id: number;
@@ -83,6 +105,13 @@ export interface EmailCampaign {
   scheduled_at: string | null;
   sent_at: string | null;
   stats: CampaignStats | null;
+  variants: CampaignVariant[] | null;
+  test_percent: number;
+  test_wait_hours: number;
+  winner_metric: WinnerMetric;
+  winning_variant: string;
+  test_ends_at: string | null;
+  variant_stats: Record<string, CampaignVariantStats> | null;
   created_at: string;
   updated_at: string;
   template?: EmailTemplate;
@@ -90,7 +119,7 @@ export interface EmailCampaign {
 
 // --- Email Sends ---
 
-export type SendStatus = "queued" | "sent" | "delivered" | "opened" | "clicked" | "bounced" | "failed";
+export type SendStatus = "queued" | "sent" | "delivered" | "opened" | "clicked" | "bounced" | "complained" | "failed";
 
 export interface EmailSend {
   id: number;
@@ -98,6 +127,7 @@ export interface EmailSend {
   contact_id: number;
   campaign_id: number | null;
   sequence_step_id: number | null;
+  variant: string;
   subject: string;
   status: SendStatus;
   external_id: string;
@@ -123,6 +153,7 @@ export interface EmailSequence {
   description: string;
   trigger: SequenceTrigger;
   trigger_event: string;
+  exit_event: string;
   status: SequenceStatus;
   created_at: string;
   updated_at: string;
@@ -148,7 +179,7 @@ export interface EmailSequenceStep {
 
 // --- Email Sequence Enrollments ---
 
-export type EnrollmentStatus = "active" | "completed" | "cancelled";
+export type EnrollmentStatus = "active" | "completed" | "cancelled" | "exited";
 
 export interface EmailSequenceEnrollment {
   id: number;
@@ -167,6 +198,23 @@ export interface EmailSequenceEnrollment {
   current_step?: EmailSequenceStep;
 }
 
+// --- Suppressions ---
+
+export type SuppressionReason = "unsubscribed_all" | "bounced" | "complained" | "manual";
+
+export interface EmailSuppression {
+  id: number;
+  tenant_id: number;
+  email: string;
+  domain: string;
+  contact_id: number | null;
+  reason: SuppressionReason;
+  source: string;
+  details: string;
+  created_at: string;
+  updated_at: string;
+}
+
 // --- Segments ---
 
 export type SegmentType = "static" | "dynamic";
@@ -3,6 +3,9 @@ export type {
   LoginRequest,
   RegisterRequest,
   AuthResponse,
+  UserSession,
+  TwoFactorStatus,
+  TwoFactorSetup,
 } from "./user";
 
 export type {
@@ -48,6 +51,10 @@ export type {
   EmailCampaign,
   CampaignStatus,
   CampaignStats,
+  CampaignVariant,
+  CampaignVariantStats,
+  CampaignLinkStats,
+  WinnerMetric,
   EmailSend,
   SendStatus,
   EmailSequence,
@@ -61,6 +68,8 @@ export type {
   SegmentRuleGroup,
   SegmentType,
   EmailDashboardStats,
+  EmailSuppression,
+  SuppressionReason,
 } from "./email";
 export type {
   Course,
@@ -73,6 +82,9 @@ export type {
   EnrollStatus,
   LessonProgress,
   ProgressStatus,
+  DripMode,
+  LessonAccess,
+  LessonLockedReason,
   Quiz,
   QuizQuestion,
   QuestionType,
@@ -119,8 +131,20 @@ export type {
   ThreadType,
   ThreadStatus,
   ThreadReply,
+  ModerationStatus,
   Reaction,
   ReactionType,
+  ReportReason,
+  ReportStatus,
+  ContentReport,
+  ModerationQueueItem,
+  ModerationAction,
+  ModerationLog,
+  FollowableType,
+  CommunityNotificationType,
+  DigestFrequency,
+  CommunityNotification,
+  CommunityNotificationPreference,
   CommunityEvent,
   CommunityEventType,
   CommunityEventStatus,
@@ -142,8 +166,13 @@ export type {
   CalendarStatus,
   BookingEventType,
   Availability,
+  AvailabilityOverride,
+  AssignmentStrategy,
   Appointment,
   AppointmentStatus,
+  AppointmentReminder,
+  AppointmentReminderKind,
+  AppointmentReminderStatus,
 } from "./booking";
 export type {
   AffiliateProgram,
@@ -165,4 +194,10 @@ export type {
   WorkflowExecution,
   ExecutionStatus,
 } from "./workflow";
+export type {
+  WebhookEndpoint,
+  WebhookDelivery,
+  WebhookDeliveryStatus,
+} from "./webhook";
+export type { ApiKey } from "./api_key";
 // grit:types
@@ -35,3 +35,31 @@ export interface AuthResponse {
     expires_at: number;
   };
 }
+
+export interface UserSession {
+  id: number;
+  tenant_id: number;
+  user_id: number;
+  device: string;
+  ip_address: string;
+  user_agent: string;
+  last_used_at: string;
+  expires_at: string;
+  revoked_at: string | null;
+  revoked_reason: string;
+  created_at: string;
+  updated_at: string;
+  current: boolean;
+}
+
+export interface TwoFactorStatus {
+  enabled: boolean;
+  enabled_at: string | null;
+  recovery_codes_remaining: number;
+  required: boolean;
+}
+
+export interface TwoFactorSetup {
+  secret: string;
+  otpauth_uri: string;
+}
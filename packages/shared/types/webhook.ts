@@ -0,0 +1,33 @@
+export type WebhookDeliveryStatus = "pending" | "retrying" | "succeeded" | "failed";
+
+export interface WebhookEndpoint {
+  id: number;
+  tenant_id: number;
+  name: string;
+  url: string;
+  description: string;
+  secret: string;
+  events: string[];
+  active: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface WebhookDelivery {
+  id: number;
+  tenant_id: number;
+  endpoint_id: number;
+  event_id: string;
+  event: string;
+  payload?: unknown;
+  status: WebhookDeliveryStatus;
+  attempts: number;
+  response_code: number;
+  response_body?: string;
+  error: string;
+  duration_ms: number;
+  delivered_at: string | null;
+  created_at: string;
+  updated_at: string;
+  endpoint?: Pick<WebhookEndpoint, "id" | "name" | "url">;
+}